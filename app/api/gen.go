@@ -12,9 +12,34 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for AlertDeliveryStatus.
+const (
+	AlertDeliveryStatusFailed  AlertDeliveryStatus = "failed"
+	AlertDeliveryStatusPending AlertDeliveryStatus = "pending"
+	AlertDeliveryStatusSending AlertDeliveryStatus = "sending"
+	AlertDeliveryStatusSent    AlertDeliveryStatus = "sent"
+)
+
+// Defines values for AlertEventType.
+const (
+	AlertEventTypeBatteryLow    AlertEventType = "battery_low"
+	AlertEventTypeDriveFinish   AlertEventType = "drive_finish"
+	AlertEventTypeDriveStart    AlertEventType = "drive_start"
+	AlertEventTypeFenceEnter    AlertEventType = "fence_enter"
+	AlertEventTypeFenceLeave    AlertEventType = "fence_leave"
+	AlertEventTypeOffline       AlertEventType = "offline"
+	AlertEventTypeOnline        AlertEventType = "online"
+	AlertEventTypeProximityMeet AlertEventType = "proximity_meet"
+	AlertEventTypeProximityPart AlertEventType = "proximity_part"
+	AlertEventTypeSpeeding      AlertEventType = "speeding"
+	AlertEventTypeWatch         AlertEventType = "watch"
 )
 
 // Defines values for GeneralError.
@@ -22,10 +47,164 @@ const (
 	GeneralErrorTrue GeneralError = true
 )
 
+// Defines values for Language.
+const (
+	LanguageEn Language = "en"
+	LanguageRu Language = "ru"
+)
+
+// Defines values for MapProvider.
+const (
+	MapProviderGeo    MapProvider = "geo"
+	MapProviderGoogle MapProvider = "google"
+	MapProviderN2gis  MapProvider = "2gis"
+	MapProviderOsm    MapProvider = "osm"
+	MapProviderYandex MapProvider = "yandex"
+)
+
+// Defines values for NotificationChannel.
+const (
+	NotificationChannelEmail    NotificationChannel = "email"
+	NotificationChannelGotify   NotificationChannel = "gotify"
+	NotificationChannelNtfy     NotificationChannel = "ntfy"
+	NotificationChannelTelegram NotificationChannel = "telegram"
+	NotificationChannelWebhook  NotificationChannel = "webhook"
+)
+
+// Defines values for SosEventType.
+const (
+	SosEventTypeAcknowledged SosEventType = "acknowledged"
+	SosEventTypeEscalated    SosEventType = "escalated"
+	SosEventTypeTriggered    SosEventType = "triggered"
+)
+
+// Defines values for WatchDirection.
+const (
+	WatchDirectionArrive WatchDirection = "arrive"
+	WatchDirectionLeave  WatchDirection = "leave"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSending   WebhookDeliveryStatus = "sending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeAccountOffline WebhookEventType = "account.offline"
+	WebhookEventTypeAccountOnline  WebhookEventType = "account.online"
+	WebhookEventTypeFenceEntered   WebhookEventType = "fence.entered"
+	WebhookEventTypeFenceLeft      WebhookEventType = "fence.left"
+	WebhookEventTypeUpdateAccepted WebhookEventType = "update.accepted"
+)
+
+// AccountSettings defines model for AccountSettings.
+type AccountSettings struct {
+	// AccuracyFactor How many reported accuracies are added to fence and proximity radii, overrides the server default
+	AccuracyFactor *AccuracyFactor `json:"accuracyFactor,omitempty"`
+
+	// DriveAlerts Whether drive start and finish alerts are sent for this account
+	DriveAlerts *bool `json:"driveAlerts,omitempty"`
+
+	// InlineVisible Whether other members can look this account up with the bot inline mode, false by default
+	InlineVisible *bool `json:"inlineVisible,omitempty"`
+
+	// Language Language of the bot messages and alerts sent to the account
+	Language            *Language `json:"language,omitempty"`
+	LowBatteryThreshold *int      `json:"lowBatteryThreshold,omitempty"`
+
+	// MapProvider Map service the links in bot messages and alerts sent to the account open in
+	MapProvider             *MapProvider         `json:"mapProvider,omitempty"`
+	Notifications           *[]NotificationRoute `json:"notifications,omitempty"`
+	OfflineThresholdMinutes *int                 `json:"offlineThresholdMinutes,omitempty"`
+
+	// SpeedLimitKmh Speeding alerts are sent when exceeded, 0 disables them
+	SpeedLimitKmh *int `json:"speedLimitKmh,omitempty"`
+}
+
 // AccountStatus defines model for AccountStatus.
 type AccountStatus struct {
-	InsideFences []int64 `json:"insideFences"`
-	Offline      bool    `json:"offline"`
+	Drive        *DriveState `json:"drive,omitempty"`
+	InsideFences []int64     `json:"insideFences"`
+	LowBattery   bool        `json:"lowBattery"`
+	Offline      bool        `json:"offline"`
+	Speeding     bool        `json:"speeding"`
+}
+
+// AccuracyFactor How many reported accuracies are added to fence and proximity radii, overrides the server default
+type AccuracyFactor = float64
+
+// AlertDelivery defines model for AlertDelivery.
+type AlertDelivery struct {
+	AccountId   int64               `json:"accountId"`
+	Attempts    int                 `json:"attempts"`
+	Channel     NotificationChannel `json:"channel"`
+	Created     time.Time           `json:"created"`
+	Event       AlertEventType      `json:"event"`
+	Id          int64               `json:"id"`
+	LastError   *string             `json:"lastError,omitempty"`
+	NextAttempt time.Time           `json:"nextAttempt"`
+	Sent        *time.Time          `json:"sent,omitempty"`
+
+	// Status An alert is sending while a worker holds it and is retried if the worker does not finish before its lease expires
+	Status AlertDeliveryStatus `json:"status"`
+	Target *string             `json:"target,omitempty"`
+	Text   string              `json:"text"`
+}
+
+// AlertDeliveryStatus An alert is sending while a worker holds it and is retried if the worker does not finish before its lease expires
+type AlertDeliveryStatus string
+
+// AlertEventType defines model for AlertEventType.
+type AlertEventType string
+
+// BatteryData defines model for BatteryData.
+type BatteryData struct {
+	Charging bool `json:"charging"`
+	Level    int  `json:"level"`
+}
+
+// Diagnostics defines model for Diagnostics.
+type Diagnostics struct {
+	Checks     []HealthCheck `json:"checks"`
+	GoVersion  string        `json:"goVersion"`
+	Goroutines int           `json:"goroutines"`
+
+	// OfflineCheck When the offline checker last completed a pass
+	OfflineCheck *time.Time  `json:"offlineCheck,omitempty"`
+	Pool         PoolStats   `json:"pool"`
+	Queues       QueueDepths `json:"queues"`
+	Revision     *string     `json:"revision,omitempty"`
+	Started      time.Time   `json:"started"`
+	Version      string      `json:"version"`
+}
+
+// DriveState defines model for DriveState.
+type DriveState struct {
+	LastMoving     time.Time `json:"lastMoving"`
+	MaxSpeedKmh    float64   `json:"maxSpeedKmh"`
+	StartLatitude  float64   `json:"startLatitude"`
+	StartLongitude float64   `json:"startLongitude"`
+	Started        time.Time `json:"started"`
+}
+
+// Fence defines model for Fence.
+type Fence struct {
+	Id        int64         `json:"id"`
+	Latitude  float64       `json:"latitude"`
+	Longitude float64       `json:"longitude"`
+	Name      string        `json:"name"`
+	Radius    float64       `json:"radius"`
+	Settings  FenceSettings `json:"settings"`
+}
+
+// FenceSettings defines model for FenceSettings.
+type FenceSettings struct {
+	// AccuracyFactor How many reported accuracies are added to fence and proximity radii, overrides the server default
+	AccuracyFactor *AccuracyFactor `json:"accuracyFactor,omitempty"`
 }
 
 // General defines model for General.
@@ -38,27 +217,320 @@ type General struct {
 // GeneralError defines model for General.Error.
 type GeneralError bool
 
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Error *string `json:"error,omitempty"`
+	Name  string  `json:"name"`
+	Ok    bool    `json:"ok"`
+}
+
+// Language Language of the bot messages and alerts sent to the account
+type Language string
+
 // LocationData defines model for LocationData.
 type LocationData struct {
 	Accuracy  float64 `json:"accuracy"`
 	Address   *string `json:"address,omitempty"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
+
+	// Speed Speed in meters per second
+	Speed *float64 `json:"speed,omitempty"`
+}
+
+// MapProvider Map service the links in bot messages and alerts sent to the account open in
+type MapProvider string
+
+// NotificationChannel defines model for NotificationChannel.
+type NotificationChannel string
+
+// NotificationRoute defines model for NotificationRoute.
+type NotificationRoute struct {
+	Channel NotificationChannel `json:"channel"`
+	Events  *[]AlertEventType   `json:"events,omitempty"`
+	Target  *string             `json:"target,omitempty"`
+}
+
+// PoolStats defines model for PoolStats.
+type PoolStats struct {
+	AcquireCount int64 `json:"acquireCount"`
+
+	// AcquireDuration Total time spent waiting for connections
+	AcquireDuration   string `json:"acquireDuration"`
+	AcquiredConns     int32  `json:"acquiredConns"`
+	EmptyAcquireCount int64  `json:"emptyAcquireCount"`
+	IdleConns         int32  `json:"idleConns"`
+	MaxConns          int32  `json:"maxConns"`
+	TotalConns        int32  `json:"totalConns"`
+}
+
+// ProximityRule defines model for ProximityRule.
+type ProximityRule struct {
+	Changed         *time.Time `json:"changed,omitempty"`
+	Created         time.Time  `json:"created"`
+	Distance        float64    `json:"distance"`
+	FirstAccountId  int64      `json:"firstAccountId"`
+	Id              int64      `json:"id"`
+	NotifyMeet      bool       `json:"notifyMeet"`
+	NotifyPart      bool       `json:"notifyPart"`
+	SecondAccountId int64      `json:"secondAccountId"`
+	Together        bool       `json:"together"`
+}
+
+// ProximityRuleInput defines model for ProximityRuleInput.
+type ProximityRuleInput struct {
+	Distance        float64 `json:"distance"`
+	FirstAccountId  int64   `json:"firstAccountId"`
+	NotifyMeet      *bool   `json:"notifyMeet,omitempty"`
+	NotifyPart      *bool   `json:"notifyPart,omitempty"`
+	SecondAccountId int64   `json:"secondAccountId"`
+}
+
+// QueueDepths defines model for QueueDepths.
+type QueueDepths struct {
+	FailedWebhooks  int64 `json:"failedWebhooks"`
+	OpenSos         int64 `json:"openSos"`
+	PendingAlerts   int64 `json:"pendingAlerts"`
+	PendingWebhooks int64 `json:"pendingWebhooks"`
+}
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks []HealthCheck `json:"checks"`
+	Ready  bool          `json:"ready"`
+}
+
+// ReplayResult defines model for ReplayResult.
+type ReplayResult struct {
+	Count int64 `json:"count"`
+}
+
+// SosEvent defines model for SosEvent.
+type SosEvent struct {
+	AccountId *int64       `json:"accountId,omitempty"`
+	Created   time.Time    `json:"created"`
+	Details   *string      `json:"details,omitempty"`
+	Type      SosEventType `json:"type"`
+}
+
+// SosEventType defines model for SosEventType.
+type SosEventType string
+
+// SosIncident defines model for SosIncident.
+type SosIncident struct {
+	AccountId       int64      `json:"accountId"`
+	Acknowledged    *time.Time `json:"acknowledged,omitempty"`
+	AcknowledgedBy  *int64     `json:"acknowledgedBy,omitempty"`
+	Created         time.Time  `json:"created"`
+	EscalationLevel int        `json:"escalationLevel"`
+	Events          []SosEvent `json:"events"`
+	Id              int64      `json:"id"`
+	Message         *string    `json:"message,omitempty"`
+}
+
+// SosRequest defines model for SosRequest.
+type SosRequest struct {
+	Location *LocationData `json:"location,omitempty"`
+	Message  *string       `json:"message,omitempty"`
 }
 
 // UpdateData defines model for UpdateData.
 type UpdateData struct {
+	Battery  *BatteryData  `json:"battery,omitempty"`
 	Location *LocationData `json:"location,omitempty"`
 }
 
+// Watch defines model for Watch.
+type Watch struct {
+	AccountId int64          `json:"accountId"`
+	Created   time.Time      `json:"created"`
+	Direction WatchDirection `json:"direction"`
+	Distance  *float64       `json:"distance,omitempty"`
+	Expires   time.Time      `json:"expires"`
+	FenceId   *int64         `json:"fenceId,omitempty"`
+	Id        int64          `json:"id"`
+}
+
+// WatchDirection defines model for WatchDirection.
+type WatchDirection string
+
+// WatchInput defines model for WatchInput.
+type WatchInput struct {
+	AccountId int64          `json:"accountId"`
+	Direction WatchDirection `json:"direction"`
+
+	// Distance Distance to the watch owner in meters, used when fenceId is not set
+	Distance         *float64 `json:"distance,omitempty"`
+	ExpiresInMinutes *int     `json:"expiresInMinutes,omitempty"`
+	FenceId          *int64   `json:"fenceId,omitempty"`
+}
+
+// WebhookAccount defines model for WebhookAccount.
+type WebhookAccount struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts       int              `json:"attempts"`
+	Created        time.Time        `json:"created"`
+	Delivered      *time.Time       `json:"delivered,omitempty"`
+	Event          WebhookEventType `json:"event"`
+	Id             int64            `json:"id"`
+	LastError      *string          `json:"lastError,omitempty"`
+	LastStatusCode *int             `json:"lastStatusCode,omitempty"`
+	NextAttempt    time.Time        `json:"nextAttempt"`
+	Payload        WebhookEvent     `json:"payload"`
+
+	// Status A delivery is sending while a worker holds it and is retried if the worker does not finish before its lease expires
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionId int64                 `json:"subscriptionId"`
+}
+
+// WebhookDeliveryStatus A delivery is sending while a worker holds it and is retried if the worker does not finish before its lease expires
+type WebhookDeliveryStatus string
+
+// WebhookEvent defines model for WebhookEvent.
+type WebhookEvent struct {
+	Account WebhookAccount         `json:"account"`
+	Created time.Time              `json:"created"`
+	Data    map[string]interface{} `json:"data"`
+	Id      string                 `json:"id"`
+	Type    WebhookEventType       `json:"type"`
+}
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	Created time.Time          `json:"created"`
+	Enabled bool               `json:"enabled"`
+	Events  []WebhookEventType `json:"events"`
+	Id      int64              `json:"id"`
+	Secret  string             `json:"secret"`
+	Url     string             `json:"url"`
+}
+
+// WebhookSubscriptionInput defines model for WebhookSubscriptionInput.
+type WebhookSubscriptionInput struct {
+	Enabled *bool              `json:"enabled,omitempty"`
+	Events  []WebhookEventType `json:"events"`
+	Secret  *string            `json:"secret,omitempty"`
+	Url     string             `json:"url"`
+}
+
+// ListAlertDeliveriesParams defines parameters for ListAlertDeliveries.
+type ListAlertDeliveriesParams struct {
+	Status *AlertDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int                 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int                   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// UpdateAccountSettingsJSONRequestBody defines body for UpdateAccountSettings for application/json ContentType.
+type UpdateAccountSettingsJSONRequestBody = AccountSettings
+
+// UpdateFenceSettingsJSONRequestBody defines body for UpdateFenceSettings for application/json ContentType.
+type UpdateFenceSettingsJSONRequestBody = FenceSettings
+
+// CreateProximityRuleJSONRequestBody defines body for CreateProximityRule for application/json ContentType.
+type CreateProximityRuleJSONRequestBody = ProximityRuleInput
+
+// UpdateProximityRuleJSONRequestBody defines body for UpdateProximityRule for application/json ContentType.
+type UpdateProximityRuleJSONRequestBody = ProximityRuleInput
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = WebhookSubscriptionInput
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = WebhookSubscriptionInput
+
+// TriggerSosJSONRequestBody defines body for TriggerSos for application/json ContentType.
+type TriggerSosJSONRequestBody = SosRequest
+
 // IngestUpdateJSONRequestBody defines body for IngestUpdate for application/json ContentType.
 type IngestUpdateJSONRequestBody = UpdateData
 
+// CreateWatchJSONRequestBody defines body for CreateWatch for application/json ContentType.
+type CreateWatchJSONRequestBody = WatchInput
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get Account Settings
+	// (GET /account/settings)
+	GetAccountSettings(c *fiber.Ctx) error
+	// Update Account Settings
+	// (PATCH /account/settings)
+	UpdateAccountSettings(c *fiber.Ctx) error
+	// List Alert Deliveries
+	// (GET /admin/alerts)
+	ListAlertDeliveries(c *fiber.Ctx, params ListAlertDeliveriesParams) error
+	// Get Diagnostics
+	// (GET /admin/diagnostics)
+	GetDiagnostics(c *fiber.Ctx) error
+	// List Fences
+	// (GET /admin/fences)
+	ListFences(c *fiber.Ctx) error
+	// Update Fence Settings
+	// (PUT /admin/fences/{id}/settings)
+	UpdateFenceSettings(c *fiber.Ctx, id int64) error
+	// List Proximity Rules
+	// (GET /admin/proximity-rules)
+	ListProximityRules(c *fiber.Ctx) error
+	// Create Proximity Rule
+	// (POST /admin/proximity-rules)
+	CreateProximityRule(c *fiber.Ctx) error
+	// Delete Proximity Rule
+	// (DELETE /admin/proximity-rules/{id})
+	DeleteProximityRule(c *fiber.Ctx, id int64) error
+	// Update Proximity Rule
+	// (PUT /admin/proximity-rules/{id})
+	UpdateProximityRule(c *fiber.Ctx, id int64) error
+	// Replay Webhook Delivery
+	// (POST /admin/webhook-deliveries/{id}/replay)
+	ReplayWebhookDelivery(c *fiber.Ctx, id int64) error
+	// List Webhook Subscriptions
+	// (GET /admin/webhooks)
+	ListWebhooks(c *fiber.Ctx) error
+	// Create Webhook Subscription
+	// (POST /admin/webhooks)
+	CreateWebhook(c *fiber.Ctx) error
+	// Delete Webhook Subscription
+	// (DELETE /admin/webhooks/{id})
+	DeleteWebhook(c *fiber.Ctx, id int64) error
+	// Update Webhook Subscription
+	// (PUT /admin/webhooks/{id})
+	UpdateWebhook(c *fiber.Ctx, id int64) error
+	// List Webhook Deliveries
+	// (GET /admin/webhooks/{id}/deliveries)
+	ListWebhookDeliveries(c *fiber.Ctx, id int64, params ListWebhookDeliveriesParams) error
+	// Replay Failed Webhook Deliveries
+	// (POST /admin/webhooks/{id}/replay)
+	ReplayFailedWebhookDeliveries(c *fiber.Ctx, id int64) error
+	// Trigger SOS
+	// (POST /sos)
+	TriggerSos(c *fiber.Ctx) error
+	// Get SOS Incident
+	// (GET /sos/{id})
+	GetSos(c *fiber.Ctx, id int64) error
 	// Ingest Updates
 	// (POST /update/ingest)
 	IngestUpdate(c *fiber.Ctx) error
+	// List My Watches
+	// (GET /watches)
+	ListWatches(c *fiber.Ctx) error
+	// Create Watch
+	// (POST /watches)
+	CreateWatch(c *fiber.Ctx) error
+	// Delete Watch
+	// (DELETE /watches/{id})
+	DeleteWatch(c *fiber.Ctx, id int64) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -68,132 +540,2295 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
-// IngestUpdate operation middleware
-func (siw *ServerInterfaceWrapper) IngestUpdate(c *fiber.Ctx) error {
+// GetAccountSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountSettings(c *fiber.Ctx) error {
 
-	return siw.Handler.IngestUpdate(c)
+	return siw.Handler.GetAccountSettings(c)
 }
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
-}
+// UpdateAccountSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAccountSettings(c *fiber.Ctx) error {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+	return siw.Handler.UpdateAccountSettings(c)
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+// ListAlertDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListAlertDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAlertDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
 	}
 
-	router.Post(options.BaseURL+"/update/ingest", wrapper.IngestUpdate)
+	// ------------- Optional query parameter "limit" -------------
 
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
 
-type IngestUpdateRequestObject struct {
-	Body *IngestUpdateJSONRequestBody
+	return siw.Handler.ListAlertDeliveries(c, params)
 }
 
-type IngestUpdateResponseObject interface {
-	VisitIngestUpdateResponse(ctx *fiber.Ctx) error
+// GetDiagnostics operation middleware
+func (siw *ServerInterfaceWrapper) GetDiagnostics(c *fiber.Ctx) error {
+
+	return siw.Handler.GetDiagnostics(c)
 }
 
-type IngestUpdate200Response struct {
+// ListFences operation middleware
+func (siw *ServerInterfaceWrapper) ListFences(c *fiber.Ctx) error {
+
+	return siw.Handler.ListFences(c)
 }
 
-func (response IngestUpdate200Response) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
-	ctx.Status(200)
-	return nil
+// UpdateFenceSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFenceSettings(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.UpdateFenceSettings(c, id)
 }
 
-type IngestUpdate400JSONResponse General
+// ListProximityRules operation middleware
+func (siw *ServerInterfaceWrapper) ListProximityRules(c *fiber.Ctx) error {
 
-func (response IngestUpdate400JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(400)
+	return siw.Handler.ListProximityRules(c)
+}
 
-	return ctx.JSON(&response)
+// CreateProximityRule operation middleware
+func (siw *ServerInterfaceWrapper) CreateProximityRule(c *fiber.Ctx) error {
+
+	return siw.Handler.CreateProximityRule(c)
 }
 
-type IngestUpdate401JSONResponse General
+// DeleteProximityRule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProximityRule(c *fiber.Ctx) error {
 
-func (response IngestUpdate401JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(401)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteProximityRule(c, id)
 }
 
-type IngestUpdate403JSONResponse General
+// UpdateProximityRule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProximityRule(c *fiber.Ctx) error {
 
-func (response IngestUpdate403JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(403)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.UpdateProximityRule(c, id)
 }
 
-type IngestUpdate500JSONResponse General
+// ReplayWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) ReplayWebhookDelivery(c *fiber.Ctx) error {
 
-func (response IngestUpdate500JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.ReplayWebhookDelivery(c, id)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Ingest Updates
-	// (POST /update/ingest)
-	IngestUpdate(ctx context.Context, request IngestUpdateRequestObject) (IngestUpdateResponseObject, error)
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *fiber.Ctx) error {
+
+	return siw.Handler.ListWebhooks(c)
 }
 
-type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *fiber.Ctx) error {
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	return siw.Handler.CreateWebhook(c)
+}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteWebhook(c, id)
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.UpdateWebhook(c, id)
 }
 
-// IngestUpdate operation middleware
-func (sh *strictHandler) IngestUpdate(ctx *fiber.Ctx) error {
-	var request IngestUpdateRequestObject
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(c *fiber.Ctx) error {
 
-	var body IngestUpdateJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
-	request.Body = &body
 
-	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.IngestUpdate(ctx.UserContext(), request.(IngestUpdateRequestObject))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "IngestUpdate")
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
 	}
 
-	response, err := handler(ctx, request)
+	// ------------- Optional query parameter "limit" -------------
 
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return err
-	} else if validResponse, ok := response.(IngestUpdateResponseObject); ok {
-		if err := validResponse.VisitIngestUpdateResponse(ctx); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.ListWebhookDeliveries(c, id, params)
+}
+
+// ReplayFailedWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ReplayFailedWebhookDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.ReplayFailedWebhookDeliveries(c, id)
+}
+
+// TriggerSos operation middleware
+func (siw *ServerInterfaceWrapper) TriggerSos(c *fiber.Ctx) error {
+
+	return siw.Handler.TriggerSos(c)
+}
+
+// GetSos operation middleware
+func (siw *ServerInterfaceWrapper) GetSos(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.GetSos(c, id)
+}
+
+// IngestUpdate operation middleware
+func (siw *ServerInterfaceWrapper) IngestUpdate(c *fiber.Ctx) error {
+
+	return siw.Handler.IngestUpdate(c)
+}
+
+// ListWatches operation middleware
+func (siw *ServerInterfaceWrapper) ListWatches(c *fiber.Ctx) error {
+
+	return siw.Handler.ListWatches(c)
+}
+
+// CreateWatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateWatch(c *fiber.Ctx) error {
+
+	return siw.Handler.CreateWatch(c)
+}
+
+// DeleteWatch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWatch(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteWatch(c, id)
+}
+
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
+
+	router.Get(options.BaseURL+"/account/settings", wrapper.GetAccountSettings)
+
+	router.Patch(options.BaseURL+"/account/settings", wrapper.UpdateAccountSettings)
+
+	router.Get(options.BaseURL+"/admin/alerts", wrapper.ListAlertDeliveries)
+
+	router.Get(options.BaseURL+"/admin/diagnostics", wrapper.GetDiagnostics)
+
+	router.Get(options.BaseURL+"/admin/fences", wrapper.ListFences)
+
+	router.Put(options.BaseURL+"/admin/fences/:id/settings", wrapper.UpdateFenceSettings)
+
+	router.Get(options.BaseURL+"/admin/proximity-rules", wrapper.ListProximityRules)
+
+	router.Post(options.BaseURL+"/admin/proximity-rules", wrapper.CreateProximityRule)
+
+	router.Delete(options.BaseURL+"/admin/proximity-rules/:id", wrapper.DeleteProximityRule)
+
+	router.Put(options.BaseURL+"/admin/proximity-rules/:id", wrapper.UpdateProximityRule)
+
+	router.Post(options.BaseURL+"/admin/webhook-deliveries/:id/replay", wrapper.ReplayWebhookDelivery)
+
+	router.Get(options.BaseURL+"/admin/webhooks", wrapper.ListWebhooks)
+
+	router.Post(options.BaseURL+"/admin/webhooks", wrapper.CreateWebhook)
+
+	router.Delete(options.BaseURL+"/admin/webhooks/:id", wrapper.DeleteWebhook)
+
+	router.Put(options.BaseURL+"/admin/webhooks/:id", wrapper.UpdateWebhook)
+
+	router.Get(options.BaseURL+"/admin/webhooks/:id/deliveries", wrapper.ListWebhookDeliveries)
+
+	router.Post(options.BaseURL+"/admin/webhooks/:id/replay", wrapper.ReplayFailedWebhookDeliveries)
+
+	router.Post(options.BaseURL+"/sos", wrapper.TriggerSos)
+
+	router.Get(options.BaseURL+"/sos/:id", wrapper.GetSos)
+
+	router.Post(options.BaseURL+"/update/ingest", wrapper.IngestUpdate)
+
+	router.Get(options.BaseURL+"/watches", wrapper.ListWatches)
+
+	router.Post(options.BaseURL+"/watches", wrapper.CreateWatch)
+
+	router.Delete(options.BaseURL+"/watches/:id", wrapper.DeleteWatch)
+
+}
+
+type GetAccountSettingsRequestObject struct {
+}
+
+type GetAccountSettingsResponseObject interface {
+	VisitGetAccountSettingsResponse(ctx *fiber.Ctx) error
+}
+
+type GetAccountSettings200JSONResponse AccountSettings
+
+func (response GetAccountSettings200JSONResponse) VisitGetAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetAccountSettings401JSONResponse General
+
+func (response GetAccountSettings401JSONResponse) VisitGetAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetAccountSettings403JSONResponse General
+
+func (response GetAccountSettings403JSONResponse) VisitGetAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetAccountSettings500JSONResponse General
+
+func (response GetAccountSettings500JSONResponse) VisitGetAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAccountSettingsRequestObject struct {
+	Body *UpdateAccountSettingsJSONRequestBody
+}
+
+type UpdateAccountSettingsResponseObject interface {
+	VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateAccountSettings200JSONResponse AccountSettings
+
+func (response UpdateAccountSettings200JSONResponse) VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAccountSettings400JSONResponse General
+
+func (response UpdateAccountSettings400JSONResponse) VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAccountSettings401JSONResponse General
+
+func (response UpdateAccountSettings401JSONResponse) VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAccountSettings403JSONResponse General
+
+func (response UpdateAccountSettings403JSONResponse) VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAccountSettings500JSONResponse General
+
+func (response UpdateAccountSettings500JSONResponse) VisitUpdateAccountSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveriesRequestObject struct {
+	Params ListAlertDeliveriesParams
+}
+
+type ListAlertDeliveriesResponseObject interface {
+	VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type ListAlertDeliveries200JSONResponse []AlertDelivery
+
+func (response ListAlertDeliveries200JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries400JSONResponse General
+
+func (response ListAlertDeliveries400JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries401JSONResponse General
+
+func (response ListAlertDeliveries401JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries403JSONResponse General
+
+func (response ListAlertDeliveries403JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries500JSONResponse General
+
+func (response ListAlertDeliveries500JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetDiagnosticsRequestObject struct {
+}
+
+type GetDiagnosticsResponseObject interface {
+	VisitGetDiagnosticsResponse(ctx *fiber.Ctx) error
+}
+
+type GetDiagnostics200JSONResponse Diagnostics
+
+func (response GetDiagnostics200JSONResponse) VisitGetDiagnosticsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetDiagnostics401JSONResponse General
+
+func (response GetDiagnostics401JSONResponse) VisitGetDiagnosticsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetDiagnostics403JSONResponse General
+
+func (response GetDiagnostics403JSONResponse) VisitGetDiagnosticsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetDiagnostics500JSONResponse General
+
+func (response GetDiagnostics500JSONResponse) VisitGetDiagnosticsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListFencesRequestObject struct {
+}
+
+type ListFencesResponseObject interface {
+	VisitListFencesResponse(ctx *fiber.Ctx) error
+}
+
+type ListFences200JSONResponse []Fence
+
+func (response ListFences200JSONResponse) VisitListFencesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListFences401JSONResponse General
+
+func (response ListFences401JSONResponse) VisitListFencesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListFences403JSONResponse General
+
+func (response ListFences403JSONResponse) VisitListFencesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListFences500JSONResponse General
+
+func (response ListFences500JSONResponse) VisitListFencesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettingsRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateFenceSettingsJSONRequestBody
+}
+
+type UpdateFenceSettingsResponseObject interface {
+	VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateFenceSettings200JSONResponse Fence
+
+func (response UpdateFenceSettings200JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettings400JSONResponse General
+
+func (response UpdateFenceSettings400JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettings401JSONResponse General
+
+func (response UpdateFenceSettings401JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettings403JSONResponse General
+
+func (response UpdateFenceSettings403JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettings404JSONResponse General
+
+func (response UpdateFenceSettings404JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateFenceSettings500JSONResponse General
+
+func (response UpdateFenceSettings500JSONResponse) VisitUpdateFenceSettingsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListProximityRulesRequestObject struct {
+}
+
+type ListProximityRulesResponseObject interface {
+	VisitListProximityRulesResponse(ctx *fiber.Ctx) error
+}
+
+type ListProximityRules200JSONResponse []ProximityRule
+
+func (response ListProximityRules200JSONResponse) VisitListProximityRulesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListProximityRules401JSONResponse General
+
+func (response ListProximityRules401JSONResponse) VisitListProximityRulesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListProximityRules403JSONResponse General
+
+func (response ListProximityRules403JSONResponse) VisitListProximityRulesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListProximityRules500JSONResponse General
+
+func (response ListProximityRules500JSONResponse) VisitListProximityRulesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type CreateProximityRuleRequestObject struct {
+	Body *CreateProximityRuleJSONRequestBody
+}
+
+type CreateProximityRuleResponseObject interface {
+	VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error
+}
+
+type CreateProximityRule200JSONResponse ProximityRule
+
+func (response CreateProximityRule200JSONResponse) VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateProximityRule400JSONResponse General
+
+func (response CreateProximityRule400JSONResponse) VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type CreateProximityRule401JSONResponse General
+
+func (response CreateProximityRule401JSONResponse) VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type CreateProximityRule403JSONResponse General
+
+func (response CreateProximityRule403JSONResponse) VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type CreateProximityRule500JSONResponse General
+
+func (response CreateProximityRule500JSONResponse) VisitCreateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteProximityRuleRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteProximityRuleResponseObject interface {
+	VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteProximityRule200Response struct {
+}
+
+func (response DeleteProximityRule200Response) VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type DeleteProximityRule401JSONResponse General
+
+func (response DeleteProximityRule401JSONResponse) VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteProximityRule403JSONResponse General
+
+func (response DeleteProximityRule403JSONResponse) VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteProximityRule404JSONResponse General
+
+func (response DeleteProximityRule404JSONResponse) VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteProximityRule500JSONResponse General
+
+func (response DeleteProximityRule500JSONResponse) VisitDeleteProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRuleRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateProximityRuleJSONRequestBody
+}
+
+type UpdateProximityRuleResponseObject interface {
+	VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateProximityRule200JSONResponse ProximityRule
+
+func (response UpdateProximityRule200JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRule400JSONResponse General
+
+func (response UpdateProximityRule400JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRule401JSONResponse General
+
+func (response UpdateProximityRule401JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRule403JSONResponse General
+
+func (response UpdateProximityRule403JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRule404JSONResponse General
+
+func (response UpdateProximityRule404JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateProximityRule500JSONResponse General
+
+func (response UpdateProximityRule500JSONResponse) VisitUpdateProximityRuleResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayWebhookDeliveryRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type ReplayWebhookDeliveryResponseObject interface {
+	VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error
+}
+
+type ReplayWebhookDelivery200JSONResponse WebhookDelivery
+
+func (response ReplayWebhookDelivery200JSONResponse) VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayWebhookDelivery401JSONResponse General
+
+func (response ReplayWebhookDelivery401JSONResponse) VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayWebhookDelivery403JSONResponse General
+
+func (response ReplayWebhookDelivery403JSONResponse) VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayWebhookDelivery404JSONResponse General
+
+func (response ReplayWebhookDelivery404JSONResponse) VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayWebhookDelivery500JSONResponse General
+
+func (response ReplayWebhookDelivery500JSONResponse) VisitReplayWebhookDeliveryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhooksRequestObject struct {
+}
+
+type ListWebhooksResponseObject interface {
+	VisitListWebhooksResponse(ctx *fiber.Ctx) error
+}
+
+type ListWebhooks200JSONResponse []WebhookSubscription
+
+func (response ListWebhooks200JSONResponse) VisitListWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhooks401JSONResponse General
+
+func (response ListWebhooks401JSONResponse) VisitListWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhooks403JSONResponse General
+
+func (response ListWebhooks403JSONResponse) VisitListWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhooks500JSONResponse General
+
+func (response ListWebhooks500JSONResponse) VisitListWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhookRequestObject struct {
+	Body *CreateWebhookJSONRequestBody
+}
+
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type CreateWebhook200JSONResponse WebhookSubscription
+
+func (response CreateWebhook200JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhook400JSONResponse General
+
+func (response CreateWebhook400JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhook401JSONResponse General
+
+func (response CreateWebhook401JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhook403JSONResponse General
+
+func (response CreateWebhook403JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhook500JSONResponse General
+
+func (response CreateWebhook500JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhookRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWebhook200Response struct {
+}
+
+func (response DeleteWebhook200Response) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type DeleteWebhook401JSONResponse General
+
+func (response DeleteWebhook401JSONResponse) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhook403JSONResponse General
+
+func (response DeleteWebhook403JSONResponse) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhook404JSONResponse General
+
+func (response DeleteWebhook404JSONResponse) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebhook500JSONResponse General
+
+func (response DeleteWebhook500JSONResponse) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhookRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateWebhookJSONRequestBody
+}
+
+type UpdateWebhookResponseObject interface {
+	VisitUpdateWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateWebhook200JSONResponse WebhookSubscription
+
+func (response UpdateWebhook200JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook400JSONResponse General
+
+func (response UpdateWebhook400JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook401JSONResponse General
+
+func (response UpdateWebhook401JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook403JSONResponse General
+
+func (response UpdateWebhook403JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook404JSONResponse General
+
+func (response UpdateWebhook404JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook500JSONResponse General
+
+func (response UpdateWebhook500JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveriesRequestObject struct {
+	Id     int64 `json:"id"`
+	Params ListWebhookDeliveriesParams
+}
+
+type ListWebhookDeliveriesResponseObject interface {
+	VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type ListWebhookDeliveries200JSONResponse []WebhookDelivery
+
+func (response ListWebhookDeliveries200JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveries400JSONResponse General
+
+func (response ListWebhookDeliveries400JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveries401JSONResponse General
+
+func (response ListWebhookDeliveries401JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveries403JSONResponse General
+
+func (response ListWebhookDeliveries403JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveries404JSONResponse General
+
+func (response ListWebhookDeliveries404JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListWebhookDeliveries500JSONResponse General
+
+func (response ListWebhookDeliveries500JSONResponse) VisitListWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayFailedWebhookDeliveriesRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type ReplayFailedWebhookDeliveriesResponseObject interface {
+	VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type ReplayFailedWebhookDeliveries200JSONResponse ReplayResult
+
+func (response ReplayFailedWebhookDeliveries200JSONResponse) VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayFailedWebhookDeliveries401JSONResponse General
+
+func (response ReplayFailedWebhookDeliveries401JSONResponse) VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayFailedWebhookDeliveries403JSONResponse General
+
+func (response ReplayFailedWebhookDeliveries403JSONResponse) VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayFailedWebhookDeliveries404JSONResponse General
+
+func (response ReplayFailedWebhookDeliveries404JSONResponse) VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ReplayFailedWebhookDeliveries500JSONResponse General
+
+func (response ReplayFailedWebhookDeliveries500JSONResponse) VisitReplayFailedWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSosRequestObject struct {
+	Body *TriggerSosJSONRequestBody
+}
+
+type TriggerSosResponseObject interface {
+	VisitTriggerSosResponse(ctx *fiber.Ctx) error
+}
+
+type TriggerSos200JSONResponse SosIncident
+
+func (response TriggerSos200JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSos400JSONResponse General
+
+func (response TriggerSos400JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSos401JSONResponse General
+
+func (response TriggerSos401JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSos403JSONResponse General
+
+func (response TriggerSos403JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSos429JSONResponse General
+
+func (response TriggerSos429JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response)
+}
+
+type TriggerSos500JSONResponse General
+
+func (response TriggerSos500JSONResponse) VisitTriggerSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetSosRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetSosResponseObject interface {
+	VisitGetSosResponse(ctx *fiber.Ctx) error
+}
+
+type GetSos200JSONResponse SosIncident
+
+func (response GetSos200JSONResponse) VisitGetSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetSos401JSONResponse General
+
+func (response GetSos401JSONResponse) VisitGetSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetSos403JSONResponse General
+
+func (response GetSos403JSONResponse) VisitGetSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetSos404JSONResponse General
+
+func (response GetSos404JSONResponse) VisitGetSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetSos500JSONResponse General
+
+func (response GetSos500JSONResponse) VisitGetSosResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type IngestUpdateRequestObject struct {
+	Body *IngestUpdateJSONRequestBody
+}
+
+type IngestUpdateResponseObject interface {
+	VisitIngestUpdateResponse(ctx *fiber.Ctx) error
+}
+
+type IngestUpdate200Response struct {
+}
+
+func (response IngestUpdate200Response) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type IngestUpdate400JSONResponse General
+
+func (response IngestUpdate400JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type IngestUpdate401JSONResponse General
+
+func (response IngestUpdate401JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type IngestUpdate403JSONResponse General
+
+func (response IngestUpdate403JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type IngestUpdate500JSONResponse General
+
+func (response IngestUpdate500JSONResponse) VisitIngestUpdateResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListWatchesRequestObject struct {
+}
+
+type ListWatchesResponseObject interface {
+	VisitListWatchesResponse(ctx *fiber.Ctx) error
+}
+
+type ListWatches200JSONResponse []Watch
+
+func (response ListWatches200JSONResponse) VisitListWatchesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListWatches401JSONResponse General
+
+func (response ListWatches401JSONResponse) VisitListWatchesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListWatches403JSONResponse General
+
+func (response ListWatches403JSONResponse) VisitListWatchesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListWatches500JSONResponse General
+
+func (response ListWatches500JSONResponse) VisitListWatchesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWatchRequestObject struct {
+	Body *CreateWatchJSONRequestBody
+}
+
+type CreateWatchResponseObject interface {
+	VisitCreateWatchResponse(ctx *fiber.Ctx) error
+}
+
+type CreateWatch200JSONResponse Watch
+
+func (response CreateWatch200JSONResponse) VisitCreateWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWatch400JSONResponse General
+
+func (response CreateWatch400JSONResponse) VisitCreateWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWatch401JSONResponse General
+
+func (response CreateWatch401JSONResponse) VisitCreateWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWatch403JSONResponse General
+
+func (response CreateWatch403JSONResponse) VisitCreateWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWatch500JSONResponse General
+
+func (response CreateWatch500JSONResponse) VisitCreateWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWatchRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteWatchResponseObject interface {
+	VisitDeleteWatchResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWatch200Response struct {
+}
+
+func (response DeleteWatch200Response) VisitDeleteWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type DeleteWatch401JSONResponse General
+
+func (response DeleteWatch401JSONResponse) VisitDeleteWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWatch403JSONResponse General
+
+func (response DeleteWatch403JSONResponse) VisitDeleteWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWatch404JSONResponse General
+
+func (response DeleteWatch404JSONResponse) VisitDeleteWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWatch500JSONResponse General
+
+func (response DeleteWatch500JSONResponse) VisitDeleteWatchResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get Account Settings
+	// (GET /account/settings)
+	GetAccountSettings(ctx context.Context, request GetAccountSettingsRequestObject) (GetAccountSettingsResponseObject, error)
+	// Update Account Settings
+	// (PATCH /account/settings)
+	UpdateAccountSettings(ctx context.Context, request UpdateAccountSettingsRequestObject) (UpdateAccountSettingsResponseObject, error)
+	// List Alert Deliveries
+	// (GET /admin/alerts)
+	ListAlertDeliveries(ctx context.Context, request ListAlertDeliveriesRequestObject) (ListAlertDeliveriesResponseObject, error)
+	// Get Diagnostics
+	// (GET /admin/diagnostics)
+	GetDiagnostics(ctx context.Context, request GetDiagnosticsRequestObject) (GetDiagnosticsResponseObject, error)
+	// List Fences
+	// (GET /admin/fences)
+	ListFences(ctx context.Context, request ListFencesRequestObject) (ListFencesResponseObject, error)
+	// Update Fence Settings
+	// (PUT /admin/fences/{id}/settings)
+	UpdateFenceSettings(ctx context.Context, request UpdateFenceSettingsRequestObject) (UpdateFenceSettingsResponseObject, error)
+	// List Proximity Rules
+	// (GET /admin/proximity-rules)
+	ListProximityRules(ctx context.Context, request ListProximityRulesRequestObject) (ListProximityRulesResponseObject, error)
+	// Create Proximity Rule
+	// (POST /admin/proximity-rules)
+	CreateProximityRule(ctx context.Context, request CreateProximityRuleRequestObject) (CreateProximityRuleResponseObject, error)
+	// Delete Proximity Rule
+	// (DELETE /admin/proximity-rules/{id})
+	DeleteProximityRule(ctx context.Context, request DeleteProximityRuleRequestObject) (DeleteProximityRuleResponseObject, error)
+	// Update Proximity Rule
+	// (PUT /admin/proximity-rules/{id})
+	UpdateProximityRule(ctx context.Context, request UpdateProximityRuleRequestObject) (UpdateProximityRuleResponseObject, error)
+	// Replay Webhook Delivery
+	// (POST /admin/webhook-deliveries/{id}/replay)
+	ReplayWebhookDelivery(ctx context.Context, request ReplayWebhookDeliveryRequestObject) (ReplayWebhookDeliveryResponseObject, error)
+	// List Webhook Subscriptions
+	// (GET /admin/webhooks)
+	ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error)
+	// Create Webhook Subscription
+	// (POST /admin/webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
+	// Delete Webhook Subscription
+	// (DELETE /admin/webhooks/{id})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+	// Update Webhook Subscription
+	// (PUT /admin/webhooks/{id})
+	UpdateWebhook(ctx context.Context, request UpdateWebhookRequestObject) (UpdateWebhookResponseObject, error)
+	// List Webhook Deliveries
+	// (GET /admin/webhooks/{id}/deliveries)
+	ListWebhookDeliveries(ctx context.Context, request ListWebhookDeliveriesRequestObject) (ListWebhookDeliveriesResponseObject, error)
+	// Replay Failed Webhook Deliveries
+	// (POST /admin/webhooks/{id}/replay)
+	ReplayFailedWebhookDeliveries(ctx context.Context, request ReplayFailedWebhookDeliveriesRequestObject) (ReplayFailedWebhookDeliveriesResponseObject, error)
+	// Trigger SOS
+	// (POST /sos)
+	TriggerSos(ctx context.Context, request TriggerSosRequestObject) (TriggerSosResponseObject, error)
+	// Get SOS Incident
+	// (GET /sos/{id})
+	GetSos(ctx context.Context, request GetSosRequestObject) (GetSosResponseObject, error)
+	// Ingest Updates
+	// (POST /update/ingest)
+	IngestUpdate(ctx context.Context, request IngestUpdateRequestObject) (IngestUpdateResponseObject, error)
+	// List My Watches
+	// (GET /watches)
+	ListWatches(ctx context.Context, request ListWatchesRequestObject) (ListWatchesResponseObject, error)
+	// Create Watch
+	// (POST /watches)
+	CreateWatch(ctx context.Context, request CreateWatchRequestObject) (CreateWatchResponseObject, error)
+	// Delete Watch
+	// (DELETE /watches/{id})
+	DeleteWatch(ctx context.Context, request DeleteWatchRequestObject) (DeleteWatchResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetAccountSettings operation middleware
+func (sh *strictHandler) GetAccountSettings(ctx *fiber.Ctx) error {
+	var request GetAccountSettingsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountSettings(ctx.UserContext(), request.(GetAccountSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountSettings")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAccountSettingsResponseObject); ok {
+		if err := validResponse.VisitGetAccountSettingsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateAccountSettings operation middleware
+func (sh *strictHandler) UpdateAccountSettings(ctx *fiber.Ctx) error {
+	var request UpdateAccountSettingsRequestObject
+
+	var body UpdateAccountSettingsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAccountSettings(ctx.UserContext(), request.(UpdateAccountSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAccountSettings")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateAccountSettingsResponseObject); ok {
+		if err := validResponse.VisitUpdateAccountSettingsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListAlertDeliveries operation middleware
+func (sh *strictHandler) ListAlertDeliveries(ctx *fiber.Ctx, params ListAlertDeliveriesParams) error {
+	var request ListAlertDeliveriesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAlertDeliveries(ctx.UserContext(), request.(ListAlertDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAlertDeliveries")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListAlertDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListAlertDeliveriesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetDiagnostics operation middleware
+func (sh *strictHandler) GetDiagnostics(ctx *fiber.Ctx) error {
+	var request GetDiagnosticsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDiagnostics(ctx.UserContext(), request.(GetDiagnosticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDiagnostics")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetDiagnosticsResponseObject); ok {
+		if err := validResponse.VisitGetDiagnosticsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListFences operation middleware
+func (sh *strictHandler) ListFences(ctx *fiber.Ctx) error {
+	var request ListFencesRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFences(ctx.UserContext(), request.(ListFencesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFences")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListFencesResponseObject); ok {
+		if err := validResponse.VisitListFencesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateFenceSettings operation middleware
+func (sh *strictHandler) UpdateFenceSettings(ctx *fiber.Ctx, id int64) error {
+	var request UpdateFenceSettingsRequestObject
+
+	request.Id = id
+
+	var body UpdateFenceSettingsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateFenceSettings(ctx.UserContext(), request.(UpdateFenceSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateFenceSettings")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateFenceSettingsResponseObject); ok {
+		if err := validResponse.VisitUpdateFenceSettingsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListProximityRules operation middleware
+func (sh *strictHandler) ListProximityRules(ctx *fiber.Ctx) error {
+	var request ListProximityRulesRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListProximityRules(ctx.UserContext(), request.(ListProximityRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListProximityRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListProximityRulesResponseObject); ok {
+		if err := validResponse.VisitListProximityRulesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateProximityRule operation middleware
+func (sh *strictHandler) CreateProximityRule(ctx *fiber.Ctx) error {
+	var request CreateProximityRuleRequestObject
+
+	var body CreateProximityRuleJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProximityRule(ctx.UserContext(), request.(CreateProximityRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProximityRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateProximityRuleResponseObject); ok {
+		if err := validResponse.VisitCreateProximityRuleResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteProximityRule operation middleware
+func (sh *strictHandler) DeleteProximityRule(ctx *fiber.Ctx, id int64) error {
+	var request DeleteProximityRuleRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProximityRule(ctx.UserContext(), request.(DeleteProximityRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProximityRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteProximityRuleResponseObject); ok {
+		if err := validResponse.VisitDeleteProximityRuleResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateProximityRule operation middleware
+func (sh *strictHandler) UpdateProximityRule(ctx *fiber.Ctx, id int64) error {
+	var request UpdateProximityRuleRequestObject
+
+	request.Id = id
+
+	var body UpdateProximityRuleJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateProximityRule(ctx.UserContext(), request.(UpdateProximityRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateProximityRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateProximityRuleResponseObject); ok {
+		if err := validResponse.VisitUpdateProximityRuleResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ReplayWebhookDelivery operation middleware
+func (sh *strictHandler) ReplayWebhookDelivery(ctx *fiber.Ctx, id int64) error {
+	var request ReplayWebhookDeliveryRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayWebhookDelivery(ctx.UserContext(), request.(ReplayWebhookDeliveryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayWebhookDelivery")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ReplayWebhookDeliveryResponseObject); ok {
+		if err := validResponse.VisitReplayWebhookDeliveryResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListWebhooks operation middleware
+func (sh *strictHandler) ListWebhooks(ctx *fiber.Ctx) error {
+	var request ListWebhooksRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhooks(ctx.UserContext(), request.(ListWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhooks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListWebhooksResponseObject); ok {
+		if err := validResponse.VisitListWebhooksResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(ctx *fiber.Ctx) error {
+	var request CreateWebhookRequestObject
+
+	var body CreateWebhookJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateWebhook(ctx.UserContext(), request.(CreateWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateWebhook")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(ctx *fiber.Ctx, id int64) error {
+	var request DeleteWebhookRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebhook(ctx.UserContext(), request.(DeleteWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebhook")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateWebhook operation middleware
+func (sh *strictHandler) UpdateWebhook(ctx *fiber.Ctx, id int64) error {
+	var request UpdateWebhookRequestObject
+
+	request.Id = id
+
+	var body UpdateWebhookJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateWebhook(ctx.UserContext(), request.(UpdateWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateWebhook")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateWebhookResponseObject); ok {
+		if err := validResponse.VisitUpdateWebhookResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries operation middleware
+func (sh *strictHandler) ListWebhookDeliveries(ctx *fiber.Ctx, id int64, params ListWebhookDeliveriesParams) error {
+	var request ListWebhookDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeliveries(ctx.UserContext(), request.(ListWebhookDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeliveries")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeliveriesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ReplayFailedWebhookDeliveries operation middleware
+func (sh *strictHandler) ReplayFailedWebhookDeliveries(ctx *fiber.Ctx, id int64) error {
+	var request ReplayFailedWebhookDeliveriesRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayFailedWebhookDeliveries(ctx.UserContext(), request.(ReplayFailedWebhookDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayFailedWebhookDeliveries")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ReplayFailedWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitReplayFailedWebhookDeliveriesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// TriggerSos operation middleware
+func (sh *strictHandler) TriggerSos(ctx *fiber.Ctx) error {
+	var request TriggerSosRequestObject
+
+	var body TriggerSosJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.TriggerSos(ctx.UserContext(), request.(TriggerSosRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TriggerSos")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(TriggerSosResponseObject); ok {
+		if err := validResponse.VisitTriggerSosResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSos operation middleware
+func (sh *strictHandler) GetSos(ctx *fiber.Ctx, id int64) error {
+	var request GetSosRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSos(ctx.UserContext(), request.(GetSosRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSos")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSosResponseObject); ok {
+		if err := validResponse.VisitGetSosResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// IngestUpdate operation middleware
+func (sh *strictHandler) IngestUpdate(ctx *fiber.Ctx) error {
+	var request IngestUpdateRequestObject
+
+	var body IngestUpdateJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.IngestUpdate(ctx.UserContext(), request.(IngestUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "IngestUpdate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(IngestUpdateResponseObject); ok {
+		if err := validResponse.VisitIngestUpdateResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListWatches operation middleware
+func (sh *strictHandler) ListWatches(ctx *fiber.Ctx) error {
+	var request ListWatchesRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWatches(ctx.UserContext(), request.(ListWatchesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWatches")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListWatchesResponseObject); ok {
+		if err := validResponse.VisitListWatchesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateWatch operation middleware
+func (sh *strictHandler) CreateWatch(ctx *fiber.Ctx) error {
+	var request CreateWatchRequestObject
+
+	var body CreateWatchJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateWatch(ctx.UserContext(), request.(CreateWatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateWatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateWatchResponseObject); ok {
+		if err := validResponse.VisitCreateWatchResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteWatch operation middleware
+func (sh *strictHandler) DeleteWatch(ctx *fiber.Ctx, id int64) error {
+	var request DeleteWatchRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWatch(ctx.UserContext(), request.(DeleteWatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteWatchResponseObject); ok {
+		if err := validResponse.VisitDeleteWatchResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -205,16 +2840,63 @@ func (sh *strictHandler) IngestUpdate(ctx *fiber.Ctx) error {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7xUTW/bMAz9KwG3oxenH9vBt3ZbhwA7DC16KnqQJTpRJ0saRRXzCv/3QVKaxG2A9TDk",
-	"ZJKS+PgeST+BdL13Fi0HaJ4gyDX2IpsXUrpo+YYFxxzw5DwSa8yetkErvEIrNz5jn43OUS8YGtCWP51D",
-	"BTx4LC6ukGDcRgSRGJLvus5oi+n15qh1zqCwMI4VEP6KmlBBczdF3T283+Z07QNKTkm/oUUS5nXlSOQo",
-	"GQo7EQ1DwxSxArSxh+YuObt820Iq6MNqr8LApO0qxUNW6LNT+wSe2Vbw+4Prkzieh4L0klOpp+SfZDvE",
-	"6ruTgrWzXwSL19SElJGEHCZ9UC62BneNsLFvSx+EUoQhHGRlBGuOhdMbUhlnV2+//0KBLdZ+nmrH5pAQ",
-	"t14JxsMymI1IyX5P2EED7+rdoNebKa8nYo7jK5QU0rZzKY90loXkZFrRp1vXrjOXKKSzUEEkAw2smX1o",
-	"6rrN4Tm5zuDPgMOcYp57zWb68nR28WMJFTwihVwwnMwX80VeCo9WeA0NnM0X87RIXvA686tjJl9ru8KQ",
-	"S/KufJMKmdJSQQPLfF6UgiI5Br50anhmhDY/E94bXbSoH0IRroj0Lwn32jBO25pHPQWCdzaUxpwuFmXx",
-	"giTtS4vgJkqZpnCs4Lyc/5fKnvc/lzVFvBRqdl3EKKgnx0C9tSLy2pH+g6rAnh0D9spRq5XC/A/7eByB",
-	"l5aRrDCzG6RHpNnX/ItL90Lse0HDdjhnZYBCyRLy9QDN3cshKWtStqx+PIHxfvwbAAD//ykjTbK8BgAA",
+	"H4sIAAAAAAAC/+xd3XPbNrb/VzC89+HeGcZSPtrZ+s2Jm9azdpuN0+ahk8lAxJGEmgQYALStzfh/38EH",
+	"SZAEJdKxZHfLl9YUCeDgnN/5xCHzNUp4lnMGTMno+GskkzVk2Px5kiS8YOoSlKJsZX7KBc9BKArmCidJ",
+	"IXCyeYsTxYX+5X8FLKPj6H9m9aQzN+PspPn0XRwRQa/hJAVhlyYgE0FzRTmLjqOPa1BrEMg8hKTCQiHM",
+	"CFpSRuUaYTMMYQFIAlNoyQVSayoRtlRHcaQ2OUTH0YLzFDDTC1KWUga/U0kXKfQvyc1/M8gWICRKMEMp",
+	"51eN6VGRoxuq1kitAS24QnZqlHECMVriVAJabBCBJS7SMDEpZqsCr2AX387L5/QYfvMaKwVi82EtQK55",
+	"SvTwDN/SrMii4x9+iKOMMnvxvFqVMgUrMDzPcP5O8GtKYKfALrxH7+KIcUWXNMGaWUZeVEEmd03yizfq",
+	"PS+U2YYjCwuBN/qaL5eae9WeLigrFMjG1p7P5/+Y79qdzAHIOc2o+me27gr4Ut+mbNVBz80aGILbBIAA",
+	"idEcESrxIgWp5ZtFcU3Gy7lPxLxLRL09vvgTEqXJKjVJYVUE9MhAfBcjT/VDegawSJaUwFtgCTRlseQi",
+	"w8oS9P2rKMSkNvdrUOkJukB10gnflI6lobt3cSTgS0EFkOj4jybN9bQNArwJP4UZ2bI4TQH/zG9QhtkG",
+	"Cci5UECQs1EUrLQxIUCQ4mipyTAGJRf8ViNmgwQmlMaIX4MQlFjhIwniGoSnyRWHCS+0GYl9jAaxwQpt",
+	"SQz9GnenkNJrx+2OQdU4OSMDJamZluXWenbvJmvMGKRjNPSNG6JHC8AKmpQQrOCZohnU1EgltLDu4giu",
+	"gamdTkAz4Ef95IdNbpE8dLMplupHIazYO6szuFUnlh3DSZbAxjxdqe/OHZYidhqvlQ6LFagg6QpuQzfa",
+	"2kOi2ANIye9azG6iik4PHk321LINqliA/o6enTBrQhGV2oAam3qzpikgjG64uAKBtB2XiFqnTSUSoAQF",
+	"gujSqJV7inCQiHFVuvUFLLkARJVEKWAJCG5zKoy9AKa16o8ot+tFRnz1X0Y1MU0b26qZ3ALe8ddqPmMJ",
+	"PgNTIKLYXaWAr6Fhozhzfyyspfqc8hvfWrlo5rMJVKoru6sojm6wSvT/K2PzOQNQjR9yPTBEurONp1jh",
+	"rslI1liseuxvHKVwbQ2A70Z3+i8fdnaGuF4nhJlTileMS0UTGaIQkqvhAcPPgFO1fqMHhZzViv8OQhoU",
+	"BlRpxQUvFGXQYxKdPO3sofiPGXC6x5AhHQTSpgdpWlMwPgXlWMooHmg3cs53GuF3nKda2Yyt+FJAATsZ",
+	"9S/91Cnkam0GCbimvXwxqBxjzK97mdzCR/mgL5l6vYZEHCeq/cUlNIKQqsOdDqK0OC74tUP9sA1l+NZE",
+	"fy4u7Drxjq82ezjHiqqCwKgxnK1GDxounJYAalZ7bGlT36GsyZCQAEyc1uX9CHc9inPpSKYxnEEQ6jqI",
+	"K+RQ1nu57TZdM8yoEuGgazYEedv2t1RR5a3Yy/N95duhxOQnYCBw2l0KykCrjHuPlSigcsL64lMoqc3k",
+	"qs8AqUK+4QQChjmObp/xjJroZGNXarPY0mPnb8wWYqPvQvp31qGxF1L8akB24+TPr4IknXvJftPplHcQ",
+	"X1bFhAykxCudsjBS5qomT1XcPFPXOJw8IlGYi2D8cM5tcB8OIEpQDdQYTIgAKYN82rPKm1irJ6lHlKEM",
+	"lK7X5CCQhIQzEk7W+vOzdugTVuWKYSE5XzTLK01KL3Bu0kmagBFjStmV1JSPkDniOTBEmSf7DWYEbo2v",
+	"5SuzRy6zKI5erKjUvwIPwiKU93lhsYIUVgLriW5gseb8Si+ZYao9OFPLjVlQ0eVm5+y27hMKXb8hPzXp",
+	"z/Cwspt4tiPL3gytBYuS7JD46zAuoGdmijdGcQem93bIaSGwcsFYE1AfuMIp0mECkrmpY2GqfYephiac",
+	"MUiUqdYFBOQmJ284Y53K0csXQYKMgT4ZvxFKUhizToZvxzyuNBuGD2jJs1qsMZFPdJtZcVOYIb50hReE",
+	"S5n8vS/SHgVZjYnaR9dsCJUKuzhvgAVeUiHVycgi1eCI0RSYNxcAKuRuy/vvsOi5b43+WPIUX5mq/5AC",
+	"Jok6POgu63G1safGBrx1t5djGhA5Y3mhAvXjrVL0U/95I/t/Pn8oGT+G6FrCGSOXEKP9dLrDYVtY+mj9",
+	"4NA6u/bUl3zo066sVR+HDR8ziq4W25rLdqeM23uv9xXi4nvAhDKQAR4+bB1IACabATprn9taangPeYo3",
+	"70GaPKdD9mA/11rZDgwteMnlj2Wx/NsOAMYbfFCYpuHw3f6wXSol6TaIau/YUeOm2rb1dhFWCbpagTCD",
+	"QSY4dRPh5IrxmxTIqqeue8nlGUsoeRB2NlYbzFN/1OvNviTnuEI5Oy+Lut1ZRwbFFRADCjbYbbvE5R4n",
+	"GDVe2purdtKDoffwpQAZkHjqct2dh+p+TtzcRIZvz4Gt1Np6zFD9rUPSb7mWXTjBXtRnq9so8qv8Jjm+",
+	"z0ZCtH00Rw+PYGqosOnHri0YAk+rp8eHpeUZ0WDSzCHPQwewu9Be86OBfEf7pz7JnfpsLM0lFqZlII7s",
+	"QVXIMJrBPQHjWNk/kCibyeupu1MWOMwRGeI3DERdzYlRIYHY/gwnNUTtiaGEnafxrXj3u3k/eM7YvZtO",
+	"xqCpBZIwPoJYsPGXC2e/oS7fU+XsLWlvIWZLI8P2xoTxUYtZ6MG7Edw+9tGPoO9ebit637dnIceblGMy",
+	"ZmvDmxdagq3bF2SxqHT37P72sDVN3cQwvGeh5sAAZPb2LyAHqc1T6WCoIb61jaEh1j67PlDKpS25l0a6",
+	"UAcTQjVPcfrOo8SeFXWEY1XrXnlHV1ND8DIz+VCpD0oMwVsQE8xKChPWHeEkgdxOaGz9kWkW8a5TWKp6",
+	"taO6a6T6xXaPbJHopacZgSR0dLbAdPsiCVdbRmYJISt572xBQiJ6+qAKkQ50TPrJahv1ZqvZt5fUAhzv",
+	"iZMOzcWaORllVRLyfdzLq4rhhaA7OwUaXOsyRj9O2ZLbogdTODGU2Hghes+X6WvAiQlfzerRWqlcHs9m",
+	"C/PzkeDLFK4kbI5EYTZHVdoc+QKdvDuLvOaS6PnR/Ghe1spwTqPj6OXR/OiVsfKuEjdzSjTzD+vdSY0W",
+	"Fi59UvQTqHbPuuaAzDmTVqAv5vNye86A4jxP3eHS7E9pdc+KbMD5emMpw8DW0WSRJCCNA301f/5gK5en",
+	"9oEVf2O4UGsu6L+B2GVfHmLZt1wsKCFgFOO7+fwQa54xBYLhFF3aDl0bi92ZYCXLsNhYRCAnJ1QLyoAr",
+	"CfWHg7JNv0sK2vfnAswRLGXuaJ5sYvOXeUNAoiuAXF9Tga5xWoA8Qh/W+mzX9bF7rcT/19PjHqNAT3+M",
+	"Go3sMWp2fvy/eSeBs3SDFoDcKZF+3UCThklG2VEUt3TDVihC6mEqKa852TyY0IKaUVsi09/xhBTzIGh9",
+	"jQkqq1aTOXgkc2CVIGAR7uJoZhRnhqvzl6CLOadS+R3S1PY1YoFtvSI6/uNrRDU9Xwr7PoPzn1WCMxDD",
+	"oS7yuzg8d6rtRGPqqmtLlzyqasZ38x21jLtP36iWw1sxyr11w6BJWydtFRuna8iABXna5ukqabad98WE",
+	"fnf6Ht2Ov8wUCz7NWLApowpIy+o9ul6jX722tn/7aJYabRcnSD2ShXLI6MBp9pWSu0bSGgoTdJpbe3JT",
+	"3WhGyr5b311z/RRHeRGAsI18mv3l+wn+263yBw39nepMIcRjKuir+atDrPkLV+gtLxh5mkmGgWIwxahe",
+	"+XsminSH22m0/x3G/TSWnNzQX8UNVWJDFirmxUMZgNUbUxpvSnk/riDQu3pgf9DC8uQXJo0Rm0oHWjqz",
+	"xUabWM4WVlJQ0FWqU/N7V6m6uJ5s598+QrBg6aAvfvwEYfIKk1eYbMEjZAv9nsi9fPmMVCVQW1gQ5q2F",
+	"A5YVgsGkfXei3Q+4R91tLzXlI5MebSogIgcP5OGjrUnbE27vPaf9p9qh3q8p4f6LJNwl1Hzp7Uy7P1av",
+	"0u8jtOrtbDtwgBXE9RRmTdrjJ98h/QmZ64G5t69ZU9Y9RQh9WXcYdk8g955cw+QaJrvwaBn4KHc0q7Px",
+	"IdnEnpsFe97a+i9pF+xk/FPD4GQ9nmYq6LcpHiai6LNPT6k6+Nb/gEzDFO4tymh80mWqoEyq6pUILRyD",
+	"Oqu1SdovNoUB/cF+puaS76t7zvu2yYEDc/9DOpNLfVw9ffHDIdb8wDm60P9YhuOyfGL66pQNXf56Walm",
+	"VQzqa/YvVfMJaMnkV/4efkW/WHD56yWqgXG42M9+oWBG2ar8HFbQb52Z+zbT3ZPn8j6BNdxzTT5mOg9w",
+	"4EQWQC4KMx+D2lVccc8cpAih15rOZv8qZ7MXG1SiY9eBrPuHefZSc68/wXboKruF6xTGT0rROHJ1sKgN",
+	"7NDD1UpLpqPVKQLuPVotneRBol9NgSHJLtL6wNu7s+o7RbPr59Hdp7v/DACQ89yB6nYAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
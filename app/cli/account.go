@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"roflbeacon2/app/service/account"
+	"roflbeacon2/pkg/database"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+func newAccountCmd(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account",
+		Short: "Manage accounts",
+	}
+
+	var chatID int64
+
+	create := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an account and print its token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				var chatIDOpt *int64
+				if cmd.Flags().Changed("chat-id") {
+					chatIDOpt = &chatID
+				}
+
+				acc, err := do.MustInvoke[*account.Service](di).Create(cmd.Context(), args[0], chatIDOpt)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Created account %d (%s)\nToken: %s\n", acc.ID, acc.Name, acc.Token)
+
+				return nil
+			})
+		},
+	}
+	create.Flags().Int64Var(&chatID, "chat-id", 0, "telegram chat id of the account")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the accounts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				queries := do.MustInvoke[*database.Queries](di)
+
+				accounts, err := queries.GetAllAccounts(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("get all accounts: %w", err)
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tNAME\tCHAT ID\tOFFLINE\tLAST UPDATE")
+
+				for _, acc := range accounts {
+					chat, lastUpdate := "-", "-"
+					if acc.ChatID != nil {
+						chat = strconv.FormatInt(*acc.ChatID, 10)
+					}
+
+					updates, err := queries.GetLastUpdateByAccountID(cmd.Context(), acc.ID)
+					if err != nil {
+						return fmt.Errorf("get last update: %w", err)
+					}
+					if len(updates) > 0 {
+						lastUpdate = updates[0].Created.Format(time.DateTime)
+					}
+
+					fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n", acc.ID, acc.Name, chat, acc.Status.Offline, lastUpdate)
+				}
+
+				return w.Flush()
+			})
+		},
+	}
+
+	var rotate bool
+
+	token := &cobra.Command{
+		Use:   "token NAME|ID",
+		Short: "Print the token of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				acc, err := findAccount(cmd.Context(), do.MustInvoke[*database.Queries](di), args[0])
+				if err != nil {
+					return err
+				}
+
+				if rotate {
+					if err = do.MustInvoke[*account.Service](di).RotateToken(cmd.Context(), &acc); err != nil {
+						return err
+					}
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), acc.Token)
+
+				return nil
+			})
+		},
+	}
+	token.Flags().BoolVar(&rotate, "rotate", false, "replace the token with a new one first")
+
+	cmd.AddCommand(create, list, token)
+
+	return cmd
+}
+
+// findAccount looks the account up by name and then by id.
+func findAccount(ctx context.Context, queries *database.Queries, ref string) (database.Account, error) {
+	acc, err := queries.GetAccountByName(ctx, ref)
+	if err == nil {
+		return acc, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return database.Account{}, fmt.Errorf("get account by name: %w", err)
+	}
+
+	if id, parseErr := strconv.ParseInt(ref, 10, 64); parseErr == nil {
+		acc, err = queries.GetAccount(ctx, id)
+		if err == nil {
+			return acc, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return database.Account{}, fmt.Errorf("get account: %w", err)
+		}
+	}
+
+	return database.Account{}, fmt.Errorf("account %q not found", ref)
+}
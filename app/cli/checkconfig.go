@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"roflbeacon2/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+func newCheckConfigCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-config",
+		Short: "Load and validate the config with the environment overrides applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := config.Load(*configPath)
+			if err != nil {
+				return fmt.Errorf("config %s is invalid: %w", *configPath, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Config %s is valid\n", *configPath)
+			fmt.Fprintf(cmd.OutOrStdout(), "Telegram mode: %s\n", cfg.Telegram.Mode)
+			fmt.Fprintf(cmd.OutOrStdout(), "Database: %s/%s\n", cfg.DB.Host, cfg.DB.Database)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"roflbeacon2/pkg/database"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+// timeLayouts are the accepted formats of the time flags, the ones without a zone are in local time.
+var timeLayouts = []string{time.RFC3339, time.DateTime, "2006-01-02 15:04", time.DateOnly}
+
+func newExportCmd(configPath *string) *cobra.Command {
+	var since, until, format, output string
+
+	cmd := &cobra.Command{
+		Use:   "export NAME|ID",
+		Short: "Write the track of an account as jsonl or gpx",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != formatJSONL && format != formatGPX {
+				return fmt.Errorf("unknown format %q", format)
+			}
+
+			now := time.Now()
+
+			sinceTime, err := parseTime(since, now.Add(-24*time.Hour))
+			if err != nil {
+				return fmt.Errorf("parse since: %w", err)
+			}
+
+			untilTime, err := parseTime(until, now)
+			if err != nil {
+				return fmt.Errorf("parse until: %w", err)
+			}
+
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				queries := do.MustInvoke[*database.Queries](di)
+
+				acc, err := findAccount(cmd.Context(), queries, args[0])
+				if err != nil {
+					return err
+				}
+
+				updates, err := queries.GetUpdatesByAccountIDBetween(cmd.Context(), database.GetUpdatesByAccountIDBetweenParams{
+					AccountID: acc.ID,
+					Since:     sinceTime,
+					Until:     untilTime,
+				})
+				if err != nil {
+					return fmt.Errorf("get updates: %w", err)
+				}
+
+				return writeOutput(cmd, output, func(w io.Writer) error {
+					if format == formatGPX {
+						return writeGPX(w, acc.Name, updates)
+					}
+
+					return writeJSONL(w, updates)
+				})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "start of the period, 24 hours ago by default")
+	cmd.Flags().StringVar(&until, "until", "", "end of the period, now by default")
+	cmd.Flags().StringVar(&format, "format", formatJSONL, "jsonl (replayable, all updates) or gpx (locations only)")
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "file to write, - for stdout")
+
+	return cmd
+}
+
+func parseTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported time %q", value)
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	fallback := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: fallback},
+		{name: "rfc3339", value: "2026-05-01T08:30:00+03:00", want: time.Date(2026, 5, 1, 5, 30, 0, 0, time.UTC)},
+		{name: "date time", value: "2026-05-01 08:30:15", want: time.Date(2026, 5, 1, 8, 30, 15, 0, time.Local)},
+		{name: "date time without seconds", value: "2026-05-01 08:30", want: time.Date(2026, 5, 1, 8, 30, 0, 0, time.Local)},
+		{name: "date", value: "2026-05-01", want: time.Date(2026, 5, 1, 0, 0, 0, 0, time.Local)},
+		{name: "unsupported", value: "yesterday", wantErr: true},
+		{name: "day first", value: "01.05.2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.value, fallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTime(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
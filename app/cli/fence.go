@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+func newFenceCmd(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fence",
+		Short: "Import and export fences",
+	}
+
+	var output string
+
+	export := &cobra.Command{
+		Use:   "export",
+		Short: "Write all fences as JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				fences, err := do.MustInvoke[*database.Queries](di).GetAllFences(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("get all fences: %w", err)
+				}
+
+				result := make([]api.Fence, 0, len(fences))
+				for _, fence := range fences {
+					result = append(result, api.Fence{
+						Id:        fence.ID,
+						Name:      fence.Name,
+						Latitude:  fence.Latitude,
+						Longitude: fence.Longitude,
+						Radius:    fence.Radius,
+						Settings:  fence.Settings,
+					})
+				}
+
+				return writeOutput(cmd, output, func(w io.Writer) error {
+					encoder := json.NewEncoder(w)
+					encoder.SetIndent("", "  ")
+
+					return encoder.Encode(result)
+				})
+			})
+		},
+	}
+	export.Flags().StringVarP(&output, "output", "o", "-", "file to write, - for stdout")
+
+	var dryRun bool
+
+	importCmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Create or update fences by name from a JSON file written by export",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("read fences: %w", err)
+			}
+
+			var fences []api.Fence
+			if err = json.Unmarshal(data, &fences); err != nil {
+				return fmt.Errorf("parse fences: %w", err)
+			}
+
+			for _, fence := range fences {
+				if err = validateFence(fence); err != nil {
+					return err
+				}
+			}
+
+			if dryRun {
+				for _, fence := range fences {
+					fmt.Fprintf(cmd.OutOrStdout(), "Would import %s: %.5f, %.5f, radius %.0f m\n", fence.Name, fence.Latitude, fence.Longitude, fence.Radius)
+				}
+
+				return nil
+			}
+
+			return withInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				tx, err := do.MustInvoke[*pgxpool.Pool](di).Begin(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to begin transaction: %w", err)
+				}
+				defer tx.Rollback(cmd.Context()) //nolint:errcheck
+
+				qtx := do.MustInvoke[*database.Queries](di).WithTx(tx)
+
+				for _, fence := range fences {
+					if _, err = qtx.UpsertFence(cmd.Context(), database.UpsertFenceParams{
+						Name:      fence.Name,
+						Longitude: fence.Longitude,
+						Latitude:  fence.Latitude,
+						Radius:    fence.Radius,
+						Settings:  fence.Settings,
+					}); err != nil {
+						return fmt.Errorf("upsert fence %s: %w", fence.Name, err)
+					}
+				}
+
+				if err = tx.Commit(cmd.Context()); err != nil {
+					return fmt.Errorf("failed to commit transaction: %w", err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Imported %d fences\n", len(fences))
+
+				return nil
+			})
+		},
+	}
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "only validate the file and list the fences")
+
+	cmd.AddCommand(export, importCmd)
+
+	return cmd
+}
+
+func validateFence(fence api.Fence) error {
+	switch {
+	case fence.Name == "":
+		return fmt.Errorf("fence without a name")
+	case fence.Latitude < -90 || fence.Latitude > 90 || fence.Longitude < -180 || fence.Longitude > 180:
+		return fmt.Errorf("fence %s: coordinates out of range", fence.Name)
+	case fence.Radius <= 0:
+		return fmt.Errorf("fence %s: radius must be positive", fence.Name)
+	case fence.Settings.AccuracyFactor != nil && (*fence.Settings.AccuracyFactor < 0 || *fence.Settings.AccuracyFactor > 10):
+		return fmt.Errorf("fence %s: accuracy factor must be between 0 and 10", fence.Name)
+	}
+
+	return nil
+}
+
+// writeOutput writes to the file at path, or to the command's output if the path is "-".
+func writeOutput(cmd *cobra.Command, path string, write func(w io.Writer) error) error {
+	if path == "-" {
+		return write(cmd.OutOrStdout())
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+
+	if err = write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/util"
+	"testing"
+)
+
+func TestValidateFence(t *testing.T) {
+	valid := api.Fence{Name: "home", Latitude: 55.75, Longitude: 37.61, Radius: 100}
+
+	tests := []struct {
+		name    string
+		modify  func(f *api.Fence)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*api.Fence) {}},
+		{name: "bounds", modify: func(f *api.Fence) { f.Latitude, f.Longitude = -90, 180 }},
+		{name: "accuracy factor", modify: func(f *api.Fence) { f.Settings.AccuracyFactor = util.ToPtr(0.0) }},
+		{name: "no name", modify: func(f *api.Fence) { f.Name = "" }, wantErr: true},
+		{name: "latitude out of range", modify: func(f *api.Fence) { f.Latitude = 90.1 }, wantErr: true},
+		{name: "longitude out of range", modify: func(f *api.Fence) { f.Longitude = -181 }, wantErr: true},
+		{name: "zero radius", modify: func(f *api.Fence) { f.Radius = 0 }, wantErr: true},
+		{name: "negative radius", modify: func(f *api.Fence) { f.Radius = -5 }, wantErr: true},
+		{name: "accuracy factor too big", modify: func(f *api.Fence) { f.Settings.AccuracyFactor = util.ToPtr(10.5) }, wantErr: true},
+		{name: "negative accuracy factor", modify: func(f *api.Fence) { f.Settings.AccuracyFactor = util.ToPtr(-1.0) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fence := valid
+			tt.modify(&fence)
+
+			if err := validateFence(fence); (err != nil) != tt.wantErr {
+				t.Errorf("validateFence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
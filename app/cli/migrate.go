@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"roflbeacon2/pkg/migration"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd(configPath *string) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withUncheckedInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				if !dryRun {
+					return migration.Migrate(cmd.Context(), di)
+				}
+
+				pending, err := migration.Pending(cmd.Context(), di)
+				if err != nil {
+					return err
+				}
+
+				if len(pending) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No pending migrations")
+					return nil
+				}
+
+				for _, m := range pending {
+					fmt.Fprintf(cmd.OutOrStdout(), "Would apply %s\n", m.Id())
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only list the migrations that would be applied")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and when they were applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withUncheckedInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				states, err := migration.Status(cmd.Context(), di)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tAPPLIED\tSTATE")
+
+				for _, state := range states {
+					applied, status := "-", "pending"
+					if state.Applied != nil {
+						applied, status = state.Applied.Format(time.DateTime), "applied"
+					}
+
+					switch {
+					case state.Unknown:
+						status = "unknown to this build"
+					case state.Modified:
+						status = "modified after applying"
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\n", state.ID, applied, status)
+				}
+
+				return w.Flush()
+			})
+		},
+	})
+
+	cmd.AddCommand(newMigrateDownCmd(configPath))
+
+	return cmd
+}
+
+func newMigrateDownCmd(configPath *string) *cobra.Command {
+	var (
+		steps  int
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the last applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if steps < 1 {
+				return fmt.Errorf("steps must be positive")
+			}
+
+			return withUncheckedInjector(cmd.Context(), *configPath, func(di *do.Injector) error {
+				if !dryRun {
+					return migration.Rollback(cmd.Context(), di, steps)
+				}
+
+				states, err := migration.Status(cmd.Context(), di)
+				if err != nil {
+					return err
+				}
+
+				for i := len(states) - 1; i >= 0 && steps > 0; i-- {
+					if states[i].Applied != nil && !states[i].Unknown {
+						fmt.Fprintf(cmd.OutOrStdout(), "Would roll back %s\n", states[i].ID)
+						steps--
+					}
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&steps, "steps", 1, "how many migrations to roll back")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only list the migrations that would be rolled back")
+
+	return cmd
+}
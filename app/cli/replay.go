@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"roflbeacon2/app/service/account"
+	"roflbeacon2/app/service/ingest"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/database"
+	"strings"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+func newReplayCmd(configPath *string) *cobra.Command {
+	var speed, accuracy float64
+
+	cmd := &cobra.Command{
+		Use:   "replay NAME|ID FILE",
+		Short: "Feed a jsonl or gpx track through ingest as the account",
+		Long: "Feed a jsonl or gpx track through ingest as the account. The updates are stored with the current time " +
+			"and the resulting alerts are queued for the running server to deliver.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if speed < 0 {
+				return fmt.Errorf("speed must not be negative")
+			}
+
+			points, err := readTrack(args[1], accuracy)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			return withInjector(ctx, *configPath, func(di *do.Injector) error {
+				acc, err := findAccount(ctx, do.MustInvoke[*database.Queries](di), args[0])
+				if err != nil {
+					return err
+				}
+
+				accountService := do.MustInvoke[*account.Service](di)
+				ingestService := do.MustInvoke[*ingest.Service](di)
+
+				// the followers' live locations move along with the replay
+				go do.MustInvoke[*telegram.Service](di).RunLiveLocations(ctx)
+
+				accCtx := accountService.WithCtxAccount(ctx, &acc)
+
+				for i, point := range points {
+					if i > 0 && speed > 0 {
+						if err = sleep(ctx, time.Duration(float64(point.Created.Sub(points[i-1].Created))/speed)); err != nil {
+							return err
+						}
+					}
+
+					if err = ingestService.Ingest(accCtx, ingest.ProtocolReplay, point.Data); err != nil {
+						return fmt.Errorf("ingest point %d: %w", i+1, err)
+					}
+
+					slog.InfoContext(ctx, "Replayed point",
+						slog.Int("n", i+1),
+						slog.Int("total", len(points)),
+						slog.Time("created", point.Created),
+					)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d points as %s\n", len(points), acc.Name)
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().Float64Var(&speed, "speed", 1, "how many times faster than recorded to replay, 0 for no pauses")
+	cmd.Flags().Float64Var(&accuracy, "accuracy", 10, "accuracy in meters of gpx points")
+
+	return cmd
+}
+
+func readTrack(path string, accuracy float64) ([]trackPoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open track: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	var reader func(r io.Reader) ([]trackPoint, error)
+
+	if strings.EqualFold(filepath.Ext(path), "."+formatGPX) {
+		reader = func(r io.Reader) ([]trackPoint, error) {
+			return readGPX(r, accuracy)
+		}
+	} else {
+		reader = readJSONL
+	}
+
+	return reader(file)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"roflbeacon2/app/service/account"
+	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/health"
+	"roflbeacon2/app/service/ingest"
+	"roflbeacon2/app/service/limits"
+	"roflbeacon2/app/service/notifier"
+	"roflbeacon2/app/service/offline"
+	"roflbeacon2/app/service/proximity"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/share"
+	"roflbeacon2/app/service/sos"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/app/service/watch"
+	"roflbeacon2/app/service/webhook"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/migration"
+	"roflbeacon2/pkg/tlog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+// Execute runs the command picked by the arguments, the server if there is none.
+func Execute() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	var configPath string
+
+	root := &cobra.Command{
+		Use:          "roflbeacon2",
+		Short:        "RoflBeacon2 location server",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return serve(configPath)
+		},
+	}
+
+	root.PersistentFlags().StringVar(&configPath, "config", "config.yaml", "path to the config file")
+
+	root.AddCommand(
+		newServeCmd(&configPath),
+		newMigrateCmd(&configPath),
+		newAccountCmd(&configPath),
+		newFenceCmd(&configPath),
+		newExportCmd(&configPath),
+		newReplayCmd(&configPath),
+		newCheckConfigCmd(&configPath),
+	)
+
+	return root
+}
+
+// newInjector wires the config, the database and the services the same way for every command,
+// the services are created lazily on first use.
+func newInjector(ctx context.Context, configPath string) (*do.Injector, error) {
+	di := do.New()
+	do.ProvideValue(di, ctx)
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config load failed: %w", err)
+	}
+	do.ProvideValue(di, cfg)
+
+	i18n.SetOverrides(cfg.Tune().Texts)
+
+	if err = tlog.Init(cfg); err != nil {
+		return nil, fmt.Errorf("logging init failed: %w", err)
+	}
+
+	dbConn, err := database.NewPool(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	do.ProvideValue(di, dbConn)
+	do.ProvideValue(di, database.New(dbConn))
+
+	do.Provide(di, settings.New)
+	do.Provide(di, account.New)
+	do.Provide(di, share.New)
+	do.Provide(di, telegram.New)
+	do.Provide(di, notifier.New)
+	do.Provide(di, alert.New)
+	do.Provide(di, webhook.New)
+	do.Provide(di, limits.New)
+	do.Provide(di, watch.New)
+	do.Provide(di, proximity.New)
+	do.Provide(di, ingest.New)
+	do.Provide(di, offline.New)
+	do.Provide(di, sos.New)
+	do.Provide(di, health.New)
+
+	return di, nil
+}
+
+// withInjector runs fn with a fresh injector once the database is known to be fully migrated,
+// and tears the injector down afterwards.
+func withInjector(ctx context.Context, configPath string, fn func(di *do.Injector) error) error {
+	return withUncheckedInjector(ctx, configPath, func(di *do.Injector) error {
+		if err := migration.Verify(ctx, di); err != nil {
+			return fmt.Errorf("database is not ready, see the migrate command: %w", err)
+		}
+
+		return fn(di)
+	})
+}
+
+// withUncheckedInjector is withInjector for the commands that work on an outdated schema.
+func withUncheckedInjector(ctx context.Context, configPath string, fn func(di *do.Injector) error) error {
+	di, err := newInjector(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer shutdown(di)
+
+	return fn(di)
+}
+
+func shutdown(di *do.Injector) {
+	dbConn := do.MustInvoke[*pgxpool.Pool](di)
+
+	_ = di.Shutdown()
+	dbConn.Close()
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/controller"
+	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/offline"
+	"roflbeacon2/app/service/sos"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/app/service/webhook"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/middleware"
+	"roflbeacon2/pkg/migration"
+	"roflbeacon2/pkg/routes"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+func newServeCmd(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the API server, the bot and the background jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return serve(*configPath)
+		},
+	}
+}
+
+func serve(configPath string) error {
+	appCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	exitChan := make(chan struct{})
+
+	di, err := newInjector(appCtx, configPath)
+	if err != nil {
+		return err
+	}
+	defer shutdown(di)
+
+	slog.ErrorContext(appCtx, "Service restarted")
+
+	if err = migration.Migrate(appCtx, di); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	cfg := do.MustInvoke[*config.Config](di)
+
+	go do.MustInvoke[*telegram.Service](di).Run(appCtx)
+	go do.MustInvoke[*telegram.Service](di).RunLiveLocations(appCtx)
+	go do.MustInvoke[*offline.Service](di).RunBackgroundChecks(appCtx)
+	go do.MustInvoke[*alert.Service](di).RunDelivery(appCtx)
+	go do.MustInvoke[*sos.Service](di).RunEscalation(appCtx)
+	go do.MustInvoke[*webhook.Service](di).RunDeliveries(appCtx)
+
+	// the share streams run until their context ends, the server would wait for them on shutdown
+	streamsCtx, stopStreams := context.WithCancel(appCtx)
+	defer stopStreams()
+
+	server := controller.NewStrictServer(di)
+	handler := api.NewStrictHandler(server, nil)
+
+	app := fiber.New(fiber.Config{
+		AppName:          "RoflBeacon2 API",
+		BodyLimit:        1024 * 1024 * 10, // 10MB
+		ErrorHandler:     middleware.ErrorHandler,
+		ProxyHeader:      "X-Forwarded-For",
+		ReadTimeout:      time.Second * 60,
+		WriteTimeout:     time.Second * 60,
+		DisableKeepalive: false,
+	})
+
+	middleware.FiberMiddleware(app, di)
+
+	apiGroup := app.Group("/v1")
+	api.RegisterHandlersWithOptions(apiGroup, handler, api.FiberServerOptions{
+		BaseURL: "",
+		Middlewares: []api.MiddlewareFunc{
+			middleware.NewOpenAPIValidator(),
+		},
+	})
+
+	routes.ShareRoutes(app, di, streamsCtx)
+	routes.TelegramRoutes(app, di)
+	routes.HealthRoutes(app, di)
+	routes.NotFoundRoute(app)
+
+	go func() {
+		sighup := make(chan os.Signal, 1)
+		signal.Notify(sighup, syscall.SIGHUP)
+
+		for range sighup {
+			tunables, err := cfg.Reload()
+			if err != nil {
+				slog.ErrorContext(appCtx, "Failed to reload config",
+					slog.Any("error", err),
+				)
+				continue
+			}
+
+			i18n.SetOverrides(tunables.Texts)
+
+			slog.InfoContext(appCtx, "Config reloaded")
+		}
+	}()
+
+	go func() {
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+		<-sigint
+
+		log.Info("Shutting down server...")
+
+		stopStreams()
+
+		if err := app.Shutdown(); err != nil {
+			log.Infof("Server is shutting down! Reason: %v", err)
+		}
+
+		close(exitChan)
+	}()
+
+	log.Infof("Server started on port %d", cfg.Server.Port)
+
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+
+		log.Infof("Started metrics server on port %d", cfg.Server.MetricsPort)
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", cfg.Server.MetricsPort), nil); err != nil { //nolint:gosec
+			log.Warnf("failed to start metrics server: %v", err)
+		}
+	}()
+
+	if err := app.Listen(fmt.Sprintf(":%d", cfg.Server.Port)); err != nil {
+		log.Infof("Server stopped! Reason: %v", err)
+	}
+
+	<-exitChan
+	cancel()
+
+	log.Info("Waiting for services to finish...")
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"time"
+)
+
+const (
+	formatJSONL = "jsonl"
+	formatGPX   = "gpx"
+)
+
+// trackPoint is a line of a jsonl track file.
+type trackPoint struct {
+	Created time.Time      `json:"created"`
+	Data    api.UpdateData `json:"data"`
+}
+
+type gpxFile struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64   `xml:"lat,attr"`
+	Lon  float64   `xml:"lon,attr"`
+	Time time.Time `xml:"time"`
+}
+
+func writeJSONL(w io.Writer, updates []database.Update) error {
+	encoder := json.NewEncoder(w)
+
+	for _, update := range updates {
+		if err := encoder.Encode(trackPoint{Created: update.Created, Data: update.Data}); err != nil {
+			return fmt.Errorf("encode point: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeGPX writes the location updates as a single track, the other updates are skipped.
+func writeGPX(w io.Writer, name string, updates []database.Update) error {
+	var points []gpxPoint
+
+	for _, update := range updates {
+		if loc := update.Data.Location; loc != nil {
+			points = append(points, gpxPoint{Lat: loc.Latitude, Lon: loc.Longitude, Time: update.Created.UTC()})
+		}
+	}
+
+	file := gpxFile{
+		Version: "1.1",
+		Creator: "roflbeacon2",
+		XMLNS:   "http://www.topografix.com/GPX/1/1",
+		Tracks: []gpxTrack{{
+			Name:     name,
+			Segments: []gpxSegment{{Points: points}},
+		}},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(file); err != nil {
+		return fmt.Errorf("encode gpx: %w", err)
+	}
+
+	return nil
+}
+
+func readJSONL(r io.Reader) ([]trackPoint, error) {
+	var points []trackPoint
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var point trackPoint
+		if err := json.Unmarshal(scanner.Bytes(), &point); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		points = append(points, point)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read track: %w", err)
+	}
+
+	return points, nil
+}
+
+// readGPX turns every track point into a location update with the given accuracy, GPX has no accuracy of its own.
+func readGPX(r io.Reader, accuracy float64) ([]trackPoint, error) {
+	var file gpxFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse gpx: %w", err)
+	}
+
+	var points []trackPoint
+
+	for _, track := range file.Tracks {
+		for _, segment := range track.Segments {
+			for _, point := range segment.Points {
+				points = append(points, trackPoint{
+					Created: point.Time,
+					Data: api.UpdateData{
+						Location: &api.LocationData{
+							Latitude:  point.Lat,
+							Longitude: point.Lon,
+							Accuracy:  accuracy,
+						},
+					},
+				})
+			}
+		}
+	}
+
+	return points, nil
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+func trackUpdates() []database.Update {
+	start := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	return []database.Update{
+		{
+			Created: start,
+			Data: api.UpdateData{
+				Location: &api.LocationData{Latitude: 55.75, Longitude: 37.61, Accuracy: 12, Speed: util.ToPtr(3.5)},
+				Battery:  &api.BatteryData{Level: 80},
+			},
+		},
+		{
+			Created: start.Add(time.Minute),
+			Data:    api.UpdateData{Battery: &api.BatteryData{Level: 79, Charging: true}},
+		},
+		{
+			Created: start.Add(2 * time.Minute),
+			Data: api.UpdateData{
+				Location: &api.LocationData{Latitude: 55.76, Longitude: 37.62, Accuracy: 8, Address: util.ToPtr("Tverskaya")},
+			},
+		},
+	}
+}
+
+func TestTrackRoundTrip(t *testing.T) {
+	updates := trackUpdates()
+
+	tests := []struct {
+		name  string
+		write func(w *bytes.Buffer) error
+		read  func(r *bytes.Buffer) ([]trackPoint, error)
+		want  []trackPoint
+	}{
+		{
+			name:  formatJSONL,
+			write: func(w *bytes.Buffer) error { return writeJSONL(w, updates) },
+			read:  func(r *bytes.Buffer) ([]trackPoint, error) { return readJSONL(r) },
+			want: []trackPoint{
+				{Created: updates[0].Created, Data: updates[0].Data},
+				{Created: updates[1].Created, Data: updates[1].Data},
+				{Created: updates[2].Created, Data: updates[2].Data},
+			},
+		},
+		{
+			// GPX keeps the coordinates and the time only, the battery-only update is dropped
+			name:  formatGPX,
+			write: func(w *bytes.Buffer) error { return writeGPX(w, "alice", updates) },
+			read:  func(r *bytes.Buffer) ([]trackPoint, error) { return readGPX(r, 25) },
+			want: []trackPoint{
+				{
+					Created: updates[0].Created,
+					Data:    api.UpdateData{Location: &api.LocationData{Latitude: 55.75, Longitude: 37.61, Accuracy: 25}},
+				},
+				{
+					Created: updates[2].Created,
+					Data:    api.UpdateData{Location: &api.LocationData{Latitude: 55.76, Longitude: 37.62, Accuracy: 25}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			got, err := tt.read(&buf)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("read %d points, want %d", len(got), len(tt.want))
+			}
+
+			for i := range got {
+				if !got[i].Created.Equal(tt.want[i].Created) {
+					t.Errorf("point %d created %s, want %s", i, got[i].Created, tt.want[i].Created)
+				}
+
+				if !reflect.DeepEqual(got[i].Data, tt.want[i].Data) {
+					t.Errorf("point %d data %+v, want %+v", i, got[i].Data, tt.want[i].Data)
+				}
+			}
+		})
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", input: ""},
+		{name: "blank lines", input: "\n{\"created\":\"2026-05-01T08:00:00Z\",\"data\":{}}\n\n", want: 1},
+		{name: "broken line", input: "{\"created\":\"2026-05-01T08:00:00Z\",\"data\":{}}\n{", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readJSONL(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readJSONL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("readJSONL() read %d points, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"context"
+	"github.com/samber/oops"
+	"net/http"
+	"roflbeacon2/app/api"
+)
+
+func (s *Server) GetAccountSettings(ctx context.Context, _ api.GetAccountSettingsRequestObject) (api.GetAccountSettingsResponseObject, error) {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	return api.GetAccountSettings200JSONResponse(acc.Settings), nil
+}
+
+func (s *Server) UpdateAccountSettings(ctx context.Context, request api.UpdateAccountSettingsRequestObject) (api.UpdateAccountSettingsResponseObject, error) {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	if err := s.accountService.UpdateSettings(ctx, acc, *request.Body); err != nil {
+		return nil, err
+	}
+
+	return api.UpdateAccountSettings200JSONResponse(acc.Settings), nil
+}
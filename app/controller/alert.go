@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"context"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+
+	"github.com/elliotchance/pie/v2"
+)
+
+func (s *Server) ListAlertDeliveries(ctx context.Context, request api.ListAlertDeliveriesRequestObject) (api.ListAlertDeliveriesResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.alertService.ListDeliveries(ctx, request.Params.Status, util.GetPtrOrDefault(request.Params.Limit, 50))
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListAlertDeliveries200JSONResponse(pie.Map(alerts, mapAlertDelivery)), nil
+}
+
+func mapAlertDelivery(alert database.AlertOutbox) api.AlertDelivery {
+	return api.AlertDelivery{
+		Id:          alert.ID,
+		AccountId:   alert.AccountID,
+		Event:       api.AlertEventType(alert.Event),
+		Channel:     api.NotificationChannel(alert.Channel),
+		Target:      alert.Target,
+		Text:        alert.Text,
+		Status:      api.AlertDeliveryStatus(alert.Status),
+		Attempts:    int(alert.Attempts),
+		NextAttempt: alert.NextAttempt,
+		LastError:   alert.LastError,
+		Created:     alert.Created,
+		Sent:        alert.Sent,
+	}
+}
@@ -8,8 +8,15 @@ import (
 	"net/http"
 	"roflbeacon2/app/api"
 	"roflbeacon2/app/service/account"
+	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/health"
 	"roflbeacon2/app/service/ingest"
 	"roflbeacon2/app/service/limits"
+	"roflbeacon2/app/service/proximity"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/sos"
+	"roflbeacon2/app/service/watch"
+	"roflbeacon2/app/service/webhook"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
 )
@@ -17,29 +24,43 @@ import (
 var _ api.StrictServerInterface = (*Server)(nil)
 
 type Server struct {
-	appCtx         context.Context
-	cfg            *config.Config
-	dbConn         *pgxpool.Pool
-	queries        *database.Queries
-	accountService *account.Service
-	limitsService  *limits.Service
-	ingestService  *ingest.Service
+	appCtx           context.Context
+	cfg              *config.Config
+	dbConn           *pgxpool.Pool
+	queries          *database.Queries
+	accountService   *account.Service
+	alertService     *alert.Service
+	healthService    *health.Service
+	limitsService    *limits.Service
+	ingestService    *ingest.Service
+	proximityService *proximity.Service
+	settingsService  *settings.Service
+	sosService       *sos.Service
+	watchService     *watch.Service
+	webhookService   *webhook.Service
 }
 
 func NewStrictServer(di *do.Injector) *Server {
 	return &Server{
-		appCtx:         do.MustInvoke[context.Context](di),
-		cfg:            do.MustInvoke[*config.Config](di),
-		dbConn:         do.MustInvoke[*pgxpool.Pool](di),
-		queries:        do.MustInvoke[*database.Queries](di),
-		accountService: do.MustInvoke[*account.Service](di),
-		limitsService:  do.MustInvoke[*limits.Service](di),
-		ingestService:  do.MustInvoke[*ingest.Service](di),
+		appCtx:           do.MustInvoke[context.Context](di),
+		cfg:              do.MustInvoke[*config.Config](di),
+		dbConn:           do.MustInvoke[*pgxpool.Pool](di),
+		queries:          do.MustInvoke[*database.Queries](di),
+		accountService:   do.MustInvoke[*account.Service](di),
+		alertService:     do.MustInvoke[*alert.Service](di),
+		healthService:    do.MustInvoke[*health.Service](di),
+		limitsService:    do.MustInvoke[*limits.Service](di),
+		ingestService:    do.MustInvoke[*ingest.Service](di),
+		proximityService: do.MustInvoke[*proximity.Service](di),
+		settingsService:  do.MustInvoke[*settings.Service](di),
+		sosService:       do.MustInvoke[*sos.Service](di),
+		watchService:     do.MustInvoke[*watch.Service](di),
+		webhookService:   do.MustInvoke[*webhook.Service](di),
 	}
 }
 
 func (s *Server) IngestUpdate(ctx context.Context, request api.IngestUpdateRequestObject) (api.IngestUpdateResponseObject, error) {
-	if !s.limitsService.AllowIpRps(ctx, "ingest_update", 3) {
+	if !s.limitsService.AllowIpRps(ctx, "ingest_update", s.settingsService.IngestRps()) {
 		return nil, oops.With("statusCode", http.StatusTooManyRequests).New("Too many requests")
 	}
 
@@ -47,9 +68,18 @@ func (s *Server) IngestUpdate(ctx context.Context, request api.IngestUpdateReque
 		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
 	}
 
-	if err := s.ingestService.Ingest(ctx, *request.Body); err != nil {
+	if err := s.ingestService.Ingest(ctx, ingest.ProtocolAPI, *request.Body); err != nil {
 		return nil, err
 	}
 
 	return api.IngestUpdate200Response{}, nil
 }
+
+func (s *Server) requireAdmin(ctx context.Context) error {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil || !s.accountService.IsAdmin(acc) {
+		return oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	return nil
+}
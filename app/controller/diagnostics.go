@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"context"
+	"roflbeacon2/app/api"
+)
+
+func (s *Server) GetDiagnostics(ctx context.Context, _ api.GetDiagnosticsRequestObject) (api.GetDiagnosticsResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	diagnostics, err := s.healthService.Diagnostics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetDiagnostics200JSONResponse(diagnostics), nil
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+
+	"github.com/elliotchance/pie/v2"
+)
+
+func (s *Server) ListFences(ctx context.Context, _ api.ListFencesRequestObject) (api.ListFencesResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	fences, err := s.settingsService.ListFences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListFences200JSONResponse(pie.Map(fences, mapFence)), nil
+}
+
+func (s *Server) UpdateFenceSettings(ctx context.Context, request api.UpdateFenceSettingsRequestObject) (api.UpdateFenceSettingsResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	fence, err := s.settingsService.UpdateFenceSettings(ctx, request.Id, *request.Body)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.UpdateFenceSettings200JSONResponse(mapFence(fence)), nil
+}
+
+func mapFence(fence database.Fence) api.Fence {
+	return api.Fence{
+		Id:        fence.ID,
+		Name:      fence.Name,
+		Latitude:  fence.Latitude,
+		Longitude: fence.Longitude,
+		Radius:    fence.Radius,
+		Settings:  fence.Settings,
+	}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/samber/oops"
+)
+
+func (s *Server) ListProximityRules(ctx context.Context, _ api.ListProximityRulesRequestObject) (api.ListProximityRulesResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.proximityService.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListProximityRules200JSONResponse(pie.Map(rules, mapProximityRule)), nil
+}
+
+func (s *Server) CreateProximityRule(ctx context.Context, request api.CreateProximityRuleRequestObject) (api.CreateProximityRuleResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.proximityService.CreateRule(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateProximityRule200JSONResponse(mapProximityRule(rule)), nil
+}
+
+func (s *Server) UpdateProximityRule(ctx context.Context, request api.UpdateProximityRuleRequestObject) (api.UpdateProximityRuleResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.proximityService.UpdateRule(ctx, request.Id, *request.Body)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.UpdateProximityRule200JSONResponse(mapProximityRule(rule)), nil
+}
+
+func (s *Server) DeleteProximityRule(ctx context.Context, request api.DeleteProximityRuleRequestObject) (api.DeleteProximityRuleResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := s.proximityService.DeleteRule(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, oops.With("statusCode", http.StatusNotFound).New("Not found")
+	}
+
+	return api.DeleteProximityRule200Response{}, nil
+}
+
+func mapProximityRule(rule database.ProximityRule) api.ProximityRule {
+	return api.ProximityRule{
+		Id:              rule.ID,
+		FirstAccountId:  rule.FirstAccountID,
+		SecondAccountId: rule.SecondAccountID,
+		Distance:        rule.Distance,
+		NotifyMeet:      rule.NotifyMeet,
+		NotifyPart:      rule.NotifyPart,
+		Together:        rule.Together,
+		Changed:         rule.Changed,
+		Created:         rule.Created,
+	}
+}
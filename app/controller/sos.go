@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/ingest"
+	"roflbeacon2/pkg/database"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/samber/oops"
+)
+
+func (s *Server) TriggerSos(ctx context.Context, request api.TriggerSosRequestObject) (api.TriggerSosResponseObject, error) {
+	if !s.limitsService.AllowIpRpm(ctx, "trigger_sos", s.settingsService.SosRpm()) {
+		return nil, oops.With("statusCode", http.StatusTooManyRequests).New("Too many requests")
+	}
+
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	// the SOS goes out with the last known position if the new one can't be stored
+	if request.Body.Location != nil {
+		if err := s.ingestService.Ingest(ctx, ingest.ProtocolSOS, api.UpdateData{
+			Location: request.Body.Location,
+		}); err != nil {
+			slog.ErrorContext(ctx, "Failed to ingest SOS location",
+				slog.Int64("account_id", acc.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	incident, err := s.sosService.Trigger(ctx, acc, request.Body.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.TriggerSos200JSONResponse(mapSosIncident(incident, nil)), nil
+}
+
+func (s *Server) GetSos(ctx context.Context, request api.GetSosRequestObject) (api.GetSosResponseObject, error) {
+	if acc := s.accountService.ExtractCtxAccount(ctx); acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	incident, events, err := s.sosService.Get(ctx, request.Id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.GetSos200JSONResponse(mapSosIncident(incident, events)), nil
+}
+
+func mapSosIncident(incident database.SosIncident, events []database.SosEvent) api.SosIncident {
+	return api.SosIncident{
+		Id:              incident.ID,
+		AccountId:       incident.AccountID,
+		Created:         incident.Created,
+		Message:         incident.Message,
+		EscalationLevel: int(incident.EscalationLevel),
+		AcknowledgedBy:  incident.AcknowledgedBy,
+		Acknowledged:    incident.Acknowledged,
+		Events: pie.Map(events, func(event database.SosEvent) api.SosEvent {
+			return api.SosEvent{
+				Created:   event.Created,
+				Type:      api.SosEventType(event.Type),
+				AccountId: event.AccountID,
+				Details:   event.Details,
+			}
+		}),
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/samber/oops"
+)
+
+func (s *Server) ListWatches(ctx context.Context, _ api.ListWatchesRequestObject) (api.ListWatchesResponseObject, error) {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	watches, err := s.watchService.List(ctx, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListWatches200JSONResponse(pie.Map(watches, mapWatch)), nil
+}
+
+func (s *Server) CreateWatch(ctx context.Context, request api.CreateWatchRequestObject) (api.CreateWatchResponseObject, error) {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	watch, err := s.watchService.Create(ctx, acc, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateWatch200JSONResponse(mapWatch(watch)), nil
+}
+
+func (s *Server) DeleteWatch(ctx context.Context, request api.DeleteWatchRequestObject) (api.DeleteWatchResponseObject, error) {
+	acc := s.accountService.ExtractCtxAccount(ctx)
+	if acc == nil {
+		return nil, oops.With("statusCode", http.StatusForbidden).New("Forbidden")
+	}
+
+	found, err := s.watchService.Delete(ctx, acc, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, oops.With("statusCode", http.StatusNotFound).New("Not found")
+	}
+
+	return api.DeleteWatch200Response{}, nil
+}
+
+func mapWatch(watch database.Watch) api.Watch {
+	return api.Watch{
+		Id:        watch.ID,
+		AccountId: watch.TargetID,
+		FenceId:   watch.FenceID,
+		Distance:  watch.Distance,
+		Direction: api.WatchDirection(watch.Direction),
+		Created:   watch.Created,
+		Expires:   watch.Expires,
+	}
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/oops"
+)
+
+func (s *Server) ListWebhooks(ctx context.Context, _ api.ListWebhooksRequestObject) (api.ListWebhooksResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListWebhooks200JSONResponse(pie.Map(subscriptions, mapWebhookSubscription)), nil
+}
+
+func (s *Server) CreateWebhook(ctx context.Context, request api.CreateWebhookRequestObject) (api.CreateWebhookResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.webhookService.CreateSubscription(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateWebhook200JSONResponse(mapWebhookSubscription(subscription)), nil
+}
+
+func (s *Server) UpdateWebhook(ctx context.Context, request api.UpdateWebhookRequestObject) (api.UpdateWebhookResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.webhookService.UpdateSubscription(ctx, request.Id, *request.Body)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.UpdateWebhook200JSONResponse(mapWebhookSubscription(subscription)), nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, request api.DeleteWebhookRequestObject) (api.DeleteWebhookResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := s.webhookService.DeleteSubscription(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, oops.With("statusCode", http.StatusNotFound).New("Not found")
+	}
+
+	return api.DeleteWebhook200Response{}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, request api.ListWebhookDeliveriesRequestObject) (api.ListWebhookDeliveriesResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookService.ListDeliveries(ctx, request.Id, request.Params.Status, util.GetPtrOrDefault(request.Params.Limit, 50))
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.ListWebhookDeliveries200JSONResponse(pie.Map(deliveries, mapWebhookDelivery)), nil
+}
+
+func (s *Server) ReplayWebhookDelivery(ctx context.Context, request api.ReplayWebhookDeliveryRequestObject) (api.ReplayWebhookDeliveryResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookService.ReplayDelivery(ctx, request.Id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.ReplayWebhookDelivery200JSONResponse(mapWebhookDelivery(delivery)), nil
+}
+
+func (s *Server) ReplayFailedWebhookDeliveries(ctx context.Context, request api.ReplayFailedWebhookDeliveriesRequestObject) (api.ReplayFailedWebhookDeliveriesResponseObject, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	count, err := s.webhookService.ReplayFailed(ctx, request.Id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	return api.ReplayFailedWebhookDeliveries200JSONResponse{Count: count}, nil
+}
+
+func mapWebhookSubscription(subscription database.WebhookSubscription) api.WebhookSubscription {
+	return api.WebhookSubscription{
+		Id:  subscription.ID,
+		Url: subscription.Url,
+		Events: pie.Map(subscription.Events, func(e string) api.WebhookEventType {
+			return api.WebhookEventType(e)
+		}),
+		Enabled: subscription.Enabled,
+		Secret:  subscription.Secret,
+		Created: subscription.Created,
+	}
+}
+
+func mapWebhookDelivery(delivery database.WebhookDelivery) api.WebhookDelivery {
+	var payload api.WebhookEvent
+	_ = json.Unmarshal(delivery.Payload, &payload)
+
+	return api.WebhookDelivery{
+		Id:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		Event:          api.WebhookEventType(delivery.Event),
+		Status:         api.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       int(delivery.Attempts),
+		NextAttempt:    delivery.NextAttempt,
+		LastError:      delivery.LastError,
+		LastStatusCode: util.PtrInt32ToPtrInt(delivery.LastStatusCode),
+		Created:        delivery.Created,
+		Delivered:      delivery.Delivered,
+		Payload:        payload,
+	}
+}
+
+func mapNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return oops.With("statusCode", http.StatusNotFound).Wrap(err)
+	}
+
+	return err
+}
@@ -3,20 +3,33 @@ package account
 import (
 	"context"
 	_ "embed"
+	"fmt"
 	"github.com/samber/do"
+	"github.com/samber/oops"
+	"net/http"
+	"net/mail"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/notifier"
+	"roflbeacon2/app/service/settings"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/maplink"
+	"roflbeacon2/pkg/netguard"
+	"roflbeacon2/pkg/util"
+	"strconv"
 )
 
 type Service struct {
-	cfg     *config.Config
-	queries *database.Queries
+	cfg             *config.Config
+	queries         *database.Queries
+	settingsService *settings.Service
 }
 
 func New(di *do.Injector) (*Service, error) {
 	return &Service{
-		cfg:     do.MustInvoke[*config.Config](di),
-		queries: do.MustInvoke[*database.Queries](di),
+		cfg:             do.MustInvoke[*config.Config](di),
+		queries:         do.MustInvoke[*database.Queries](di),
+		settingsService: do.MustInvoke[*settings.Service](di),
 	}, nil
 }
 
@@ -33,3 +46,168 @@ func (s *Service) ExtractCtxAccount(ctx context.Context) *database.Account {
 
 	return account
 }
+
+// WithCtxAccount returns the context acting as the account, the same way the auth middleware sets it.
+func (s *Service) WithCtxAccount(ctx context.Context, acc *database.Account) context.Context {
+	return context.WithValue(ctx, "account", acc) //nolint:staticcheck
+}
+
+func (s *Service) IsAdmin(acc *database.Account) bool {
+	return acc.ChatID != nil && *acc.ChatID == s.cfg.Telegram.AdminChatID
+}
+
+// Create registers an account with a fresh token.
+func (s *Service) Create(ctx context.Context, name string, chatID *int64) (database.Account, error) {
+	token, err := util.RandomToken(24)
+	if err != nil {
+		return database.Account{}, fmt.Errorf("generate token: %w", err)
+	}
+
+	id, err := s.queries.CreateAccount(ctx, database.CreateAccountParams{
+		Token:  token,
+		Name:   name,
+		ChatID: chatID,
+		Status: api.AccountStatus{InsideFences: []int64{}},
+	})
+	if err != nil {
+		return database.Account{}, fmt.Errorf("create account: %w", err)
+	}
+
+	return s.queries.GetAccount(ctx, id)
+}
+
+// RotateToken replaces the token of the account, the old one stops working immediately.
+func (s *Service) RotateToken(ctx context.Context, acc *database.Account) error {
+	token, err := util.RandomToken(24)
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+
+	if err = s.queries.UpdateAccountToken(ctx, database.UpdateAccountTokenParams{
+		ID:    acc.ID,
+		Token: token,
+	}); err != nil {
+		return fmt.Errorf("update account token: %w", err)
+	}
+
+	acc.Token = token
+
+	return nil
+}
+
+// UpdateSettings applies the fields set in patch to the account's settings, the others keep their values.
+// The threshold overrides decide when the account's alerts fire, so only the admin may change them.
+func (s *Service) UpdateSettings(ctx context.Context, acc *database.Account, patch api.AccountSettings) error {
+	if !s.IsAdmin(acc) && hasThresholds(patch) {
+		return oops.With("statusCode", http.StatusForbidden).New("Only the admin can change the thresholds")
+	}
+
+	for _, route := range util.GetPtrOrZero(patch.Notifications) {
+		if err := s.validateRoute(ctx, acc, route); err != nil {
+			return oops.With("statusCode", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	if language := patch.Language; language != nil && *language != api.LanguageRu && *language != api.LanguageEn {
+		return oops.With("statusCode", http.StatusBadRequest).Errorf("unknown language: %s", *language)
+	}
+
+	if provider := patch.MapProvider; provider != nil && !maplink.Valid(string(*provider)) {
+		return oops.With("statusCode", http.StatusBadRequest).Errorf("unknown map provider: %s", *provider)
+	}
+
+	updated, err := s.settingsService.UpdateAccountSettings(ctx, acc.ID, func(settings *api.AccountSettings) error {
+		mergeSettings(settings, patch)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update account settings: %w", err)
+	}
+
+	acc.Settings = updated.Settings
+
+	return nil
+}
+
+func hasThresholds(settings api.AccountSettings) bool {
+	return settings.OfflineThresholdMinutes != nil ||
+		settings.LowBatteryThreshold != nil ||
+		settings.SpeedLimitKmh != nil ||
+		settings.AccuracyFactor != nil
+}
+
+func mergeSettings(settings *api.AccountSettings, patch api.AccountSettings) {
+	if patch.Notifications != nil {
+		settings.Notifications = patch.Notifications
+	}
+	if patch.OfflineThresholdMinutes != nil {
+		settings.OfflineThresholdMinutes = patch.OfflineThresholdMinutes
+	}
+	if patch.LowBatteryThreshold != nil {
+		settings.LowBatteryThreshold = patch.LowBatteryThreshold
+	}
+	if patch.SpeedLimitKmh != nil {
+		settings.SpeedLimitKmh = patch.SpeedLimitKmh
+	}
+	if patch.DriveAlerts != nil {
+		settings.DriveAlerts = patch.DriveAlerts
+	}
+	if patch.InlineVisible != nil {
+		settings.InlineVisible = patch.InlineVisible
+	}
+	if patch.Language != nil {
+		settings.Language = patch.Language
+	}
+	if patch.MapProvider != nil {
+		settings.MapProvider = patch.MapProvider
+	}
+	if patch.AccuracyFactor != nil {
+		settings.AccuracyFactor = patch.AccuracyFactor
+	}
+}
+
+func (s *Service) validateRoute(ctx context.Context, acc *database.Account, route api.NotificationRoute) error {
+	target := util.GetPtrOrZero(route.Target)
+
+	switch route.Channel {
+	case api.NotificationChannelTelegram:
+		// the alerts carry live locations, so they may only go to the account's own chat or the admin's
+		if target == "" || s.IsAdmin(acc) {
+			return nil
+		}
+
+		chatID, err := strconv.ParseInt(target, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chat id: %q", target)
+		}
+
+		if chatID != s.cfg.Telegram.AdminChatID && (acc.ChatID == nil || chatID != *acc.ChatID) {
+			return fmt.Errorf("chat %d is neither the account's nor the admin's", chatID)
+		}
+	case api.NotificationChannelWebhook:
+		if err := netguard.CheckURL(ctx, target); err != nil {
+			return fmt.Errorf("webhook url: %w", err)
+		}
+	case api.NotificationChannelEmail:
+		if _, err := mail.ParseAddress(target); err != nil {
+			return fmt.Errorf("invalid email address: %q", target)
+		}
+	case api.NotificationChannelNtfy:
+		if target == "" {
+			return fmt.Errorf("target is required for %s", route.Channel)
+		}
+		if notifier.IsURL(target) {
+			if err := netguard.CheckURL(ctx, target); err != nil {
+				return fmt.Errorf("ntfy topic url: %w", err)
+			}
+		}
+	case api.NotificationChannelGotify:
+		if target == "" {
+			return fmt.Errorf("target is required for %s", route.Channel)
+		}
+	default:
+		return fmt.Errorf("unknown channel: %s", route.Channel)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"reflect"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"testing"
+)
+
+func TestUpdateSettingsForbidsThresholds(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Telegram.AdminChatID = 1
+
+	s := &Service{cfg: cfg}
+
+	tests := []struct {
+		name  string
+		patch api.AccountSettings
+	}{
+		{name: "offline", patch: api.AccountSettings{OfflineThresholdMinutes: util.ToPtr(600)}},
+		{name: "battery", patch: api.AccountSettings{LowBatteryThreshold: util.ToPtr(1)}},
+		{name: "speed", patch: api.AccountSettings{SpeedLimitKmh: util.ToPtr(0)}},
+		{name: "accuracy", patch: api.AccountSettings{AccuracyFactor: util.ToPtr(10.0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := &database.Account{ID: 2, ChatID: util.ToPtr(int64(2))}
+
+			if err := s.UpdateSettings(context.Background(), acc, tt.patch); err == nil {
+				t.Fatal("a monitored account changed its own threshold")
+			}
+		})
+	}
+}
+
+func TestMergeSettings(t *testing.T) {
+	stored := api.AccountSettings{
+		Language:      util.ToPtr(api.LanguageRu),
+		SpeedLimitKmh: util.ToPtr(90),
+		Notifications: &[]api.NotificationRoute{{Channel: api.NotificationChannelTelegram}},
+	}
+
+	tests := []struct {
+		name  string
+		patch api.AccountSettings
+		want  api.AccountSettings
+	}{
+		{name: "empty patch keeps everything", want: stored},
+		{
+			name:  "sets a field and keeps the others",
+			patch: api.AccountSettings{MapProvider: util.ToPtr(api.MapProvider("osm"))},
+			want: api.AccountSettings{
+				Language:      stored.Language,
+				SpeedLimitKmh: stored.SpeedLimitKmh,
+				Notifications: stored.Notifications,
+				MapProvider:   util.ToPtr(api.MapProvider("osm")),
+			},
+		},
+		{
+			name:  "empty list clears the routes",
+			patch: api.AccountSettings{Notifications: &[]api.NotificationRoute{}},
+			want: api.AccountSettings{
+				Language:      stored.Language,
+				SpeedLimitKmh: stored.SpeedLimitKmh,
+				Notifications: &[]api.NotificationRoute{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := stored
+			mergeSettings(&settings, tt.patch)
+
+			if !reflect.DeepEqual(settings, tt.want) {
+				t.Errorf("mergeSettings() = %+v, want %+v", settings, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTelegramRoute(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Telegram.AdminChatID = 1
+
+	s := &Service{cfg: cfg}
+
+	member := &database.Account{ID: 2, ChatID: util.ToPtr(int64(2))}
+	admin := &database.Account{ID: 1, ChatID: util.ToPtr(int64(1))}
+	unlinked := &database.Account{ID: 3}
+
+	tests := []struct {
+		name    string
+		acc     *database.Account
+		target  string
+		wantErr bool
+	}{
+		{name: "own chat by default", acc: member},
+		{name: "own chat", acc: member, target: "2"},
+		{name: "admin chat", acc: member, target: "1"},
+		{name: "admin chat without a linked chat", acc: unlinked, target: "1"},
+		{name: "other chat", acc: member, target: "-100123", wantErr: true},
+		{name: "other chat without a linked chat", acc: unlinked, target: "2", wantErr: true},
+		{name: "not a chat id", acc: member, target: "@channel", wantErr: true},
+		{name: "admin may use any chat", acc: admin, target: "-100123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := api.NotificationRoute{Channel: api.NotificationChannelTelegram}
+			if tt.target != "" {
+				route.Target = &tt.target
+			}
+
+			if err := s.validateRoute(context.Background(), tt.acc, route); (err != nil) != tt.wantErr {
+				t.Errorf("validateRoute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
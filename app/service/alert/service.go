@@ -2,47 +2,257 @@ package alert
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/samber/do"
 	"log/slog"
-	"roflbeacon2/app/service/telegram"
+	"math"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/notifier"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"time"
+)
+
+const (
+	alertTitle        = "RoflBeacon"
+	deliveryBatchSize = 50
+	maxBackoff        = time.Hour
+	// deliveryLease is how long a claimed batch is hidden from other workers,
+	// it must outlast sending the whole batch
+	deliveryLease = 10 * time.Minute
 )
 
 type Service struct {
-	appCtx          context.Context
 	cfg             *config.Config
 	queries         *database.Queries
-	telegramService *telegram.Service
+	notifierService *notifier.Service
+
+	// channels that asked to slow down, only accessed by the delivery loop
+	pausedUntil map[api.NotificationChannel]time.Time
 }
 
 func New(di *do.Injector) (*Service, error) {
 	return &Service{
-		appCtx:          do.MustInvoke[context.Context](di),
 		cfg:             do.MustInvoke[*config.Config](di),
 		queries:         do.MustInvoke[*database.Queries](di),
-		telegramService: do.MustInvoke[*telegram.Service](di),
+		notifierService: do.MustInvoke[*notifier.Service](di),
+		pausedUntil:     map[api.NotificationChannel]time.Time{},
 	}, nil
 }
 
-func (s *Service) Alert(text string, ignoreChatID *int64) {
-	accounts, err := s.queries.GetAllAccounts(s.appCtx)
+// Alert writes the alert to the outbox of every subscribed account using the given queries,
+// so that it is committed together with the caller's transaction. Delivery happens in RunDelivery.
+// The text is rendered in each recipient's language.
+func (s *Service) Alert(ctx context.Context, queries *database.Queries, event api.AlertEventType, text i18n.Text, ignoreAccountID *int64) error {
+	accounts, err := queries.GetAllAccounts(ctx)
 	if err != nil {
-		slog.ErrorContext(s.appCtx, "Failed to get all accounts",
-			slog.Any("error", err),
-		)
-		return
+		return fmt.Errorf("get all accounts: %w", err)
 	}
 
 	for _, account := range accounts {
-		if account.ChatID == nil {
+		if ignoreAccountID != nil && account.ID == *ignoreAccountID {
 			continue
 		}
 
-		if ignoreChatID != nil && *account.ChatID == *ignoreChatID {
-			continue
+		if err = s.AlertAccount(ctx, queries, &account, event, text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AlertAccount is like Alert but addresses a single account.
+func (s *Service) AlertAccount(ctx context.Context, queries *database.Queries, account *database.Account, event api.AlertEventType, text i18n.Text) error {
+	now := time.Now()
+	localized := text.Localize(account.Locale())
+
+	for _, route := range s.notifierService.Routes(account, event) {
+		if _, err := queries.CreateAlertOutbox(ctx, database.CreateAlertOutboxParams{
+			AccountID:   account.ID,
+			Event:       string(event),
+			Channel:     string(route.Channel),
+			Target:      route.Target,
+			Title:       alertTitle,
+			Text:        localized,
+			NextAttempt: now,
+		}); err != nil {
+			return fmt.Errorf("create alert outbox: %w", err)
 		}
+	}
+
+	return nil
+}
+
+func (s *Service) RunDelivery(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Tune().Alerts.PollInterval)
+	defer ticker.Stop()
 
-		s.telegramService.SendMessage(s.appCtx, *account.ChatID, text)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.deliverDue(ctx)
+			ticker.Reset(s.cfg.Tune().Alerts.PollInterval)
+		}
 	}
 }
+
+// deliverDue claims a batch of due alerts and sends them. The claimed alerts stay leased
+// until they are marked, so concurrent instances skip them, and the alerts of an instance
+// that died mid-batch become due again once the lease expires.
+func (s *Service) deliverDue(ctx context.Context) {
+	now := time.Now()
+
+	rows, err := s.queries.ClaimDueAlertOutbox(ctx, database.ClaimDueAlertOutboxParams{
+		LeaseUntil: now.Add(deliveryLease),
+		Now:        now,
+		MaxCount:   deliveryBatchSize,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim due alerts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, row := range rows {
+		s.deliver(ctx, &row.AlertOutbox, &row.Account)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, alert *database.AlertOutbox, acc *database.Account) {
+	channel := api.NotificationChannel(alert.Channel)
+
+	if pausedUntil, ok := s.paused(channel, time.Now()); ok {
+		s.postpone(ctx, alert, pausedUntil, alert.LastError)
+		return
+	}
+
+	err := s.notifierService.SendRoute(ctx, acc, api.NotificationRoute{
+		Channel: channel,
+		Target:  alert.Target,
+	}, notifier.Message{
+		Title: alert.Title,
+		Text:  alert.Text,
+	})
+	if err == nil {
+		if err = s.queries.MarkAlertOutboxSent(ctx, database.MarkAlertOutboxSentParams{
+			ID:   alert.ID,
+			Sent: util.ToPtr(time.Now()),
+		}); err != nil {
+			slog.ErrorContext(ctx, "Failed to mark alert as sent",
+				slog.Int64("alert_id", alert.ID),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	errText := err.Error()
+
+	if nextAttempt, ok := s.pause(channel, err, time.Now()); ok {
+		slog.WarnContext(ctx, "Alert channel is rate limited",
+			slog.String("channel", alert.Channel),
+			slog.Time("next_attempt", nextAttempt),
+		)
+
+		s.postpone(ctx, alert, nextAttempt, &errText)
+		return
+	}
+
+	attempts := int(alert.Attempts) + 1
+
+	slog.WarnContext(ctx, "Alert delivery attempt failed",
+		slog.Int64("alert_id", alert.ID),
+		slog.String("channel", alert.Channel),
+		slog.Int("attempts", attempts),
+		slog.Any("error", err),
+	)
+
+	if err = s.queries.MarkAlertOutboxAttemptFailed(ctx, database.MarkAlertOutboxAttemptFailedParams{
+		ID:          alert.ID,
+		Status:      string(s.failedAttemptStatus(attempts)),
+		NextAttempt: time.Now().Add(s.backoff(attempts)),
+		LastError:   &errText,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to mark alert attempt as failed",
+			slog.Int64("alert_id", alert.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// paused tells until when the channel asked to slow down.
+func (s *Service) paused(channel api.NotificationChannel, now time.Time) (time.Time, bool) {
+	pausedUntil, ok := s.pausedUntil[channel]
+
+	return pausedUntil, ok && now.Before(pausedUntil)
+}
+
+// pause holds the channel back if the error asks to slow down and returns when it may be used again.
+func (s *Service) pause(channel api.NotificationChannel, err error, now time.Time) (time.Time, bool) {
+	var retryAfterErr *notifier.RetryAfterError
+	if !errors.As(err, &retryAfterErr) {
+		return time.Time{}, false
+	}
+
+	pausedUntil := now.Add(retryAfterErr.After)
+	s.pausedUntil[channel] = pausedUntil
+
+	return pausedUntil, true
+}
+
+// failedAttemptStatus is the status of an alert after its attempts-th attempt failed,
+// it is given up once it runs out of attempts.
+func (s *Service) failedAttemptStatus(attempts int) api.AlertDeliveryStatus {
+	if attempts >= s.cfg.Tune().Alerts.MaxAttempts {
+		return api.AlertDeliveryStatusFailed
+	}
+
+	return api.AlertDeliveryStatusPending
+}
+
+// postpone releases the claimed alert without counting an attempt.
+func (s *Service) postpone(ctx context.Context, alert *database.AlertOutbox, nextAttempt time.Time, lastError *string) {
+	if err := s.queries.PostponeAlertOutbox(ctx, database.PostponeAlertOutboxParams{
+		ID:          alert.ID,
+		NextAttempt: nextAttempt,
+		LastError:   lastError,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to postpone alert",
+			slog.Int64("alert_id", alert.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := float64(s.cfg.Tune().Alerts.BaseBackoff) * math.Pow(2, float64(attempts-1))
+	if delay > float64(maxBackoff) {
+		return maxBackoff
+	}
+
+	return time.Duration(delay)
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, status *api.AlertDeliveryStatus, limit int) ([]database.AlertOutbox, error) {
+	var statusStr *string
+	if status != nil {
+		statusStr = util.ToPtr(string(*status))
+	}
+
+	alerts, err := s.queries.GetAlertOutbox(ctx, database.GetAlertOutboxParams{
+		Status:   statusStr,
+		MaxCount: int32(limit), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get alert outbox: %w", err)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,154 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/notifier"
+	"roflbeacon2/pkg/config"
+	"testing"
+	"time"
+)
+
+const testConfig = `
+telegram:
+  token: test
+  adminChatID: 1
+alerts:
+  baseBackoff: 10s
+  maxAttempts: 4
+`
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	return &Service{cfg: cfg, pausedUntil: map[api.NotificationChannel]time.Time{}}
+}
+
+func TestBackoff(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 4, want: 80 * time.Second},
+		{attempts: 9, want: 2560 * time.Second},
+		{attempts: 10, want: maxBackoff},
+		{attempts: 100, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := s.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestFailedAttemptStatus(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		attempts int
+		want     api.AlertDeliveryStatus
+	}{
+		{attempts: 1, want: api.AlertDeliveryStatusPending},
+		{attempts: 3, want: api.AlertDeliveryStatusPending},
+		{attempts: 4, want: api.AlertDeliveryStatusFailed},
+		{attempts: 5, want: api.AlertDeliveryStatusFailed},
+	}
+
+	for _, tt := range tests {
+		if got := s.failedAttemptStatus(tt.attempts); got != tt.want {
+			t.Errorf("failedAttemptStatus(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestPause(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	retryAfter := &notifier.RetryAfterError{After: time.Minute, Err: errors.New("too many requests")}
+
+	tests := []struct {
+		name       string
+		err        error
+		at         time.Time
+		channel    api.NotificationChannel
+		wantPause  bool
+		wantPaused bool
+	}{
+		{
+			name:       "plain error is retried with backoff",
+			err:        errors.New("connection refused"),
+			at:         now,
+			channel:    api.NotificationChannelTelegram,
+			wantPause:  false,
+			wantPaused: false,
+		},
+		{
+			name:       "rate limited channel is paused",
+			err:        retryAfter,
+			at:         now.Add(30 * time.Second),
+			channel:    api.NotificationChannelTelegram,
+			wantPause:  true,
+			wantPaused: true,
+		},
+		{
+			name:       "wrapped rate limit",
+			err:        fmt.Errorf("send: %w", retryAfter),
+			at:         now.Add(30 * time.Second),
+			channel:    api.NotificationChannelTelegram,
+			wantPause:  true,
+			wantPaused: true,
+		},
+		{
+			name:       "pause expires",
+			err:        retryAfter,
+			at:         now.Add(time.Minute),
+			channel:    api.NotificationChannelTelegram,
+			wantPause:  true,
+			wantPaused: false,
+		},
+		{
+			name:       "other channels keep going",
+			err:        retryAfter,
+			at:         now,
+			channel:    api.NotificationChannelEmail,
+			wantPause:  true,
+			wantPaused: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+
+			pausedUntil, ok := s.pause(api.NotificationChannelTelegram, tt.err, now)
+			if ok != tt.wantPause {
+				t.Fatalf("pause() = %v, want %v", ok, tt.wantPause)
+			}
+
+			if ok && !pausedUntil.Equal(now.Add(time.Minute)) {
+				t.Errorf("pause() until %v, want %v", pausedUntil, now.Add(time.Minute))
+			}
+
+			if _, paused := s.paused(tt.channel, tt.at); paused != tt.wantPaused {
+				t.Errorf("paused() = %v, want %v", paused, tt.wantPaused)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/offline"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/build"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/migration"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+)
+
+const (
+	checkTimeout = 3 * time.Second
+
+	// telegramPingInterval limits how often the probes reach the Bot API
+	telegramPingInterval = 30 * time.Second
+
+	// migrationsCheckInterval limits how often the probes compare the schema with the build
+	migrationsCheckInterval = 30 * time.Second
+
+	// offlineMissedRuns is how many check intervals the offline checker may skip before it counts as stuck
+	offlineMissedRuns = 3
+)
+
+type Service struct {
+	di              *do.Injector
+	cfg             *config.Config
+	dbConn          *pgxpool.Pool
+	queries         *database.Queries
+	telegramService *telegram.Service
+	offlineService  *offline.Service
+
+	telegramMu     sync.Mutex
+	telegramPinged time.Time
+	telegramErr    error
+
+	migrationsMu      sync.Mutex
+	migrationsChecked time.Time
+	migrationsErr     error
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		di:              di,
+		cfg:             do.MustInvoke[*config.Config](di),
+		dbConn:          do.MustInvoke[*pgxpool.Pool](di),
+		queries:         do.MustInvoke[*database.Queries](di),
+		telegramService: do.MustInvoke[*telegram.Service](di),
+		offlineService:  do.MustInvoke[*offline.Service](di),
+	}, nil
+}
+
+// Ready runs the checks of the instance's own dependencies, the service is ready if all of them pass.
+// Telegram is left out, an outage of the Bot API must not stop the instance from ingesting locations.
+func (s *Service) Ready(ctx context.Context) api.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	checks := []api.HealthCheck{
+		check("database", s.checkDatabase(ctx)),
+		check("migrations", s.checkMigrations(ctx)),
+		check("offline_checker", s.checkOffline()),
+	}
+
+	ready := true
+	for _, c := range checks {
+		ready = ready && c.Ok
+	}
+
+	return api.Readiness{Ready: ready, Checks: checks}
+}
+
+// Diagnostics describes the running process for the admin.
+func (s *Service) Diagnostics(ctx context.Context) (api.Diagnostics, error) {
+	depths, err := s.queries.GetQueueDepths(ctx)
+	if err != nil {
+		return api.Diagnostics{}, fmt.Errorf("get queue depths: %w", err)
+	}
+
+	stat := s.dbConn.Stat()
+
+	result := api.Diagnostics{
+		Version:    build.Tag,
+		GoVersion:  runtime.Version(),
+		Started:    build.Started,
+		Goroutines: runtime.NumGoroutine(),
+		Pool: api.PoolStats{
+			MaxConns:          stat.MaxConns(),
+			TotalConns:        stat.TotalConns(),
+			IdleConns:         stat.IdleConns(),
+			AcquiredConns:     stat.AcquiredConns(),
+			AcquireCount:      stat.AcquireCount(),
+			EmptyAcquireCount: stat.EmptyAcquireCount(),
+			AcquireDuration:   stat.AcquireDuration().String(),
+		},
+		Queues: api.QueueDepths{
+			PendingAlerts:   depths.PendingAlerts,
+			PendingWebhooks: depths.PendingWebhooks,
+			FailedWebhooks:  depths.FailedWebhooks,
+			OpenSos:         depths.OpenSos,
+		},
+		Checks: s.Ready(ctx).Checks,
+	}
+
+	telegramCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	result.Checks = append(result.Checks, check("telegram", s.checkTelegram(telegramCtx)))
+
+	if revision := build.Revision(); revision != "" {
+		result.Revision = &revision
+	}
+
+	if lastRun := s.offlineService.LastRun(); !lastRun.IsZero() {
+		result.OfflineCheck = &lastRun
+	}
+
+	return result, nil
+}
+
+func (s *Service) checkDatabase(ctx context.Context) error {
+	if err := s.dbConn.Ping(ctx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+
+	return nil
+}
+
+// checkMigrations reuses the last verification result for migrationsCheckInterval.
+func (s *Service) checkMigrations(ctx context.Context) error {
+	s.migrationsMu.Lock()
+	defer s.migrationsMu.Unlock()
+
+	if time.Since(s.migrationsChecked) < migrationsCheckInterval {
+		return s.migrationsErr
+	}
+
+	s.migrationsErr = migration.Verify(ctx, s.di)
+	s.migrationsChecked = time.Now()
+
+	return s.migrationsErr
+}
+
+// checkTelegram reuses the last ping result for telegramPingInterval.
+func (s *Service) checkTelegram(ctx context.Context) error {
+	s.telegramMu.Lock()
+	defer s.telegramMu.Unlock()
+
+	if time.Since(s.telegramPinged) < telegramPingInterval {
+		return s.telegramErr
+	}
+
+	s.telegramErr = s.telegramService.Ping(ctx)
+	s.telegramPinged = time.Now()
+
+	return s.telegramErr
+}
+
+func (s *Service) checkOffline() error {
+	lastRun := s.offlineService.LastRun()
+	if lastRun.IsZero() {
+		return fmt.Errorf("not running")
+	}
+
+	if since := time.Since(lastRun); since > offlineMissedRuns*s.cfg.Tune().Offline.CheckInterval {
+		return fmt.Errorf("last pass finished %s ago", since.Round(time.Second))
+	}
+
+	return nil
+}
+
+func check(name string, err error) api.HealthCheck {
+	result := api.HealthCheck{Name: name, Ok: err == nil}
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+)
+
+// batteryRearmMargin is how far above the threshold the battery must recover
+// before another low battery alert can be sent.
+const batteryRearmMargin = 5
+
+func (s *Service) handleBattery(ctx context.Context, qtx *database.Queries, acc *database.Account, data api.BatteryData) error {
+	low, alert := batteryLow(acc.Status.LowBattery, data, s.settingsService.LowBatteryThreshold(acc))
+	acc.Status.LowBattery = low
+
+	if !alert {
+		return nil
+	}
+
+	text := i18n.M("alert.battery_low", acc.Name, data.Level)
+
+	if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeBatteryLow, text, &acc.ID); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	return nil
+}
+
+// batteryLow tells whether the battery counts as low after the update and whether that is news worth an alert.
+// A low battery stays low until it recovers by the rearm margin, so a level hovering around the threshold alerts once.
+func batteryLow(wasLow bool, data api.BatteryData, threshold int) (bool, bool) {
+	if wasLow {
+		return data.Level < threshold+batteryRearmMargin, false
+	}
+
+	if data.Charging || data.Level >= threshold {
+		return false, false
+	}
+
+	return true, true
+}
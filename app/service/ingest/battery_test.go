@@ -0,0 +1,36 @@
+package ingest
+
+import (
+	"roflbeacon2/app/api"
+	"testing"
+)
+
+func TestBatteryLow(t *testing.T) {
+	const threshold = 20
+
+	tests := []struct {
+		name      string
+		wasLow    bool
+		data      api.BatteryData
+		wantLow   bool
+		wantAlert bool
+	}{
+		{name: "above threshold", data: api.BatteryData{Level: 50}},
+		{name: "at threshold", data: api.BatteryData{Level: threshold}},
+		{name: "drops below", data: api.BatteryData{Level: threshold - 1}, wantLow: true, wantAlert: true},
+		{name: "below while charging", data: api.BatteryData{Level: 5, Charging: true}},
+		{name: "stays low", wasLow: true, data: api.BatteryData{Level: 10}, wantLow: true},
+		{name: "hovers at threshold", wasLow: true, data: api.BatteryData{Level: threshold + batteryRearmMargin - 1}, wantLow: true},
+		{name: "charging doesn't rearm", wasLow: true, data: api.BatteryData{Level: threshold, Charging: true}, wantLow: true},
+		{name: "recovers by the margin", wasLow: true, data: api.BatteryData{Level: threshold + batteryRearmMargin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, alert := batteryLow(tt.wasLow, tt.data, threshold)
+			if low != tt.wantLow || alert != tt.wantAlert {
+				t.Errorf("batteryLow() = %v, %v, want %v, %v", low, alert, tt.wantLow, tt.wantAlert)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/maplink"
+	"roflbeacon2/pkg/util"
+	"time"
+)
+
+const (
+	minSpeedSampleInterval = 5 * time.Second
+	maxSpeedSampleInterval = 10 * time.Minute
+
+	// speedRearmMarginKmh is how far below the limit the speed must drop
+	// before another speeding alert can be sent.
+	speedRearmMarginKmh = 10
+)
+
+// deriveSpeed fills in the speed from the previous location update when the client didn't send it.
+func (s *Service) deriveSpeed(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, now time.Time) error {
+	if loc.Speed != nil {
+		return nil
+	}
+
+	prevUpdates, err := qtx.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get last location update: %w", err)
+	}
+
+	if len(prevUpdates) == 0 {
+		return nil
+	}
+
+	loc.Speed = trackSpeed(prevUpdates[0].Data.Location, prevUpdates[0].Created, loc, now)
+
+	return nil
+}
+
+// trackSpeed is the speed in m/s between the previous and the new location, nil when the
+// interval between them is too short or too long to tell.
+func trackSpeed(prevLoc *api.LocationData, prevCreated time.Time, loc *api.LocationData, now time.Time) *float64 {
+	interval := now.Sub(prevCreated)
+	if interval < minSpeedSampleInterval || interval > maxSpeedSampleInterval {
+		return nil
+	}
+
+	// movement within the combined accuracy can't be told apart from standing still
+	distance := util.HaversineDistance(prevLoc.Latitude, prevLoc.Longitude, loc.Latitude, loc.Longitude)
+	if distance <= prevLoc.Accuracy+loc.Accuracy {
+		return util.ToPtr(0.0)
+	}
+
+	return util.ToPtr(util.SpeedMps(prevLoc.Latitude, prevLoc.Longitude, prevCreated, loc.Latitude, loc.Longitude, now))
+}
+
+func (s *Service) handleMotion(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, now time.Time) error {
+	if err := s.deriveSpeed(ctx, qtx, acc, loc, now); err != nil {
+		return fmt.Errorf("derive speed: %w", err)
+	}
+
+	if loc.Speed == nil {
+		return nil
+	}
+
+	speedKmh := util.MpsToKmh(*loc.Speed)
+
+	if err := s.handleSpeeding(ctx, qtx, acc, loc, speedKmh); err != nil {
+		return fmt.Errorf("handle speeding: %w", err)
+	}
+
+	if err := s.handleDrive(ctx, qtx, acc, loc, speedKmh, now); err != nil {
+		return fmt.Errorf("handle drive: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleSpeeding(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, speedKmh float64) error {
+	limit := s.settingsService.SpeedLimitKmh(acc)
+
+	speeding, alert := overSpeed(acc.Status.Speeding, speedKmh, limit)
+	acc.Status.Speeding = speeding
+
+	if !alert {
+		return nil
+	}
+
+	var link i18n.Message
+
+	if drive := acc.Status.Drive; drive != nil {
+		routeLink := i18n.MapRoute{FromLat: drive.StartLatitude, FromLon: drive.StartLongitude, ToLat: loc.Latitude, ToLon: loc.Longitude, Mode: maplink.Driving}
+		link = i18n.M("common.drive_route", routeLink)
+	} else {
+		link = i18n.M("common.on_map", i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude})
+	}
+
+	text := i18n.Lines(i18n.M("alert.speeding", acc.Name, speedKmh, limit), link)
+
+	if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeSpeeding, text, &acc.ID); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	return nil
+}
+
+// overSpeed tells whether the account counts as speeding after the update and whether that is news worth an alert.
+// Speeding lasts until the speed drops by the rearm margin below the limit, a limit of zero disables it.
+func overSpeed(wasSpeeding bool, speedKmh, limit float64) (bool, bool) {
+	if limit <= 0 {
+		return false, false
+	}
+
+	if wasSpeeding {
+		return speedKmh >= limit-speedRearmMarginKmh, false
+	}
+
+	if speedKmh <= limit {
+		return false, false
+	}
+
+	return true, true
+}
+
+func (s *Service) handleDrive(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, speedKmh float64, now time.Time) error {
+	driving := s.cfg.Tune().Driving
+
+	drive := acc.Status.Drive
+	next, event := stepDrive(drive, loc, speedKmh, now, driving.StartSpeedKmh, driving.StopAfter)
+	acc.Status.Drive = next
+
+	switch event {
+	case api.AlertEventTypeDriveStart:
+		if !util.GetPtrOrZero(acc.Settings.DriveAlerts) {
+			return nil
+		}
+
+		text := i18n.Lines(
+			i18n.M("alert.drive_start", acc.Name),
+			i18n.M("common.on_map", i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude}),
+		)
+
+		if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeDriveStart, text, &acc.ID); err != nil {
+			return fmt.Errorf("alert: %w", err)
+		}
+	case api.AlertEventTypeDriveFinish:
+		return s.finishDrive(ctx, qtx, acc, drive, loc.Latitude, loc.Longitude)
+	}
+
+	return nil
+}
+
+// stepDrive advances the drive with the update and returns the drive that is open afterwards,
+// along with the drive start or finish event it caused. A drive starts with the first update
+// at the start speed and finishes with the first update after stopAfter without one.
+func stepDrive(drive *api.DriveState, loc *api.LocationData, speedKmh float64, now time.Time, startSpeedKmh float64, stopAfter time.Duration) (*api.DriveState, api.AlertEventType) {
+	moving := speedKmh >= startSpeedKmh
+
+	if drive == nil {
+		if !moving {
+			return nil, ""
+		}
+
+		return &api.DriveState{
+			Started:        now,
+			LastMoving:     now,
+			StartLatitude:  loc.Latitude,
+			StartLongitude: loc.Longitude,
+			MaxSpeedKmh:    speedKmh,
+		}, api.AlertEventTypeDriveStart
+	}
+
+	if moving {
+		drive.LastMoving = now
+		drive.MaxSpeedKmh = max(drive.MaxSpeedKmh, speedKmh)
+
+		return drive, ""
+	}
+
+	if now.Sub(drive.LastMoving) < stopAfter {
+		return drive, ""
+	}
+
+	return nil, api.AlertEventTypeDriveFinish
+}
+
+// finishDrive sends the summary of the account's finished drive that ended at the given point.
+func (s *Service) finishDrive(ctx context.Context, qtx *database.Queries, acc *database.Account, drive *api.DriveState, lat, lon float64) error {
+	if !util.GetPtrOrZero(acc.Settings.DriveAlerts) {
+		return nil
+	}
+
+	routeLink := i18n.MapRoute{FromLat: drive.StartLatitude, FromLon: drive.StartLongitude, ToLat: lat, ToLon: lon, Mode: maplink.Driving}
+
+	text := i18n.Lines(
+		i18n.M("alert.drive_finish", acc.Name, i18n.Duration(drive.LastMoving.Sub(drive.Started)), drive.MaxSpeedKmh),
+		i18n.M("common.drive_route", routeLink),
+	)
+
+	if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeDriveFinish, text, &acc.ID); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	return nil
+}
+
+// driveStale tells whether an open drive has to be closed without waiting for the next update,
+// because the account hasn't reported anything for the stop timeout.
+func driveStale(drive *api.DriveState, lastUpdate, now time.Time, stopAfter time.Duration) bool {
+	return drive != nil && now.Sub(lastUpdate) >= stopAfter && now.Sub(drive.LastMoving) >= stopAfter
+}
+
+// CloseStaleDrive closes the account's drive at its last known location when the account
+// stopped reporting in the middle of it, e.g. because the phone was switched off after parking.
+func (s *Service) CloseStaleDrive(ctx context.Context, acc *database.Account) error {
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.queries.WithTx(tx)
+
+	locked, err := qtx.GetAccountForUpdate(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	*acc = locked
+
+	lastUpdates, err := qtx.GetLastUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get last update: %w", err)
+	}
+
+	// an update that arrived meanwhile has already taken care of the drive
+	if len(lastUpdates) == 0 || !driveStale(acc.Status.Drive, lastUpdates[0].Created, time.Now(), s.cfg.Tune().Driving.StopAfter) {
+		return nil
+	}
+
+	drive := acc.Status.Drive
+	acc.Status.Drive = nil
+
+	lat, lon := drive.StartLatitude, drive.StartLongitude
+
+	locationUpdates, err := qtx.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get last location update: %w", err)
+	}
+
+	if len(locationUpdates) > 0 {
+		loc := locationUpdates[0].Data.Location
+		lat, lon = loc.Latitude, loc.Longitude
+	}
+
+	if err = s.finishDrive(ctx, qtx, acc, drive, lat, lon); err != nil {
+		return fmt.Errorf("finish drive: %w", err)
+	}
+
+	if err = qtx.UpdateAccountStatus(ctx, database.UpdateAccountStatusParams{
+		ID:     acc.ID,
+		Status: acc.Status,
+	}); err != nil {
+		return fmt.Errorf("update account status: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package ingest
+
+import (
+	"math"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/util"
+	"testing"
+	"time"
+)
+
+func TestTrackSpeed(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// about 1.1 km north of prev
+	prev := &api.LocationData{Latitude: 55.7558, Longitude: 37.6173, Accuracy: 10}
+	far := &api.LocationData{Latitude: 55.7658, Longitude: 37.6173, Accuracy: 10}
+	// about 11 m north of prev
+	nearby := &api.LocationData{Latitude: 55.7559, Longitude: 37.6173, Accuracy: 10}
+
+	tests := []struct {
+		name     string
+		loc      *api.LocationData
+		interval time.Duration
+		wantKmh  *float64
+	}{
+		{name: "too soon", loc: far, interval: time.Second},
+		{name: "too late", loc: far, interval: time.Hour},
+		{name: "within accuracy", loc: nearby, interval: time.Minute, wantKmh: util.ToPtr(0.0)},
+		{name: "moving", loc: far, interval: time.Minute, wantKmh: util.ToPtr(66.7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trackSpeed(prev, now.Add(-tt.interval), tt.loc, now)
+
+			switch {
+			case got == nil || tt.wantKmh == nil:
+				if (got == nil) != (tt.wantKmh == nil) {
+					t.Errorf("trackSpeed() = %v, want %v", got, tt.wantKmh)
+				}
+			case math.Abs(*got*3.6-*tt.wantKmh) > 0.5:
+				t.Errorf("trackSpeed() = %.1f km/h, want %.1f km/h", *got*3.6, *tt.wantKmh)
+			}
+		})
+	}
+}
+
+func TestOverSpeed(t *testing.T) {
+	const limit = 90
+
+	tests := []struct {
+		name         string
+		wasSpeeding  bool
+		speedKmh     float64
+		limit        float64
+		wantSpeeding bool
+		wantAlert    bool
+	}{
+		{name: "below limit", speedKmh: 80, limit: limit},
+		{name: "at limit", speedKmh: limit, limit: limit},
+		{name: "exceeds", speedKmh: 100, limit: limit, wantSpeeding: true, wantAlert: true},
+		{name: "keeps speeding", wasSpeeding: true, speedKmh: 120, limit: limit, wantSpeeding: true},
+		{name: "slows within the margin", wasSpeeding: true, speedKmh: limit - speedRearmMarginKmh, limit: limit, wantSpeeding: true},
+		{name: "slows below the margin", wasSpeeding: true, speedKmh: limit - speedRearmMarginKmh - 1, limit: limit},
+		{name: "disabled", speedKmh: 200, limit: 0},
+		{name: "disabled while speeding", wasSpeeding: true, speedKmh: 200, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			speeding, alert := overSpeed(tt.wasSpeeding, tt.speedKmh, tt.limit)
+			if speeding != tt.wantSpeeding || alert != tt.wantAlert {
+				t.Errorf("overSpeed() = %v, %v, want %v, %v", speeding, alert, tt.wantSpeeding, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestStepDrive(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	loc := &api.LocationData{Latitude: 55.7558, Longitude: 37.6173}
+
+	const startSpeed = 20
+	stopAfter := 5 * time.Minute
+
+	open := func() *api.DriveState {
+		return &api.DriveState{Started: now.Add(-time.Hour), LastMoving: now.Add(-time.Minute), MaxSpeedKmh: 60}
+	}
+
+	tests := []struct {
+		name      string
+		drive     *api.DriveState
+		speedKmh  float64
+		at        time.Time
+		wantDrive bool
+		wantEvent api.AlertEventType
+		wantMax   float64
+	}{
+		{name: "standing", speedKmh: 5, at: now},
+		{name: "starts", speedKmh: startSpeed, at: now, wantDrive: true, wantEvent: api.AlertEventTypeDriveStart, wantMax: startSpeed},
+		{name: "keeps moving", drive: open(), speedKmh: 80, at: now, wantDrive: true, wantMax: 80},
+		{name: "slower keeps the max", drive: open(), speedKmh: 40, at: now, wantDrive: true, wantMax: 60},
+		{name: "short stop", drive: open(), speedKmh: 0, at: now.Add(3 * time.Minute), wantDrive: true, wantMax: 60},
+		{name: "finishes", drive: open(), speedKmh: 0, at: now.Add(4 * time.Minute), wantEvent: api.AlertEventTypeDriveFinish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drive, event := stepDrive(tt.drive, loc, tt.speedKmh, tt.at, startSpeed, stopAfter)
+
+			if (drive != nil) != tt.wantDrive || event != tt.wantEvent {
+				t.Fatalf("stepDrive() = %v, %q, want drive %v, %q", drive, event, tt.wantDrive, tt.wantEvent)
+			}
+
+			if drive != nil && drive.MaxSpeedKmh != tt.wantMax {
+				t.Errorf("max speed = %v, want %v", drive.MaxSpeedKmh, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestDriveStale(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	stopAfter := 5 * time.Minute
+
+	tests := []struct {
+		name       string
+		drive      *api.DriveState
+		lastUpdate time.Time
+		want       bool
+	}{
+		{
+			name:       "no drive",
+			drive:      nil,
+			lastUpdate: now.Add(-time.Hour),
+			want:       false,
+		},
+		{
+			name:       "recent update",
+			drive:      &api.DriveState{LastMoving: now.Add(-time.Hour)},
+			lastUpdate: now.Add(-time.Minute),
+			want:       false,
+		},
+		{
+			name:       "recently moving",
+			drive:      &api.DriveState{LastMoving: now.Add(-time.Minute)},
+			lastUpdate: now.Add(-time.Hour),
+			want:       false,
+		},
+		{
+			name:       "silent since the timeout",
+			drive:      &api.DriveState{LastMoving: now.Add(-stopAfter)},
+			lastUpdate: now.Add(-stopAfter),
+			want:       true,
+		},
+		{
+			name:       "silent for long",
+			drive:      &api.DriveState{LastMoving: now.Add(-time.Hour)},
+			lastUpdate: now.Add(-30 * time.Minute),
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driveStale(tt.drive, tt.lastUpdate, now, stopAfter); got != tt.want {
+				t.Errorf("driveStale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,58 +4,93 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"log/slog"
 	"roflbeacon2/app/api"
 	"roflbeacon2/app/service/account"
 	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/proximity"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/app/service/watch"
+	"roflbeacon2/app/service/webhook"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/metrics"
+	"roflbeacon2/pkg/util"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/elliotchance/pie/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/do"
 )
 
-const standByRadius = 200
-
 type Service struct {
-	cfg            *config.Config
-	queries        *database.Queries
-	accountService *account.Service
-	alertService   *alert.Service
+	cfg              *config.Config
+	dbConn           *pgxpool.Pool
+	queries          *database.Queries
+	accountService   *account.Service
+	alertService     *alert.Service
+	webhookService   *webhook.Service
+	watchService     *watch.Service
+	proximityService *proximity.Service
+	settingsService  *settings.Service
+	telegramService  *telegram.Service
 }
 
 func New(di *do.Injector) (*Service, error) {
 	return &Service{
-		cfg:            do.MustInvoke[*config.Config](di),
-		queries:        do.MustInvoke[*database.Queries](di),
-		accountService: do.MustInvoke[*account.Service](di),
-		alertService:   do.MustInvoke[*alert.Service](di),
+		cfg:              do.MustInvoke[*config.Config](di),
+		dbConn:           do.MustInvoke[*pgxpool.Pool](di),
+		queries:          do.MustInvoke[*database.Queries](di),
+		accountService:   do.MustInvoke[*account.Service](di),
+		alertService:     do.MustInvoke[*alert.Service](di),
+		webhookService:   do.MustInvoke[*webhook.Service](di),
+		watchService:     do.MustInvoke[*watch.Service](di),
+		proximityService: do.MustInvoke[*proximity.Service](di),
+		settingsService:  do.MustInvoke[*settings.Service](di),
+		telegramService:  do.MustInvoke[*telegram.Service](di),
 	}, nil
 }
 
-func (s *Service) alertFenceMovement(acc *database.Account, enteredFences mapset.Set[database.Fence], leftFences mapset.Set[database.Fence]) {
+func (s *Service) alertFenceMovement(ctx context.Context, qtx *database.Queries, acc *database.Account, enteredFences mapset.Set[database.Fence], leftFences mapset.Set[database.Fence]) error {
 	for fence := range leftFences.Iter() {
-		ruText := fmt.Sprintf("🔴 %s покинул %s", acc.Name, fence.Name)
+		metrics.FenceTransitions.WithLabelValues(acc.Name, fence.Name, "leave").Inc()
+
+		text := i18n.M("alert.fence_leave", acc.Name, fence.Name)
 
-		s.alertService.Alert(ruText, acc.ChatID)
+		if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeFenceLeave, text, &acc.ID); err != nil {
+			return fmt.Errorf("alert: %w", err)
+		}
+
+		if err := s.webhookService.Publish(ctx, qtx, api.WebhookEventTypeFenceLeft, acc, webhook.NewFenceEventData(fence)); err != nil {
+			return fmt.Errorf("publish: %w", err)
+		}
 	}
 
 	for fence := range enteredFences.Iter() {
-		ruText := fmt.Sprintf("🟢 %s вошел в %s", acc.Name, fence.Name)
+		metrics.FenceTransitions.WithLabelValues(acc.Name, fence.Name, "enter").Inc()
 
-		s.alertService.Alert(ruText, acc.ChatID)
+		text := i18n.M("alert.fence_enter", acc.Name, fence.Name)
+
+		if err := s.alertService.Alert(ctx, qtx, api.AlertEventTypeFenceEnter, text, &acc.ID); err != nil {
+			return fmt.Errorf("alert: %w", err)
+		}
+
+		if err := s.webhookService.Publish(ctx, qtx, api.WebhookEventTypeFenceEntered, acc, webhook.NewFenceEventData(fence)); err != nil {
+			return fmt.Errorf("publish: %w", err)
+		}
 	}
+
+	return nil
 }
 
-func (s *Service) handleNewLocation(ctx context.Context, data api.LocationData) error {
-	acc := s.accountService.ExtractCtxAccount(ctx)
-	if acc == nil {
-		return fmt.Errorf("no account in context")
+func (s *Service) handleNewLocation(ctx context.Context, qtx *database.Queries, acc *database.Account, data *api.LocationData, now time.Time) error {
+	if err := s.handleMotion(ctx, qtx, acc, data, now); err != nil {
+		return fmt.Errorf("handle motion: %w", err)
 	}
 
-	allFences, err := s.queries.GetAllFences(ctx)
+	allFences, err := qtx.GetAllFences(ctx)
 	if err != nil {
 		return fmt.Errorf("get all fences: %w", err)
 	}
@@ -67,7 +102,7 @@ func (s *Service) handleNewLocation(ctx context.Context, data api.LocationData)
 	newFences := mapset.NewSet[database.Fence]()
 
 	for _, fence := range allFences {
-		if fence.Contains(data.Latitude, data.Longitude, 2*data.Accuracy) {
+		if fence.Contains(data.Latitude, data.Longitude, s.settingsService.AccuracyFactor(acc, &fence)*data.Accuracy) {
 			newFences.Add(fence)
 		}
 	}
@@ -79,42 +114,154 @@ func (s *Service) handleNewLocation(ctx context.Context, data api.LocationData)
 		return f.ID
 	})
 
-	s.alertFenceMovement(acc, enteredFences, leftFences)
+	if err = s.alertFenceMovement(ctx, qtx, acc, enteredFences, leftFences); err != nil {
+		return err
+	}
+
+	if err = s.watchService.Evaluate(ctx, qtx, acc, data, enteredFences, leftFences, now); err != nil {
+		return fmt.Errorf("evaluate watches: %w", err)
+	}
+
+	if err = s.proximityService.Evaluate(ctx, qtx, acc, data, now); err != nil {
+		return fmt.Errorf("evaluate proximity: %w", err)
+	}
 
 	return nil
 }
 
-func (s *Service) Ingest(ctx context.Context, data api.UpdateData) error {
+func (s *Service) alertBackOnline(ctx context.Context, qtx *database.Queries, acc *database.Account) error {
+	text := i18n.M("alert.online", acc.Name)
+
+	lastUpdates, err := qtx.GetLastUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get last update: %w", err)
+	}
+
+	if len(lastUpdates) > 0 {
+		text = i18n.M("alert.online_after", acc.Name, i18n.Duration(time.Since(lastUpdates[0].Created)))
+	}
+
+	if err = s.alertService.Alert(ctx, qtx, api.AlertEventTypeOnline, text, &acc.ID); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	return nil
+}
+
+// Protocols label how an update reached Ingest in the metrics.
+const (
+	ProtocolAPI    = "api"
+	ProtocolSOS    = "sos"
+	ProtocolReplay = "replay"
+)
+
+// Ingest stores the update together with the resulting status change and all alerts and
+// webhook deliveries it triggers in a single transaction.
+func (s *Service) Ingest(ctx context.Context, protocol string, data api.UpdateData) error {
 	acc := s.accountService.ExtractCtxAccount(ctx)
 	if acc == nil {
 		return fmt.Errorf("no account in context")
 	}
 
+	start := time.Now()
+	err := s.ingest(ctx, acc, data)
+
+	metrics.IngestDuration.WithLabelValues(protocol).Observe(time.Since(start).Seconds())
+	metrics.Ingests.WithLabelValues(acc.Name, protocol, util.Ternary(err == nil, "ok", "error")).Inc()
+
+	if err != nil {
+		return err
+	}
+
+	metrics.LastUpdate.WithLabelValues(acc.Name).Set(float64(start.Unix()))
+
+	if data.Location != nil {
+		metrics.LocationAccuracy.WithLabelValues(acc.Name).Observe(data.Location.Accuracy)
+	}
+
+	return nil
+}
+
+func (s *Service) ingest(ctx context.Context, acc *database.Account, data api.UpdateData) error {
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.queries.WithTx(tx)
+
+	// the status is written back as a whole, so it is read again under the lock that keeps
+	// the offline checker and concurrent updates of the account from overwriting each other
+	locked, err := qtx.GetAccountForUpdate(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	*acc = locked
+
+	now := time.Now()
+
 	if data.Location != nil {
-		if err := s.handleNewLocation(ctx, *data.Location); err != nil {
-			slog.WarnContext(ctx, "Failed to handle location",
-				slog.Any("error", err),
-			)
+		if err = s.handleNewLocation(ctx, qtx, acc, data.Location, now); err != nil {
+			return fmt.Errorf("failed to handle location: %w", err)
 		}
 	}
 
+	if data.Battery != nil {
+		if err = s.handleBattery(ctx, qtx, acc, *data.Battery); err != nil {
+			return fmt.Errorf("failed to handle battery: %w", err)
+		}
+	}
+
+	wasOffline := acc.Status.Offline
 	acc.Status.Offline = false
 
-	if err := s.queries.UpdateAccountStatus(ctx, database.UpdateAccountStatusParams{
+	if wasOffline {
+		if err = s.alertBackOnline(ctx, qtx, acc); err != nil {
+			return fmt.Errorf("failed to alert back online: %w", err)
+		}
+	}
+
+	if err = qtx.UpdateAccountStatus(ctx, database.UpdateAccountStatusParams{
 		ID:     acc.ID,
 		Status: acc.Status,
 	}); err != nil {
 		return fmt.Errorf("update account status: %w", err)
 	}
 
-	_, err := s.queries.CreateUpdate(ctx, database.CreateUpdateParams{
+	updateID, err := qtx.CreateUpdate(ctx, database.CreateUpdateParams{
 		AccountID: acc.ID,
-		Created:   time.Now(),
+		Created:   now,
 		Data:      data,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create update in DB: %w", err)
 	}
 
+	if wasOffline {
+		if err = s.webhookService.Publish(ctx, qtx, api.WebhookEventTypeAccountOnline, acc, webhook.OnlineEventData{
+			Created: now,
+		}); err != nil {
+			return fmt.Errorf("publish: %w", err)
+		}
+	}
+
+	if err = s.webhookService.Publish(ctx, qtx, api.WebhookEventTypeUpdateAccepted, acc, webhook.UpdateAcceptedData{
+		UpdateID: updateID,
+		Created:  now,
+		Update:   data,
+	}); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if data.Location != nil {
+		s.telegramService.UpdateLiveLocations(acc.ID, data.Location)
+	}
+
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/samber/do"
 	"golang.org/x/time/rate"
 	"log/slog"
+	"roflbeacon2/pkg/metrics"
 	"roflbeacon2/pkg/util"
 	"time"
 )
@@ -51,7 +52,8 @@ func (s *Service) wait(ctx context.Context, key string, count int, duration time
 	return limiter.Wait(ctx) //nolint:wrapcheck
 }
 
-func (s *Service) allow(ctx context.Context, key string, count int, duration time.Duration) bool {
+// allow reports whether the limiter of the key has room, name is the limit the key belongs to.
+func (s *Service) allow(ctx context.Context, name, key string, count int, duration time.Duration) bool {
 	limiter := s.getLimiter(key, count, duration)
 	if limiter == nil {
 		return true
@@ -59,6 +61,8 @@ func (s *Service) allow(ctx context.Context, key string, count int, duration tim
 
 	allow := limiter.Allow()
 	if !allow {
+		metrics.RateLimitRejections.WithLabelValues(name).Inc()
+
 		_, exists := s.alertCache.GetOrSet(key, struct{}{}, ttlcache.WithTTL[string, struct{}](10*time.Minute))
 
 		if !exists {
@@ -95,19 +99,19 @@ func (s *Service) WaitGlobalRpm(ctx context.Context, key string, count int) erro
 func (s *Service) AllowIpRps(ctx context.Context, key string, count int) bool {
 	ip, _ := ctx.Value(util.IpContextKey).(string)
 
-	return s.allow(ctx, key+"_"+ip+"_rps", count, time.Second)
+	return s.allow(ctx, key, key+"_"+ip+"_rps", count, time.Second)
 }
 
 func (s *Service) AllowIpRpm(ctx context.Context, key string, count int) bool {
 	ip, _ := ctx.Value(util.IpContextKey).(string)
 
-	return s.allow(ctx, key+"_"+ip+"_rpm", count, time.Minute)
+	return s.allow(ctx, key, key+"_"+ip+"_rpm", count, time.Minute)
 }
 
 func (s *Service) AllowGlobalRps(ctx context.Context, key string, count int) bool {
-	return s.allow(ctx, key+"_rps", count, time.Second)
+	return s.allow(ctx, key, key+"_rps", count, time.Second)
 }
 
 func (s *Service) AllowGlobalRpm(ctx context.Context, key string, count int) bool {
-	return s.allow(ctx, key+"_rpm", count, time.Minute)
+	return s.allow(ctx, key, key+"_rpm", count, time.Minute)
 }
@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"strconv"
+	"strings"
+)
+
+var _ Notifier = (*Email)(nil)
+
+// Email sends plain text messages through the configured SMTP relay.
+type Email struct {
+	cfg *config.Config
+}
+
+func NewEmail(cfg *config.Config) *Email {
+	return &Email{
+		cfg: cfg,
+	}
+}
+
+func (e *Email) Channel() api.NotificationChannel {
+	return api.NotificationChannelEmail
+}
+
+func (e *Email) Send(_ context.Context, _ *database.Account, target string, msg Message) error {
+	smtpCfg := e.cfg.Notify.SMTP
+
+	if smtpCfg.Host == "" {
+		return fmt.Errorf("smtp is not configured")
+	}
+	if target == "" {
+		return fmt.Errorf("email address is not set")
+	}
+
+	var auth smtp.Auth
+	if smtpCfg.Username != "" {
+		auth = smtp.PlainAuth("", smtpCfg.Username, smtpCfg.Password, smtpCfg.Host)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("From: " + smtpCfg.From + "\r\n")
+	builder.WriteString("To: " + target + "\r\n")
+	builder.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Title) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(msg.Text)
+
+	addr := net.JoinHostPort(smtpCfg.Host, strconv.Itoa(smtpCfg.Port))
+
+	if err := smtp.SendMail(addr, auth, smtpCfg.From, []string{target}, []byte(builder.String())); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	return nil
+}
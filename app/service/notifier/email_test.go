@@ -0,0 +1,161 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP accepts a single plain SMTP session and records the envelope and the data.
+type fakeSMTP struct {
+	listener net.Listener
+	done     chan struct{}
+
+	from, to, data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := &fakeSMTP{listener: listener, done: make(chan struct{})}
+	go s.serve()
+
+	t.Cleanup(func() { listener.Close() })
+
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch command {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250 fake")
+		case "MAIL":
+			s.from = line
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			s.to = line
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTP) config() *config.Config {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+
+	cfg := &config.Config{}
+	cfg.Notify.SMTP.Host = host
+	cfg.Notify.SMTP.Port, _ = strconv.Atoi(port)
+	cfg.Notify.SMTP.From = "beacon@example.com"
+
+	return cfg
+}
+
+func TestEmailSend(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	err := NewEmail(server.config()).Send(context.Background(), &database.Account{}, "alice@example.com", Message{
+		Title: "Привет",
+		Text:  "Alice is offline",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	<-server.done
+
+	if !strings.HasPrefix(server.from, "MAIL FROM:<beacon@example.com>") {
+		t.Errorf("from = %q", server.from)
+	}
+	if server.to != "RCPT TO:<alice@example.com>" {
+		t.Errorf("to = %q", server.to)
+	}
+
+	message, err := textproto.NewReader(bufio.NewReader(strings.NewReader(server.data))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("read headers: %v", err)
+	}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "From", want: "beacon@example.com"},
+		{header: "To", want: "alice@example.com"},
+		{header: "Subject", want: "=?utf-8?q?=D0=9F=D1=80=D0=B8=D0=B2=D0=B5=D1=82?="},
+		{header: "Content-Type", want: "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		if got := message.Get(tt.header); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+
+	if !strings.HasSuffix(strings.TrimRight(server.data, "\r\n"), "Alice is offline") {
+		t.Errorf("body = %q", server.data)
+	}
+}
+
+func TestEmailSendMisconfigured(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *config.Config
+		target string
+	}{
+		{name: "no smtp host", cfg: &config.Config{}, target: "alice@example.com"},
+		{name: "no address", cfg: newFakeSMTP(t).config(), target: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewEmail(tt.cfg).Send(context.Background(), &database.Account{}, tt.target, Message{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"strings"
+)
+
+var _ Notifier = (*Gotify)(nil)
+
+type gotifyPayload struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+}
+
+// Gotify pushes the message to the configured Gotify server. The target is the application token.
+type Gotify struct {
+	cfg    *config.Config
+	client *http.Client
+}
+
+func NewGotify(cfg *config.Config, client *http.Client) *Gotify {
+	return &Gotify{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (g *Gotify) Channel() api.NotificationChannel {
+	return api.NotificationChannelGotify
+}
+
+func (g *Gotify) Send(ctx context.Context, _ *database.Account, target string, msg Message) error {
+	if g.cfg.Notify.Gotify.ServerURL == "" {
+		return fmt.Errorf("gotify is not configured")
+	}
+	if target == "" {
+		return fmt.Errorf("gotify token is not set")
+	}
+
+	body, err := json.Marshal(&gotifyPayload{
+		Title:    msg.Title,
+		Message:  msg.Text,
+		Priority: 8,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	messageURL := strings.TrimSuffix(g.cfg.Notify.Gotify.ServerURL, "/") + "/message?token=" + url.QueryEscape(target)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, messageURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doRequest(g.client, req)
+}
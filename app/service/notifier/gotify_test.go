@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"testing"
+)
+
+func TestGotifySend(t *testing.T) {
+	var (
+		gotToken   string
+		gotPayload gotifyPayload
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message" {
+			t.Errorf("path = %s, want /message", r.URL.Path)
+		}
+
+		gotToken = r.URL.Query().Get("token")
+		_ = json.NewDecoder(r.Body).Decode(&gotPayload)
+
+		if gotToken == "revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		serverURL string
+		target    string
+		wantErr   bool
+	}{
+		{name: "ok", serverURL: server.URL, target: "app&token"},
+		{name: "trailing slash", serverURL: server.URL + "/", target: "app"},
+		{name: "unauthorized", serverURL: server.URL, target: "revoked", wantErr: true},
+		{name: "not configured", serverURL: "", target: "app", wantErr: true},
+		{name: "no token", serverURL: server.URL, target: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotToken, gotPayload = "", gotifyPayload{}
+
+			cfg := &config.Config{}
+			cfg.Notify.Gotify.ServerURL = tt.serverURL
+
+			err := NewGotify(cfg, server.Client()).Send(context.Background(), &database.Account{}, tt.target, Message{
+				Title: "title",
+				Text:  "text",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if gotToken != tt.target {
+				t.Errorf("token = %q, want %q", gotToken, tt.target)
+			}
+
+			want := gotifyPayload{Title: "title", Message: "text", Priority: 8}
+			if gotPayload != want {
+				t.Errorf("payload = %+v, want %+v", gotPayload, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"time"
+)
+
+type Message struct {
+	Title string
+	Text  string
+}
+
+// Notifier delivers a message to a single recipient over one channel.
+// Target is the channel-specific address taken from the account's notification route
+// (webhook URL, email address, ntfy topic, gotify app token); it may be empty for telegram.
+type Notifier interface {
+	Channel() api.NotificationChannel
+	Send(ctx context.Context, recipient *database.Account, target string, msg Message) error
+}
+
+// RetryAfterError is returned when the channel asks to slow down; nothing should be sent
+// over the same channel until After elapses.
+type RetryAfterError struct {
+	After time.Duration
+	Err   error
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("retry after %s: %v", e.After, e.Err)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"strings"
+)
+
+var _ Notifier = (*Ntfy)(nil)
+
+// Ntfy publishes the message to an ntfy topic. The target is either a topic name
+// on the configured server or a full topic URL, which is requested with urlClient.
+type Ntfy struct {
+	cfg       *config.Config
+	client    *http.Client
+	urlClient *http.Client
+}
+
+func NewNtfy(cfg *config.Config, client *http.Client, urlClient *http.Client) *Ntfy {
+	return &Ntfy{
+		cfg:       cfg,
+		client:    client,
+		urlClient: urlClient,
+	}
+}
+
+func (n *Ntfy) Channel() api.NotificationChannel {
+	return api.NotificationChannelNtfy
+}
+
+func (n *Ntfy) Send(ctx context.Context, _ *database.Account, target string, msg Message) error {
+	if target == "" {
+		return fmt.Errorf("ntfy topic is not set")
+	}
+
+	topicURL, client := target, n.urlClient
+	if !IsURL(target) {
+		topicURL, client = strings.TrimSuffix(n.cfg.Notify.Ntfy.ServerURL, "/")+"/"+target, n.client
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, topicURL, strings.NewReader(msg.Text))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Title", msg.Title)
+	req.Header.Set("Markdown", "yes")
+
+	if n.cfg.Notify.Ntfy.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.cfg.Notify.Ntfy.Token)
+	}
+
+	return doRequest(client, req)
+}
+
+// IsURL tells the full topic URLs from the topic names.
+func IsURL(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"testing"
+)
+
+func TestNtfySend(t *testing.T) {
+	type request struct {
+		path, title, auth, body string
+	}
+
+	var got request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		got = request{
+			path:  r.URL.Path,
+			title: r.Header.Get("Title"),
+			auth:  r.Header.Get("Authorization"),
+			body:  string(body),
+		}
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		target  string
+		want    request
+		wantErr bool
+	}{
+		{
+			name:   "topic on the configured server",
+			target: "alerts",
+			want:   request{path: "/alerts", title: "title", body: "text"},
+		},
+		{
+			name:   "token",
+			token:  "secret",
+			target: "alerts",
+			want:   request{path: "/alerts", title: "title", auth: "Bearer secret", body: "text"},
+		},
+		{
+			name:   "full topic url",
+			target: server.URL + "/other",
+			want:   request{path: "/other", title: "title", body: "text"},
+		},
+		{
+			name:    "server error",
+			target:  "broken",
+			want:    request{path: "/broken", title: "title", body: "text"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = request{}
+
+			cfg := &config.Config{}
+			cfg.Notify.Ntfy.ServerURL = server.URL + "/"
+			cfg.Notify.Ntfy.Token = tt.token
+
+			err := NewNtfy(cfg, server.Client(), server.Client()).Send(context.Background(), &database.Account{}, tt.target, Message{
+				Title: "title",
+				Text:  "text",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("request = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNtfyTopicURLUsesURLClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Notify.Ntfy.ServerURL = server.URL
+
+	// the url client can't reach anything, so only the configured server works
+	blocked := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, io.ErrUnexpectedEOF
+	})}
+	n := NewNtfy(cfg, server.Client(), blocked)
+
+	if err := n.Send(context.Background(), &database.Account{}, "topic", Message{}); err != nil {
+		t.Errorf("topic name: %v", err)
+	}
+	if err := n.Send(context.Background(), &database.Account{}, server.URL+"/topic", Message{}); err == nil {
+		t.Error("topic url: expected the url client to be used")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/netguard"
+	"roflbeacon2/pkg/util"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/samber/do"
+)
+
+type Service struct {
+	notifiers map[api.NotificationChannel]Notifier
+}
+
+func New(di *do.Injector) (*Service, error) {
+	cfg := do.MustInvoke[*config.Config](di)
+
+	httpClient := &http.Client{
+		Timeout: cfg.Notify.WebhookTimeout,
+	}
+	// the URLs in account routes are set by users and must not reach the internal network
+	userURLClient := netguard.NewClient(cfg.Notify.WebhookTimeout)
+
+	service := &Service{
+		notifiers: map[api.NotificationChannel]Notifier{},
+	}
+
+	service.Register(NewTelegram(do.MustInvoke[*telegram.Service](di)))
+	service.Register(NewWebhook(userURLClient))
+	service.Register(NewEmail(cfg))
+	service.Register(NewNtfy(cfg, httpClient, userURLClient))
+	service.Register(NewGotify(cfg, httpClient))
+
+	return service, nil
+}
+
+func (s *Service) Register(n Notifier) {
+	s.notifiers[n.Channel()] = n
+}
+
+// Routes returns the notification routes of the account that subscribe to the event.
+// Accounts without explicit routes receive every event in telegram, if they have a chat linked.
+func (s *Service) Routes(acc *database.Account, event api.AlertEventType) []api.NotificationRoute {
+	routes := util.GetPtrOrZero(acc.Settings.Notifications)
+
+	if len(routes) == 0 {
+		if acc.ChatID == nil {
+			return nil
+		}
+
+		return []api.NotificationRoute{{Channel: api.NotificationChannelTelegram}}
+	}
+
+	return pie.Filter(routes, func(route api.NotificationRoute) bool {
+		events := util.GetPtrOrZero(route.Events)
+
+		return len(events) == 0 || pie.Contains(events, event)
+	})
+}
+
+func (s *Service) SendRoute(ctx context.Context, acc *database.Account, route api.NotificationRoute, msg Message) error {
+	n, ok := s.notifiers[route.Channel]
+	if !ok {
+		return fmt.Errorf("unknown notification channel: %s", route.Channel)
+	}
+
+	if err := n.Send(ctx, acc, util.GetPtrOrZero(route.Target), msg); err != nil {
+		return fmt.Errorf("%s: %w", route.Channel, err)
+	}
+
+	return nil
+}
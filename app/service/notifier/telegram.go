@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/database"
+	"strconv"
+	"time"
+
+	"github.com/go-telegram/bot"
+)
+
+var _ Notifier = (*Telegram)(nil)
+
+type Telegram struct {
+	telegramService *telegram.Service
+}
+
+func NewTelegram(telegramService *telegram.Service) *Telegram {
+	return &Telegram{
+		telegramService: telegramService,
+	}
+}
+
+func (t *Telegram) Channel() api.NotificationChannel {
+	return api.NotificationChannelTelegram
+}
+
+func (t *Telegram) Send(ctx context.Context, recipient *database.Account, target string, msg Message) error {
+	var chatID int64
+
+	switch {
+	case target != "":
+		value, err := strconv.ParseInt(target, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chat id %q: %w", target, err)
+		}
+
+		chatID = value
+	case recipient.ChatID != nil:
+		chatID = *recipient.ChatID
+	default:
+		return fmt.Errorf("account %d has no linked chat", recipient.ID)
+	}
+
+	err := t.telegramService.Send(ctx, chatID, msg.Text)
+
+	var tooManyRequestsErr *bot.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		return &RetryAfterError{
+			After: time.Duration(tooManyRequestsErr.RetryAfter) * time.Second,
+			Err:   err,
+		}
+	}
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,131 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/share"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+)
+
+// newTelegramService points the bot at a fake Bot API that fails sendMessage with the error code
+// configured for the chat id and succeeds for the other chats.
+func newTelegramService(t *testing.T, errorCodes map[string]int) (*telegram.Service, *[]string) {
+	t.Helper()
+
+	var chats []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"beacon","username":"beacon_bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			_ = r.ParseMultipartForm(1 << 20)
+			chatID := r.FormValue("chat_id")
+			chats = append(chats, chatID)
+
+			switch errorCodes[chatID] {
+			case 0:
+				_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`))
+			case http.StatusTooManyRequests:
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"ok":          false,
+					"error_code":  http.StatusTooManyRequests,
+					"description": "Too Many Requests: retry after 5",
+					"parameters":  map[string]any{"retry_after": 5},
+				})
+			default:
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"ok":          false,
+					"error_code":  errorCodes[chatID],
+					"description": "Bad Request: chat not found",
+				})
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.Telegram.Token = "123:test"
+	cfg.Telegram.ServerURL = server.URL
+
+	di := do.New()
+	do.ProvideValue(di, cfg)
+	do.ProvideValue(di, database.New(nil))
+	do.ProvideValue[*pgxpool.Pool](di, nil)
+	do.Provide(di, settings.New)
+	do.Provide(di, share.New)
+
+	service, err := telegram.New(di)
+	if err != nil {
+		t.Fatalf("create telegram service: %v", err)
+	}
+
+	return service, &chats
+}
+
+func TestTelegramSend(t *testing.T) {
+	service, chats := newTelegramService(t, map[string]int{
+		"100": http.StatusTooManyRequests,
+		"200": http.StatusBadRequest,
+	})
+
+	tests := []struct {
+		name       string
+		recipient  database.Account
+		target     string
+		wantChat   string
+		wantErr    bool
+		wantRetry  time.Duration
+		wantNoCall bool
+	}{
+		{name: "linked chat", recipient: database.Account{ChatID: util.ToPtr[int64](42)}, wantChat: "42"},
+		{name: "target overrides the linked chat", recipient: database.Account{ChatID: util.ToPtr[int64](42)}, target: "43", wantChat: "43"},
+		{name: "rate limited", target: "100", wantChat: "100", wantErr: true, wantRetry: 5 * time.Second},
+		{name: "api error", target: "200", wantChat: "200", wantErr: true},
+		{name: "invalid target", target: "@channel", wantErr: true, wantNoCall: true},
+		{name: "no chat", recipient: database.Account{ID: 1}, wantErr: true, wantNoCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*chats = nil
+
+			err := NewTelegram(service).Send(context.Background(), &tt.recipient, tt.target, Message{Text: "text"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var retryAfterErr *RetryAfterError
+			if isRetry := errors.As(err, &retryAfterErr); isRetry != (tt.wantRetry > 0) {
+				t.Fatalf("Send() error = %v, want retry after %s", err, tt.wantRetry)
+			}
+			if tt.wantRetry > 0 && retryAfterErr.After != tt.wantRetry {
+				t.Errorf("retry after = %s, want %s", retryAfterErr.After, tt.wantRetry)
+			}
+
+			switch {
+			case tt.wantNoCall && len(*chats) > 0:
+				t.Errorf("unexpected requests to chats %v", *chats)
+			case !tt.wantNoCall && (len(*chats) != 1 || (*chats)[0] != tt.wantChat):
+				t.Errorf("requests to chats %v, want [%s]", *chats, tt.wantChat)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+)
+
+var _ Notifier = (*Webhook)(nil)
+
+type webhookPayload struct {
+	AccountID   int64  `json:"accountId"`
+	AccountName string `json:"accountName"`
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+}
+
+// Webhook posts the message as JSON to an arbitrary URL.
+type Webhook struct {
+	client *http.Client
+}
+
+func NewWebhook(client *http.Client) *Webhook {
+	return &Webhook{
+		client: client,
+	}
+}
+
+func (w *Webhook) Channel() api.NotificationChannel {
+	return api.NotificationChannelWebhook
+}
+
+func (w *Webhook) Send(ctx context.Context, recipient *database.Account, target string, msg Message) error {
+	if target == "" {
+		return fmt.Errorf("webhook url is not set")
+	}
+
+	body, err := json.Marshal(&webhookPayload{
+		AccountID:   recipient.ID,
+		AccountName: recipient.Name,
+		Title:       msg.Title,
+		Text:        msg.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doRequest(w.client, req)
+}
+
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
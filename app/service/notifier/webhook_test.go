@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"roflbeacon2/pkg/database"
+	"testing"
+)
+
+func TestWebhookSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "no content", status: http.StatusNoContent},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+		{name: "not found", status: http.StatusNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got webhookPayload
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("content type = %q", ct)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode body: %v", err)
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewWebhook(server.Client()).Send(context.Background(), &database.Account{ID: 7, Name: "alice"}, server.URL+"/hook", Message{
+				Title: "title",
+				Text:  "text",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			want := webhookPayload{AccountID: 7, AccountName: "alice", Title: "title", Text: "text"}
+			if got != want {
+				t.Errorf("payload = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWebhookSendWithoutTarget(t *testing.T) {
+	if err := NewWebhook(http.DefaultClient).Send(context.Background(), &database.Account{}, "", Message{}); err == nil {
+		t.Error("expected an error for an empty url")
+	}
+}
@@ -2,46 +2,85 @@ package offline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"roflbeacon2/app/api"
 	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/ingest"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/webhook"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/metrics"
+	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/do"
 )
 
-const offlineThresholdMinutes = 30
+// errReported means that the account sent an update while it was being marked offline.
+var errReported = errors.New("account reported meanwhile")
 
 type Service struct {
-	cfg          *config.Config
-	queries      *database.Queries
-	alertService *alert.Service
+	cfg             *config.Config
+	settingsService *settings.Service
+	dbConn          *pgxpool.Pool
+	queries         *database.Queries
+	alertService    *alert.Service
+	webhookService  *webhook.Service
+	ingestService   *ingest.Service
+
+	// lastRun is when the background checks last finished a pass, nil until they start
+	lastRun atomic.Pointer[time.Time]
 }
 
 func New(di *do.Injector) (*Service, error) {
 	return &Service{
-		cfg:          do.MustInvoke[*config.Config](di),
-		queries:      do.MustInvoke[*database.Queries](di),
-		alertService: do.MustInvoke[*alert.Service](di),
+		cfg:             do.MustInvoke[*config.Config](di),
+		settingsService: do.MustInvoke[*settings.Service](di),
+		dbConn:          do.MustInvoke[*pgxpool.Pool](di),
+		queries:         do.MustInvoke[*database.Queries](di),
+		alertService:    do.MustInvoke[*alert.Service](di),
+		webhookService:  do.MustInvoke[*webhook.Service](di),
+		ingestService:   do.MustInvoke[*ingest.Service](di),
 	}, nil
 }
 
 func (s *Service) RunBackgroundChecks(ctx context.Context) {
-	ticker := time.NewTicker(15 * time.Minute)
+	ticker := time.NewTicker(s.cfg.Tune().Offline.CheckInterval)
 	defer ticker.Stop()
 
+	s.markRun()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			s.CheckOfflineAccounts(ctx)
+			s.markRun()
+			ticker.Reset(s.cfg.Tune().Offline.CheckInterval)
 		}
 	}
 }
 
+func (s *Service) markRun() {
+	now := time.Now()
+	s.lastRun.Store(&now)
+}
+
+// LastRun is when the background checks last completed, the zero time if they aren't running.
+func (s *Service) LastRun() time.Time {
+	if lastRun := s.lastRun.Load(); lastRun != nil {
+		return *lastRun
+	}
+
+	return time.Time{}
+}
+
 func (s *Service) CheckOfflineAccounts(ctx context.Context) {
 	accounts, err := s.queries.GetAllAccounts(ctx)
 	if err != nil {
@@ -49,38 +88,156 @@ func (s *Service) CheckOfflineAccounts(ctx context.Context) {
 		return
 	}
 
-	for _, a := range accounts {
-		if a.Status.Offline {
-			continue
-		}
+	offlineCount := 0
 
+	for _, a := range accounts {
 		lastUpdates, err := s.queries.GetLastUpdateByAccountID(ctx, a.ID)
 		if err != nil {
 			slog.Error("Get last updates failed", slog.Any("error", err))
 			return
 		}
 
-		if len(lastUpdates) < 1 {
-			continue
+		if len(lastUpdates) > 0 {
+			lastUpdate := lastUpdates[0]
+			metrics.LastUpdate.WithLabelValues(a.Name).Set(float64(lastUpdate.Created.Unix()))
+
+			if a.Status.Drive != nil && time.Since(lastUpdate.Created) >= s.cfg.Tune().Driving.StopAfter {
+				if err = s.ingestService.CloseStaleDrive(ctx, &a); err != nil {
+					slog.Error("Close stale drive failed", slog.Any("error", err))
+					return
+				}
+			}
+
+			if !a.Status.Offline && time.Since(lastUpdate.Created) >= s.settingsService.OfflineThreshold(&a) {
+				err = s.markOffline(ctx, &a, lastUpdate)
+				if err != nil && !errors.Is(err, errReported) {
+					slog.Error("Mark account offline failed", slog.Any("error", err))
+					return
+				}
+			}
 		}
 
-		lastUpdate := lastUpdates[0]
-		if time.Since(lastUpdate.Created).Minutes() < offlineThresholdMinutes {
-			continue
+		if a.Status.Offline {
+			offlineCount++
 		}
+	}
 
-		newStatus := a.Status
-		newStatus.Offline = true
+	metrics.OfflineAccounts.Set(float64(offlineCount))
 
-		if err = s.queries.UpdateAccountStatus(ctx, database.UpdateAccountStatusParams{
-			ID:     a.ID,
-			Status: newStatus,
-		}); err != nil {
-			slog.Error("Update account status failed", slog.Any("error", err))
-			return
-		}
+	s.pruneMetrics(ctx, accounts)
+}
+
+// pruneMetrics drops the series of the accounts and fences that were renamed or deleted since they were recorded.
+func (s *Service) pruneMetrics(ctx context.Context, accounts []database.Account) {
+	accountNames := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		accountNames = append(accountNames, a.Name)
+	}
+
+	metrics.PruneAccounts(accountNames)
+
+	fences, err := s.queries.GetAllFences(ctx)
+	if err != nil {
+		slog.Error("Get all fences failed", slog.Any("error", err))
+		return
+	}
+
+	fenceNames := make([]string, 0, len(fences))
+	for _, fence := range fences {
+		fenceNames = append(fenceNames, fence.Name)
+	}
+
+	metrics.PruneFences(fenceNames)
+}
+
+func (s *Service) formatOfflineText(ctx context.Context, qtx *database.Queries, acc *database.Account, lastUpdate database.Update) (i18n.Text, error) {
+	texts := []i18n.Text{i18n.M("alert.offline", acc.Name)}
+
+	if battery := lastUpdate.Data.Battery; battery != nil && !battery.Charging && battery.Level < s.settingsService.LowBatteryThreshold(acc) {
+		texts = append(texts, i18n.M("alert.offline_battery", battery.Level))
+	}
+
+	locationUpdates, err := qtx.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get last location update: %w", err)
+	}
+
+	if len(locationUpdates) == 0 {
+		return i18n.Lines(texts...), nil
+	}
+
+	locationUpdate := locationUpdates[0]
+	loc := locationUpdate.Data.Location
+
+	mapLink := i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude}
+
+	texts = append(texts, i18n.M("alert.offline_last", i18n.Ago(locationUpdate.Created), mapLink))
+
+	if loc.Address != nil {
+		texts = append(texts, i18n.Raw("📍 "+*loc.Address))
+	}
+
+	return i18n.Lines(texts...), nil
+}
+
+func (s *Service) markOffline(ctx context.Context, acc *database.Account, lastUpdate database.Update) error {
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.queries.WithTx(tx)
+
+	// the lock waits for a concurrent ingest, whose status changes and update must not be overwritten
+	locked, err := qtx.GetAccountForUpdate(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	*acc = locked
 
-		ruText := fmt.Sprintf("🚨 %s перестал присылать обновления", a.Name)
-		s.alertService.Alert(ruText, nil)
+	// another instance was faster
+	if acc.Status.Offline {
+		return nil
 	}
+
+	lastUpdates, err := qtx.GetLastUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get last update: %w", err)
+	}
+
+	if len(lastUpdates) == 0 || lastUpdates[0].ID != lastUpdate.ID {
+		return errReported
+	}
+
+	acc.Status.Offline = true
+
+	if err = qtx.UpdateAccountStatus(ctx, database.UpdateAccountStatusParams{
+		ID:     acc.ID,
+		Status: acc.Status,
+	}); err != nil {
+		return fmt.Errorf("update account status: %w", err)
+	}
+
+	text, err := s.formatOfflineText(ctx, qtx, acc, lastUpdate)
+	if err != nil {
+		return fmt.Errorf("format offline text: %w", err)
+	}
+
+	if err = s.alertService.Alert(ctx, qtx, api.AlertEventTypeOffline, text, nil); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	if err = s.webhookService.Publish(ctx, qtx, api.WebhookEventTypeAccountOffline, acc, webhook.OfflineEventData{
+		LastUpdate: lastUpdate.Created,
+	}); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
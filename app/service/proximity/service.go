@@ -0,0 +1,268 @@
+package proximity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/alert"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+type Service struct {
+	cfg             *config.Config
+	queries         *database.Queries
+	alertService    *alert.Service
+	settingsService *settings.Service
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:             do.MustInvoke[*config.Config](di),
+		queries:         do.MustInvoke[*database.Queries](di),
+		alertService:    do.MustInvoke[*alert.Service](di),
+		settingsService: do.MustInvoke[*settings.Service](di),
+	}, nil
+}
+
+func (s *Service) ListRules(ctx context.Context) ([]database.ProximityRule, error) {
+	rules, err := s.queries.GetAllProximityRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get proximity rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (s *Service) CreateRule(ctx context.Context, input api.ProximityRuleInput) (database.ProximityRule, error) {
+	firstID, secondID := orderPair(input.FirstAccountId, input.SecondAccountId)
+
+	if err := s.validatePair(ctx, firstID, secondID); err != nil {
+		return database.ProximityRule{}, err
+	}
+
+	existing, err := s.queries.GetProximityRuleByPair(ctx, database.GetProximityRuleByPairParams{
+		FirstAccountID:  firstID,
+		SecondAccountID: secondID,
+	})
+	if err != nil {
+		return database.ProximityRule{}, fmt.Errorf("get proximity rule: %w", err)
+	}
+	if len(existing) > 0 {
+		return database.ProximityRule{}, oops.With("statusCode", http.StatusBadRequest).New("Rule for this pair already exists")
+	}
+
+	params := database.CreateProximityRuleParams{
+		FirstAccountID:  firstID,
+		SecondAccountID: secondID,
+		Distance:        input.Distance,
+		NotifyMeet:      util.GetPtrOrDefault(input.NotifyMeet, true),
+		NotifyPart:      util.GetPtrOrDefault(input.NotifyPart, true),
+		Created:         time.Now(),
+	}
+
+	// start from the current state so that a pair that is already together doesn't trigger an alert
+	together, known, err := s.currentlyTogether(ctx, s.queries, &database.ProximityRule{
+		FirstAccountID:  firstID,
+		SecondAccountID: secondID,
+		Distance:        input.Distance,
+	})
+	if err != nil {
+		return database.ProximityRule{}, err
+	}
+	if known {
+		params.Together = together
+		params.Changed = util.ToPtr(params.Created)
+	}
+
+	rule, err := s.queries.CreateProximityRule(ctx, params)
+	if err != nil {
+		return database.ProximityRule{}, fmt.Errorf("create proximity rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (s *Service) UpdateRule(ctx context.Context, id int64, input api.ProximityRuleInput) (database.ProximityRule, error) {
+	existing, err := s.queries.GetProximityRule(ctx, id)
+	if err != nil {
+		return database.ProximityRule{}, fmt.Errorf("get proximity rule: %w", err)
+	}
+
+	firstID, secondID := orderPair(input.FirstAccountId, input.SecondAccountId)
+	if firstID != existing.FirstAccountID || secondID != existing.SecondAccountID {
+		return database.ProximityRule{}, oops.With("statusCode", http.StatusBadRequest).New("Pair can't be changed")
+	}
+
+	rule, err := s.queries.UpdateProximityRule(ctx, database.UpdateProximityRuleParams{
+		ID:         id,
+		Distance:   input.Distance,
+		NotifyMeet: util.GetPtrOrDefault(input.NotifyMeet, existing.NotifyMeet),
+		NotifyPart: util.GetPtrOrDefault(input.NotifyPart, existing.NotifyPart),
+	})
+	if err != nil {
+		return database.ProximityRule{}, fmt.Errorf("update proximity rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes the rule and reports whether it existed.
+func (s *Service) DeleteRule(ctx context.Context, id int64) (bool, error) {
+	count, err := s.queries.DeleteProximityRule(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("delete proximity rule: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Evaluate compares the new location of the account with the latest positions of the other
+// members of its pairs and alerts when a pair meets or separates.
+func (s *Service) Evaluate(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, now time.Time) error {
+	rules, err := qtx.GetProximityRulesByAccountID(ctx, acc.ID)
+	if err != nil {
+		return fmt.Errorf("get proximity rules: %w", err)
+	}
+
+	for _, rule := range rules {
+		other, otherLoc, err := s.freshLocation(ctx, qtx, rule.OtherAccountID(acc.ID), now)
+		if err != nil {
+			return err
+		}
+
+		if otherLoc == nil {
+			continue
+		}
+
+		together := rule.Contains(loc.Latitude, loc.Longitude, otherLoc.Latitude, otherLoc.Longitude, s.slack(acc, loc, &other, otherLoc))
+
+		changed, event := transition(&rule, together)
+		if !changed {
+			continue
+		}
+
+		if err = qtx.UpdateProximityRuleState(ctx, database.UpdateProximityRuleStateParams{
+			ID:       rule.ID,
+			Together: together,
+			Changed:  &now,
+		}); err != nil {
+			return fmt.Errorf("update proximity rule state: %w", err)
+		}
+
+		switch event {
+		case api.AlertEventTypeProximityMeet:
+			mapLink := i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude}
+			text := i18n.Lines(i18n.M("alert.proximity_meet", acc.Name, other.Name), i18n.M("common.on_map", mapLink))
+
+			if err = s.alertService.Alert(ctx, qtx, event, text, &acc.ID); err != nil {
+				return fmt.Errorf("alert: %w", err)
+			}
+		case api.AlertEventTypeProximityPart:
+			distance := util.HaversineDistance(loc.Latitude, loc.Longitude, otherLoc.Latitude, otherLoc.Longitude)
+			text := i18n.M("alert.proximity_part", acc.Name, other.Name, i18n.Distance(distance))
+
+			if err = s.alertService.Alert(ctx, qtx, event, text, &acc.ID); err != nil {
+				return fmt.Errorf("alert: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) currentlyTogether(ctx context.Context, queries *database.Queries, rule *database.ProximityRule) (bool, bool, error) {
+	now := time.Now()
+
+	first, firstLoc, err := s.freshLocation(ctx, queries, rule.FirstAccountID, now)
+	if err != nil {
+		return false, false, err
+	}
+
+	second, secondLoc, err := s.freshLocation(ctx, queries, rule.SecondAccountID, now)
+	if err != nil {
+		return false, false, err
+	}
+
+	if firstLoc == nil || secondLoc == nil {
+		return false, false, nil
+	}
+
+	together := rule.Contains(firstLoc.Latitude, firstLoc.Longitude, secondLoc.Latitude, secondLoc.Longitude, s.slack(&first, firstLoc, &second, secondLoc))
+
+	return together, true, nil
+}
+
+// freshLocation returns the account with its latest location, or nil if it is older than the offline threshold.
+func (s *Service) freshLocation(ctx context.Context, queries *database.Queries, accountID int64, now time.Time) (database.Account, *api.LocationData, error) {
+	acc, err := queries.GetAccount(ctx, accountID)
+	if err != nil {
+		return database.Account{}, nil, fmt.Errorf("get account: %w", err)
+	}
+
+	updates, err := queries.GetLastLocationUpdateByAccountID(ctx, accountID)
+	if err != nil {
+		return database.Account{}, nil, fmt.Errorf("get last location update: %w", err)
+	}
+
+	if len(updates) == 0 || now.Sub(updates[0].Created) > s.settingsService.OfflineThreshold(&acc) {
+		return acc, nil, nil
+	}
+
+	return acc, updates[0].Data.Location, nil
+}
+
+// transition tells whether the pair's persisted state changes and which alert it triggers,
+// an empty event if the rule doesn't notify about it.
+func transition(rule *database.ProximityRule, together bool) (bool, api.AlertEventType) {
+	if together == rule.Together {
+		return false, ""
+	}
+
+	if together && rule.NotifyMeet {
+		return true, api.AlertEventTypeProximityMeet
+	}
+
+	if !together && rule.NotifyPart {
+		return true, api.AlertEventTypeProximityPart
+	}
+
+	return true, ""
+}
+
+// slack is how far apart the pair may be beyond the rule distance because of the location accuracy.
+func (s *Service) slack(first *database.Account, firstLoc *api.LocationData, second *database.Account, secondLoc *api.LocationData) float64 {
+	return max(s.settingsService.AccuracyFactor(first, nil)*firstLoc.Accuracy, s.settingsService.AccuracyFactor(second, nil)*secondLoc.Accuracy)
+}
+
+func (s *Service) validatePair(ctx context.Context, firstID, secondID int64) error {
+	if firstID == secondID {
+		return oops.With("statusCode", http.StatusBadRequest).New("Pair must consist of two different accounts")
+	}
+
+	for _, id := range []int64{firstID, secondID} {
+		if _, err := s.queries.GetAccount(ctx, id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return oops.With("statusCode", http.StatusBadRequest).Errorf("Account %d not found", id)
+			}
+
+			return fmt.Errorf("get account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func orderPair(a, b int64) (int64, int64) {
+	return min(a, b), max(a, b)
+}
@@ -0,0 +1,143 @@
+package proximity
+
+import (
+	"os"
+	"path/filepath"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+)
+
+const testConfig = `
+telegram:
+  token: test
+  adminChatID: 1
+matching:
+  accuracyFactor: 2
+`
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	di := do.New()
+	do.ProvideValue(di, cfg)
+	do.ProvideValue(di, database.New(nil))
+	do.ProvideValue[*pgxpool.Pool](di, nil)
+	do.Provide(di, settings.New)
+
+	return &Service{cfg: cfg, settingsService: do.MustInvoke[*settings.Service](di)}
+}
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        database.ProximityRule
+		together    bool
+		wantChanged bool
+		wantEvent   api.AlertEventType
+	}{
+		{
+			name:     "still together",
+			rule:     database.ProximityRule{Together: true, NotifyMeet: true, NotifyPart: true},
+			together: true,
+		},
+		{
+			name:     "still apart",
+			rule:     database.ProximityRule{Together: false, NotifyMeet: true, NotifyPart: true},
+			together: false,
+		},
+		{
+			name:        "meet",
+			rule:        database.ProximityRule{Together: false, NotifyMeet: true, NotifyPart: true},
+			together:    true,
+			wantChanged: true,
+			wantEvent:   api.AlertEventTypeProximityMeet,
+		},
+		{
+			name:        "part",
+			rule:        database.ProximityRule{Together: true, NotifyMeet: true, NotifyPart: true},
+			together:    false,
+			wantChanged: true,
+			wantEvent:   api.AlertEventTypeProximityPart,
+		},
+		{
+			name:        "meet without notification",
+			rule:        database.ProximityRule{Together: false, NotifyPart: true},
+			together:    true,
+			wantChanged: true,
+		},
+		{
+			name:        "part without notification",
+			rule:        database.ProximityRule{Together: true, NotifyMeet: true},
+			together:    false,
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, event := transition(&tt.rule, tt.together)
+			if changed != tt.wantChanged || event != tt.wantEvent {
+				t.Errorf("transition() = %v, %q, want %v, %q", changed, event, tt.wantChanged, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestTogetherWithAccuracy(t *testing.T) {
+	s := newTestService(t)
+
+	// about 1.1 km apart
+	home := api.LocationData{Latitude: 55.7558, Longitude: 37.6173}
+	near := api.LocationData{Latitude: 55.7658, Longitude: 37.6173}
+
+	tests := []struct {
+		name           string
+		distance       float64
+		firstAccuracy  float64
+		secondAccuracy float64
+		firstFactor    *float64
+		want           bool
+	}{
+		{name: "within distance", distance: 1200, firstAccuracy: 5, secondAccuracy: 5, want: true},
+		{name: "beyond distance", distance: 1000, firstAccuracy: 5, secondAccuracy: 5, want: false},
+		{name: "within the accuracy margin", distance: 1000, firstAccuracy: 10, secondAccuracy: 60, want: true},
+		{name: "margin is the larger accuracy only", distance: 1000, firstAccuracy: 30, secondAccuracy: 30, want: false},
+		{name: "account factor overrides the config", distance: 1000, firstAccuracy: 60, secondAccuracy: 5, firstFactor: util.ToPtr(1.0), want: false},
+		{name: "zero factor ignores the accuracy", distance: 1000, firstAccuracy: 500, secondAccuracy: 5, firstFactor: util.ToPtr(0.0), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := database.ProximityRule{Distance: tt.distance}
+			first := database.Account{Settings: api.AccountSettings{AccuracyFactor: tt.firstFactor}}
+			second := database.Account{}
+
+			firstLoc, secondLoc := home, near
+			firstLoc.Accuracy = tt.firstAccuracy
+			secondLoc.Accuracy = tt.secondAccuracy
+
+			slack := s.slack(&first, &firstLoc, &second, &secondLoc)
+
+			if got := rule.Contains(firstLoc.Latitude, firstLoc.Longitude, secondLoc.Latitude, secondLoc.Longitude, slack); got != tt.want {
+				t.Errorf("together = %v with slack %.0f m, want %v", got, slack, tt.want)
+			}
+		})
+	}
+}
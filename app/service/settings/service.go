@@ -0,0 +1,133 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+)
+
+// Service resolves the effective thresholds: fence overrides win over account overrides,
+// which win over the config tunables.
+type Service struct {
+	cfg     *config.Config
+	dbConn  *pgxpool.Pool
+	queries *database.Queries
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:     do.MustInvoke[*config.Config](di),
+		dbConn:  do.MustInvoke[*pgxpool.Pool](di),
+		queries: do.MustInvoke[*database.Queries](di),
+	}, nil
+}
+
+// OfflineThreshold is the period without updates after which the account is considered offline.
+func (s *Service) OfflineThreshold(acc *database.Account) time.Duration {
+	if acc.Settings.OfflineThresholdMinutes != nil {
+		return time.Duration(*acc.Settings.OfflineThresholdMinutes) * time.Minute
+	}
+
+	return s.cfg.Tune().Offline.Threshold
+}
+
+func (s *Service) LowBatteryThreshold(acc *database.Account) int {
+	return util.GetPtrOrDefault(acc.Settings.LowBatteryThreshold, s.cfg.Tune().Battery.LowThreshold)
+}
+
+// SpeedLimitKmh returns the speed limit of the account, zero means speeding alerts are disabled.
+func (s *Service) SpeedLimitKmh(acc *database.Account) float64 {
+	if acc.Settings.SpeedLimitKmh != nil {
+		return float64(*acc.Settings.SpeedLimitKmh)
+	}
+
+	return s.cfg.Tune().Driving.SpeedLimitKmh
+}
+
+// AccuracyFactor is how many reported accuracies of the account are added to the radius of the fence,
+// fence may be nil for proximity rules.
+func (s *Service) AccuracyFactor(acc *database.Account, fence *database.Fence) float64 {
+	if fence != nil && fence.Settings.AccuracyFactor != nil {
+		return *fence.Settings.AccuracyFactor
+	}
+
+	return util.GetPtrOrDefault(acc.Settings.AccuracyFactor, s.cfg.Tune().Matching.AccuracyFactor)
+}
+
+func (s *Service) IngestRps() int {
+	return s.cfg.Tune().RateLimits.IngestRps
+}
+
+func (s *Service) SosRpm() int {
+	return s.cfg.Tune().RateLimits.SosRpm
+}
+
+func (s *Service) ShareRpm() int {
+	return s.cfg.Tune().RateLimits.ShareRpm
+}
+
+// UpdateAccountSettings changes the stored settings of the account with fn while its row is locked,
+// so that the fields changed meanwhile by the bot or the API are not lost.
+func (s *Service) UpdateAccountSettings(
+	ctx context.Context,
+	accountID int64,
+	fn func(settings *api.AccountSettings) error,
+) (database.Account, error) {
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return database.Account{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.queries.WithTx(tx)
+
+	acc, err := qtx.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		return database.Account{}, fmt.Errorf("get account: %w", err)
+	}
+
+	if err = fn(&acc.Settings); err != nil {
+		return database.Account{}, err
+	}
+
+	if err = qtx.UpdateAccountSettings(ctx, database.UpdateAccountSettingsParams{
+		ID:       acc.ID,
+		Settings: acc.Settings,
+	}); err != nil {
+		return database.Account{}, fmt.Errorf("update account settings: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return database.Account{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return acc, nil
+}
+
+func (s *Service) ListFences(ctx context.Context) ([]database.Fence, error) {
+	fences, err := s.queries.GetAllFences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get all fences: %w", err)
+	}
+
+	return fences, nil
+}
+
+func (s *Service) UpdateFenceSettings(ctx context.Context, id int64, settings api.FenceSettings) (database.Fence, error) {
+	fence, err := s.queries.UpdateFenceSettings(ctx, database.UpdateFenceSettingsParams{
+		ID:       id,
+		Settings: settings,
+	})
+	if err != nil {
+		return database.Fence{}, fmt.Errorf("update fence settings: %w", err)
+	}
+
+	return fence, nil
+}
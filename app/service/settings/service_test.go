@@ -0,0 +1,138 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"testing"
+	"time"
+)
+
+const testConfig = `
+telegram:
+  token: test
+  adminChatID: 1
+offline:
+  threshold: 30m
+battery:
+  lowThreshold: 15
+driving:
+  speedLimitKmh: 90
+matching:
+  accuracyFactor: 2
+`
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	return &Service{cfg: cfg}
+}
+
+func TestThresholds(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		name        string
+		account     api.AccountSettings
+		wantOffline time.Duration
+		wantBattery int
+		wantSpeed   float64
+	}{
+		{
+			name:        "config defaults",
+			wantOffline: 30 * time.Minute,
+			wantBattery: 15,
+			wantSpeed:   90,
+		},
+		{
+			name: "account overrides",
+			account: api.AccountSettings{
+				OfflineThresholdMinutes: util.ToPtr(90),
+				LowBatteryThreshold:     util.ToPtr(40),
+				SpeedLimitKmh:           util.ToPtr(60),
+			},
+			wantOffline: 90 * time.Minute,
+			wantBattery: 40,
+			wantSpeed:   60,
+		},
+		{
+			// zero turns the alerts off instead of falling back to the config
+			name: "account zeros",
+			account: api.AccountSettings{
+				LowBatteryThreshold: util.ToPtr(0),
+				SpeedLimitKmh:       util.ToPtr(0),
+			},
+			wantOffline: 30 * time.Minute,
+			wantBattery: 0,
+			wantSpeed:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := &database.Account{Settings: tt.account}
+
+			if got := s.OfflineThreshold(acc); got != tt.wantOffline {
+				t.Errorf("OfflineThreshold() = %s, want %s", got, tt.wantOffline)
+			}
+
+			if got := s.LowBatteryThreshold(acc); got != tt.wantBattery {
+				t.Errorf("LowBatteryThreshold() = %d, want %d", got, tt.wantBattery)
+			}
+
+			if got := s.SpeedLimitKmh(acc); got != tt.wantSpeed {
+				t.Errorf("SpeedLimitKmh() = %v, want %v", got, tt.wantSpeed)
+			}
+		})
+	}
+}
+
+func TestAccuracyFactor(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		name    string
+		account *float64
+		fence   *database.Fence
+		want    float64
+	}{
+		{name: "config default", want: 2},
+		{name: "config default for a fence", fence: &database.Fence{}, want: 2},
+		{name: "account override", account: util.ToPtr(1.5), want: 1.5},
+		{name: "account override for a fence", account: util.ToPtr(1.5), fence: &database.Fence{}, want: 1.5},
+		{
+			name:    "fence override wins",
+			account: util.ToPtr(1.5),
+			fence:   &database.Fence{Settings: api.FenceSettings{AccuracyFactor: util.ToPtr(0.5)}},
+			want:    0.5,
+		},
+		{
+			name:  "fence zero",
+			fence: &database.Fence{Settings: api.FenceSettings{AccuracyFactor: util.ToPtr(0.0)}},
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := &database.Account{Settings: api.AccountSettings{AccuracyFactor: tt.account}}
+
+			if got := s.AccuracyFactor(acc, tt.fence); got != tt.want {
+				t.Errorf("AccuracyFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+)
+
+const (
+	ScopeLive  = "live"
+	ScopeToday = "today"
+
+	AccessPage   = "page"
+	AccessFeed   = "feed"
+	AccessStream = "stream"
+
+	metersPerDegree = 111320
+)
+
+// ErrUnavailable is returned for unknown, expired and revoked links alike,
+// so that the viewer can't tell them apart.
+var ErrUnavailable = errors.New("share link is unavailable")
+
+type Position struct {
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Accuracy  float64   `json:"accuracy"`
+	Created   time.Time `json:"created"`
+}
+
+type Feed struct {
+	Name     string     `json:"name"`
+	Expires  time.Time  `json:"expires"`
+	Position *Position  `json:"position,omitempty"`
+	Track    []Position `json:"track,omitempty"`
+
+	// UpdateID identifies the latest update, so that the stream only sends changes
+	UpdateID int64 `json:"-"`
+}
+
+type Service struct {
+	cfg     *config.Config
+	queries *database.Queries
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:     do.MustInvoke[*config.Config](di),
+		queries: do.MustInvoke[*database.Queries](di),
+	}, nil
+}
+
+func (s *Service) Create(ctx context.Context, acc *database.Account, scope string, precision int, ttl time.Duration) (database.ShareLink, error) {
+	token, err := util.RandomToken(18)
+	if err != nil {
+		return database.ShareLink{}, fmt.Errorf("generate token: %w", err)
+	}
+
+	now := time.Now()
+
+	link, err := s.queries.CreateShareLink(ctx, database.CreateShareLinkParams{
+		Token:     token,
+		AccountID: acc.ID,
+		Scope:     scope,
+		Precision: int32(precision), //nolint:gosec
+		Created:   now,
+		Expires:   now.Add(ttl),
+	})
+	if err != nil {
+		return database.ShareLink{}, fmt.Errorf("create share link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (s *Service) URL(link *database.ShareLink) string {
+	return fmt.Sprintf("%s/share/%s", s.cfg.BaseApiURL, link.Token)
+}
+
+func (s *Service) ListActive(ctx context.Context, acc *database.Account) ([]database.GetActiveShareLinksByAccountIDRow, error) {
+	links, err := s.queries.GetActiveShareLinksByAccountID(ctx, database.GetActiveShareLinksByAccountIDParams{
+		AccountID: acc.ID,
+		Expires:   time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Revoke disables the account's link and reports whether it was active.
+func (s *Service) Revoke(ctx context.Context, acc *database.Account, id int64) (bool, error) {
+	count, err := s.queries.RevokeShareLink(ctx, database.RevokeShareLinkParams{
+		ID:        id,
+		AccountID: acc.ID,
+		Revoked:   util.ToPtr(time.Now()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("revoke share link: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Resolve returns the active link for the token.
+func (s *Service) Resolve(ctx context.Context, token string) (database.ShareLink, error) {
+	link, err := s.queries.GetShareLinkByToken(ctx, token)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.ShareLink{}, ErrUnavailable
+	}
+	if err != nil {
+		return database.ShareLink{}, fmt.Errorf("get share link: %w", err)
+	}
+
+	if link.Revoked != nil || time.Now().After(link.Expires) {
+		return database.ShareLink{}, ErrUnavailable
+	}
+
+	return link, nil
+}
+
+func (s *Service) LogAccess(ctx context.Context, link *database.ShareLink, kind, ip, userAgent string) error {
+	if err := s.queries.CreateShareAccess(ctx, database.CreateShareAccessParams{
+		ShareID:   link.ID,
+		Created:   time.Now(),
+		Kind:      kind,
+		Ip:        ip,
+		UserAgent: userAgent,
+	}); err != nil {
+		return fmt.Errorf("create share access: %w", err)
+	}
+
+	return nil
+}
+
+// Feed builds what the viewer of the link is allowed to see, with the precision reduction applied.
+func (s *Service) Feed(ctx context.Context, link *database.ShareLink) (Feed, error) {
+	acc, err := s.queries.GetAccount(ctx, link.AccountID)
+	if err != nil {
+		return Feed{}, fmt.Errorf("get account: %w", err)
+	}
+
+	feed := Feed{
+		Name:    acc.Name,
+		Expires: link.Expires,
+	}
+
+	lastUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return Feed{}, fmt.Errorf("get last location update: %w", err)
+	}
+
+	lastUpdate := util.FirstOrNil(lastUpdates)
+	if lastUpdate != nil {
+		feed.UpdateID = lastUpdate.ID
+		feed.Position = util.ToPtr(toPosition(lastUpdate, link.Precision))
+	}
+
+	if link.Scope != ScopeToday {
+		return feed, nil
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	updates, err := s.queries.GetLocationUpdatesByAccountIDSince(ctx, database.GetLocationUpdatesByAccountIDSinceParams{
+		AccountID: acc.ID,
+		Created:   startOfDay,
+	})
+	if err != nil {
+		return Feed{}, fmt.Errorf("get updates: %w", err)
+	}
+
+	feed.Track = pie.Map(updates, func(update database.Update) Position {
+		return toPosition(&update, link.Precision)
+	})
+
+	return feed, nil
+}
+
+func toPosition(update *database.Update, precision int32) Position {
+	loc := update.Data.Location
+
+	lat, lon := coarsen(loc, float64(precision))
+
+	return Position{
+		Latitude:  lat,
+		Longitude: lon,
+		Accuracy:  math.Max(loc.Accuracy, float64(precision)),
+		Created:   update.Created,
+	}
+}
+
+// coarsen snaps the location to a grid with the given cell size in meters.
+func coarsen(loc *api.LocationData, precision float64) (float64, float64) {
+	if precision <= 0 {
+		return loc.Latitude, loc.Longitude
+	}
+
+	latStep := precision / metersPerDegree
+	lat := math.Round(loc.Latitude/latStep) * latStep
+
+	lonStep := precision / (metersPerDegree * math.Max(math.Cos(lat*math.Pi/180), 0.01))
+	lon := math.Round(loc.Longitude/lonStep) * lonStep
+
+	return lat, lon
+}
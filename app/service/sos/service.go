@@ -0,0 +1,238 @@
+package sos
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/samber/do"
+)
+
+const (
+	escalationCheckInterval = 10 * time.Second
+
+	// escalationLease is how long a claimed incident is hidden from the other instances,
+	// it is escalated again after that if the instance dies before recording the escalation
+	escalationLease = 5 * time.Minute
+)
+
+type Service struct {
+	cfg             *config.Config
+	queries         *database.Queries
+	telegramService *telegram.Service
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:             do.MustInvoke[*config.Config](di),
+		queries:         do.MustInvoke[*database.Queries](di),
+		telegramService: do.MustInvoke[*telegram.Service](di),
+	}, nil
+}
+
+// Trigger records a new SOS incident and immediately alerts everyone in the circle.
+func (s *Service) Trigger(ctx context.Context, acc *database.Account, message *string) (database.SosIncident, error) {
+	now := time.Now()
+
+	incident, err := s.queries.CreateSosIncident(ctx, database.CreateSosIncidentParams{
+		AccountID:      acc.ID,
+		Created:        now,
+		Message:        message,
+		NextEscalation: now.Add(s.cfg.Tune().SOS.FirstInterval),
+	})
+	if err != nil {
+		return database.SosIncident{}, fmt.Errorf("create sos incident: %w", err)
+	}
+
+	recipients, err := s.broadcast(ctx, &incident, acc)
+	if err != nil {
+		return database.SosIncident{}, fmt.Errorf("broadcast: %w", err)
+	}
+
+	if err = s.queries.CreateSosEvent(ctx, database.CreateSosEventParams{
+		SosID:     incident.ID,
+		Created:   now,
+		Type:      string(api.SosEventTypeTriggered),
+		AccountID: &acc.ID,
+		Details:   util.ToPtr(fmt.Sprintf("sent to %d chats", recipients)),
+	}); err != nil {
+		return database.SosIncident{}, fmt.Errorf("create sos event: %w", err)
+	}
+
+	return incident, nil
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (database.SosIncident, []database.SosEvent, error) {
+	incident, err := s.queries.GetSosIncident(ctx, id)
+	if err != nil {
+		return database.SosIncident{}, nil, fmt.Errorf("get sos incident: %w", err)
+	}
+
+	events, err := s.queries.GetSosEvents(ctx, id)
+	if err != nil {
+		return database.SosIncident{}, nil, fmt.Errorf("get sos events: %w", err)
+	}
+
+	return incident, events, nil
+}
+
+// RunEscalation re-sends unacknowledged SOS incidents with the refreshed position,
+// doubling the interval every time up to the configured maximum.
+func (s *Service) RunEscalation(ctx context.Context) {
+	ticker := time.NewTicker(escalationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.escalateDue(ctx)
+		}
+	}
+}
+
+func (s *Service) escalateDue(ctx context.Context) {
+	now := time.Now()
+
+	incidents, err := s.queries.ClaimDueSosIncidents(ctx, database.ClaimDueSosIncidentsParams{
+		Now:        now,
+		LeaseUntil: now.Add(escalationLease),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim due SOS incidents",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, incident := range incidents {
+		if err = s.escalate(ctx, &incident); err != nil {
+			slog.ErrorContext(ctx, "Failed to escalate SOS",
+				slog.Int64("sos_id", incident.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func (s *Service) escalate(ctx context.Context, incident *database.SosIncident) error {
+	acc, err := s.queries.GetAccount(ctx, incident.AccountID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	now := time.Now()
+	incident.EscalationLevel++
+
+	if err = s.queries.UpdateSosIncidentEscalation(ctx, database.UpdateSosIncidentEscalationParams{
+		ID:              incident.ID,
+		EscalationLevel: incident.EscalationLevel,
+		NextEscalation:  now.Add(s.interval(incident.EscalationLevel)),
+	}); err != nil {
+		return fmt.Errorf("update escalation: %w", err)
+	}
+
+	recipients, err := s.broadcast(ctx, incident, &acc)
+	if err != nil {
+		return fmt.Errorf("broadcast: %w", err)
+	}
+
+	if err = s.queries.CreateSosEvent(ctx, database.CreateSosEventParams{
+		SosID:   incident.ID,
+		Created: now,
+		Type:    string(api.SosEventTypeEscalated),
+		Details: util.ToPtr(fmt.Sprintf("level %d, sent to %d chats", incident.EscalationLevel, recipients)),
+	}); err != nil {
+		return fmt.Errorf("create sos event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) interval(level int32) time.Duration {
+	delay := float64(s.cfg.Tune().SOS.FirstInterval) * math.Pow(2, float64(level))
+	if delay > float64(s.cfg.Tune().SOS.MaxInterval) {
+		return s.cfg.Tune().SOS.MaxInterval
+	}
+
+	return time.Duration(delay)
+}
+
+// broadcast sends the SOS to every linked chat except the sender's and returns the number of recipients.
+func (s *Service) broadcast(ctx context.Context, incident *database.SosIncident, acc *database.Account) (int, error) {
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get all accounts: %w", err)
+	}
+
+	locationUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return 0, fmt.Errorf("get last location update: %w", err)
+	}
+
+	locationUpdate := util.FirstOrNil(locationUpdates)
+
+	text := s.formatText(incident, acc, locationUpdate)
+
+	var loc *api.LocationData
+	if locationUpdate != nil {
+		loc = locationUpdate.Data.Location
+	}
+
+	recipients := 0
+
+	for _, a := range accounts {
+		if a.ChatID == nil || a.ID == acc.ID {
+			continue
+		}
+
+		if err = s.telegramService.SendSOS(ctx, &a, incident.ID, text, loc); err != nil {
+			slog.ErrorContext(ctx, "Failed to send SOS",
+				slog.Int64("sos_id", incident.ID),
+				slog.Int64("chat_id", *a.ChatID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		recipients++
+	}
+
+	return recipients, nil
+}
+
+func (s *Service) formatText(incident *database.SosIncident, acc *database.Account, locationUpdate *database.Update) i18n.Text {
+	texts := []i18n.Text{i18n.M("sos.title", i18n.Escaped(acc.Name))}
+
+	if incident.EscalationLevel > 0 {
+		texts = append(texts, i18n.M("sos.repeat", incident.EscalationLevel, i18n.Ago(incident.Created)))
+	}
+
+	if incident.Message != nil {
+		texts = append(texts, i18n.Raw("💬 "+i18n.EscapeMarkdown(*incident.Message)))
+	}
+
+	if locationUpdate == nil {
+		return i18n.Lines(append(texts, i18n.M("common.no_location"))...)
+	}
+
+	loc := locationUpdate.Data.Location
+	mapLink := i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude}
+
+	texts = append(texts, i18n.M("sos.location", i18n.Ago(locationUpdate.Created), mapLink, loc.Accuracy))
+
+	if loc.Address != nil {
+		texts = append(texts, i18n.Raw("📍 "+i18n.EscapeMarkdown(*loc.Address)))
+	}
+
+	return i18n.Lines(texts...)
+}
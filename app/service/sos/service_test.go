@@ -0,0 +1,62 @@
+package sos
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatTextEscapesUserInput(t *testing.T) {
+	s := &Service{}
+	acc := &database.Account{Name: "john_doe"}
+	incident := &database.SosIncident{
+		Created: time.Now(),
+		Message: util.ToPtr("help_me *now"),
+	}
+	update := &database.Update{
+		Created: time.Now(),
+		Data: api.UpdateData{Location: &api.LocationData{
+			Latitude:  55.75,
+			Longitude: 37.61,
+			Accuracy:  10,
+			Address:   util.ToPtr("Main st. [2]"),
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		update *database.Update
+		want   []string
+	}{
+		{
+			name:   "with location",
+			update: update,
+			want:   []string{`john\_doe`, `help\_me \*now`, `Main st. \[2]`, "[On the map]("},
+		},
+		{
+			name:   "without location",
+			update: nil,
+			want:   []string{`john\_doe`, `help\_me \*now`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.formatText(incident, acc, tt.update).Localize(i18n.Locale{Lang: i18n.EN})
+
+			for _, part := range tt.want {
+				if !strings.Contains(got, part) {
+					t.Errorf("text %q doesn't contain %q", got, part)
+				}
+			}
+
+			if strings.Contains(got, "help_me *now") {
+				t.Errorf("text %q contains the unescaped message", got)
+			}
+		})
+	}
+}
@@ -18,6 +18,10 @@ func (s *Service) handleUpdates(ctx context.Context, _ *bot.Bot, update *models.
 	if update.CallbackQuery != nil {
 		s.handleCallbackQuery(ctx, update.CallbackQuery)
 	}
+
+	if update.InlineQuery != nil {
+		s.handleInlineQuery(ctx, update.InlineQuery)
+	}
 }
 
 func (s *Service) handleMessage(ctx context.Context, msg *models.Message) {
@@ -30,13 +34,45 @@ func (s *Service) handleMessage(ctx context.Context, msg *models.Message) {
 		return
 	}
 
+	if msg.Venue != nil {
+		s.handleStateLocation(ctx, &acc, msg.Venue.Location.Latitude, msg.Venue.Location.Longitude)
+		return
+	}
+
+	if msg.Location != nil {
+		s.handleStateLocation(ctx, &acc, msg.Location.Latitude, msg.Location.Longitude)
+		return
+	}
+
 	switch strings.TrimSpace(msg.Text) {
 	case "/list":
 		s.handleList(ctx, &acc)
+	case "/map":
+		s.handleMap(ctx, &acc)
+	case "/follow":
+		s.handleFollow(ctx, &acc)
+	case "/unfollow":
+		s.handleUnfollow(ctx, &acc)
 	case "/history":
 		s.handleHistory(ctx, &acc)
+	case "/editfence":
+		s.handleEditFence(ctx, &acc)
 	case "/deletefence":
 		s.handleDeleteFence(ctx, &acc)
+	case "/watch":
+		s.handleWatch(ctx, &acc)
+	case "/watches":
+		s.handleWatches(ctx, &acc)
+	case "/share":
+		s.handleShare(ctx, &acc)
+	case "/shares":
+		s.handleShares(ctx, &acc)
+	case "/language":
+		s.handleLanguage(ctx, &acc)
+	case "/maps":
+		s.handleMaps(ctx, &acc)
+	case "/thresholds":
+		s.handleThresholds(ctx, &acc)
 	case "/addfence":
 		s.handleAddFence(ctx, &acc)
 	case "/cancel":
@@ -69,11 +105,102 @@ func (s *Service) handleCallbackQuery(ctx context.Context, query *models.Callbac
 		_ = json.Unmarshal([]byte(query.Data), &historyDTO)
 
 		s.handleHistoryCallback(ctx, &acc, historyDTO, query)
+	case "history_range", "history_page":
+		var pageDTO HistoryPageCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &pageDTO)
+
+		if pageDTO.Type == "history_range" {
+			s.handleHistoryRangeCallback(ctx, &acc, pageDTO, query)
+		} else {
+			s.handleHistoryPageCallback(ctx, &acc, pageDTO, query)
+		}
 	case "delete_fence":
 		var fenceDTO DeleteFenceCallbackDTO
 		_ = json.Unmarshal([]byte(query.Data), &fenceDTO)
 
 		s.handleDeleteFenceCallback(ctx, &acc, fenceDTO, query)
+	case "sos_ack":
+		var sosDTO SOSAckCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &sosDTO)
+
+		s.handleSOSAckCallback(ctx, &acc, sosDTO, query)
+	case "watch_acc", "watch_dir", "watch_set":
+		var watchDTO WatchCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &watchDTO)
+
+		switch watchDTO.Type {
+		case "watch_acc":
+			s.handleWatchAccountCallback(ctx, &acc, watchDTO, query)
+		case "watch_dir":
+			s.handleWatchDirectionCallback(ctx, &acc, watchDTO, query)
+		default:
+			s.handleWatchSetCallback(ctx, &acc, watchDTO, query)
+		}
+	case "delete_watch":
+		var watchDTO DeleteWatchCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &watchDTO)
+
+		s.handleDeleteWatchCallback(ctx, &acc, watchDTO, query)
+	case "share_ttl", "share_prec", "share_scope":
+		var shareDTO ShareCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &shareDTO)
+
+		switch shareDTO.Type {
+		case "share_ttl":
+			s.handleShareTTLCallback(ctx, &acc, shareDTO, query)
+		case "share_prec":
+			s.handleSharePrecisionCallback(ctx, &acc, shareDTO, query)
+		default:
+			s.handleShareScopeCallback(ctx, &acc, shareDTO, query)
+		}
+	case "revoke_share":
+		var shareDTO RevokeShareCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &shareDTO)
+
+		s.handleRevokeShareCallback(ctx, &acc, shareDTO, query)
+	case "follow_acc", "follow_set":
+		var followDTO FollowCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &followDTO)
+
+		if followDTO.Type == "follow_acc" {
+			s.handleFollowAccountCallback(ctx, &acc, followDTO, query)
+		} else {
+			s.handleFollowSetCallback(ctx, &acc, followDTO, query)
+		}
+	case "unfollow":
+		var unfollowDTO UnfollowCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &unfollowDTO)
+
+		s.handleUnfollowCallback(ctx, &acc, unfollowDTO, query)
+	case "language":
+		var languageDTO LanguageCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &languageDTO)
+
+		s.handleLanguageCallback(ctx, &acc, languageDTO, query)
+	case "threshold_acc", "threshold":
+		var thresholdDTO ThresholdCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &thresholdDTO)
+
+		if thresholdDTO.Type == "threshold_acc" {
+			s.handleThresholdAccountCallback(ctx, &acc, thresholdDTO, query)
+		} else {
+			s.handleThresholdCallback(ctx, &acc, thresholdDTO, query)
+		}
+	case "maps":
+		var mapsDTO MapsCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &mapsDTO)
+
+		s.handleMapsCallback(ctx, &acc, mapsDTO, query)
+	case "fence_radius":
+		var radiusDTO FenceRadiusCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &radiusDTO)
+
+		s.handleFenceRadiusCallback(ctx, &acc, radiusDTO, query)
+	case "edit_fence":
+		var fenceDTO EditFenceCallbackDTO
+		_ = json.Unmarshal([]byte(query.Data), &fenceDTO)
+
+		s.handleEditFenceCallback(ctx, &acc, fenceDTO, query)
 	case "cancel":
 		s.handleCancelCallback(ctx, &acc, query)
 	default:
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"roflbeacon2/pkg/database"
-	"strconv"
 	"strings"
 
 	"github.com/go-telegram/bot"
@@ -38,6 +37,8 @@ func (s *Service) handleList(ctx context.Context, selfAcc *database.Account) {
 	myLastLocation := myLastUpdate.Data.Location
 
 	var result []string
+	var venueAccounts []database.Account
+	var venueUpdates []database.Update
 
 	for _, acc := range accounts {
 		if acc.ID == selfAcc.ID {
@@ -45,22 +46,28 @@ func (s *Service) handleList(ctx context.Context, selfAcc *database.Account) {
 		}
 
 		// TODO: improve this
-		lastUpdates, err := s.queries.GetLastUpdateByAccountID(ctx, acc.ID)
+		latestUpdates, err := s.queries.GetLatestUpdatesByAccountID(ctx, acc.ID)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to get last update",
+			slog.ErrorContext(ctx, "Failed to get latest updates",
 				slog.Any("error", err),
 			)
 			return
 		}
 
-		if len(lastUpdates) == 0 {
+		if len(latestUpdates) == 0 {
 			continue
 		}
 
-		result = append(result, s.formatUpdate(&acc, lastUpdates[0], myLastLocation))
+		result = append(result, s.formatUpdate(selfAcc.Locale(), &acc, latestUpdates[0], myLastLocation, recentSpeed(latestUpdates)))
+		venueAccounts = append(venueAccounts, acc)
+		venueUpdates = append(venueUpdates, latestUpdates[0])
 	}
 
 	s.SendMessage(ctx, *selfAcc.ChatID, strings.Join(result, "\n\n"))
+
+	for i := range venueAccounts {
+		s.sendVenue(ctx, selfAcc, &venueAccounts[i], venueUpdates[i])
+	}
 }
 
 func (s *Service) handleHistory(ctx context.Context, selfAcc *database.Account) {
@@ -95,13 +102,13 @@ func (s *Service) handleHistory(ctx context.Context, selfAcc *database.Account)
 	cancelBytes, _ := json.Marshal(&cancelDTO)
 
 	buttons = append(buttons, models.InlineKeyboardButton{
-		Text:         "Отмена",
+		Text:         tr(selfAcc, "common.cancel"),
 		CallbackData: string(cancelBytes),
 	})
 
 	if _, err = s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: selfAcc.ChatID,
-		Text:   "Выберите пользователя",
+		Text:   tr(selfAcc, "history.pick_account"),
 		ReplyMarkup: models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{buttons},
 		},
@@ -113,37 +120,29 @@ func (s *Service) handleHistory(ctx context.Context, selfAcc *database.Account)
 }
 
 func (s *Service) handleCancel(ctx context.Context, selfAcc *database.Account) {
-	if *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
-		s.SendMessage(ctx, *selfAcc.ChatID, "Вы не можете использовать данную команду")
-		return
-	}
-
-	s.m.Lock()
-	defer s.m.Unlock()
-
-	s.resetState()
+	s.startStage(ctx, selfAcc, BotState{
+		Stage: idleStage,
+	})
 
-	s.SendMessage(ctx, *selfAcc.ChatID, "ОК")
+	s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "common.ok"))
 }
 
 func (s *Service) handleAddFence(ctx context.Context, selfAcc *database.Account) {
 	if *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
-		s.SendMessage(ctx, *selfAcc.ChatID, "Вы не можете использовать данную команду")
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "common.forbidden"))
 		return
 	}
 
-	s.m.Lock()
-	defer s.m.Unlock()
-
-	s.resetState()
-	s.state.Stage = "add_fence_name"
+	s.startStage(ctx, selfAcc, BotState{
+		Stage: "add_fence_name",
+	})
 
-	s.SendMessage(ctx, *selfAcc.ChatID, "Введите имя новой ограды:")
+	s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "fence.enter_name"))
 }
 
 func (s *Service) handleDeleteFence(ctx context.Context, selfAcc *database.Account) {
 	if *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
-		s.SendMessage(ctx, *selfAcc.ChatID, "Вы не можете использовать данную команду")
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "common.forbidden"))
 		return
 	}
 
@@ -178,13 +177,13 @@ func (s *Service) handleDeleteFence(ctx context.Context, selfAcc *database.Accou
 	cancelBytes, _ := json.Marshal(&cancelDTO)
 
 	buttons = append(buttons, models.InlineKeyboardButton{
-		Text:         "Отмена",
+		Text:         tr(selfAcc, "common.cancel"),
 		CallbackData: string(cancelBytes),
 	})
 
 	if _, err = s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: selfAcc.ChatID,
-		Text:   "Выберите ограду для удаления",
+		Text:   tr(selfAcc, "fence.pick_delete"),
 		ReplyMarkup: models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{buttons},
 		},
@@ -196,65 +195,10 @@ func (s *Service) handleDeleteFence(ctx context.Context, selfAcc *database.Accou
 }
 
 func (s *Service) handleUnknownMessage(ctx context.Context, selfAcc *database.Account, text string) {
-	s.m.Lock()
-	defer s.m.Unlock()
-
-	if s.state.Stage == "idle" || *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
-		s.SendMessage(ctx, *selfAcc.ChatID, "Неизвестная команда")
-		return
-	}
-
 	text = strings.TrimSpace(text)
 	if text == "" {
 		return
 	}
 
-	switch s.state.Stage {
-	case "add_fence_name":
-		s.state.FenceParams.Name = text
-		s.state.Stage = "add_fence_latitude"
-		s.SendMessage(ctx, *selfAcc.ChatID, "Введите широту:")
-	case "add_fence_latitude":
-		value, err := strconv.ParseFloat(text, 64)
-		if value == 0 || err != nil {
-			s.SendMessage(ctx, *selfAcc.ChatID, "Неверное число, попробуйте еще раз")
-			return
-		}
-
-		s.state.FenceParams.Latitude = value
-		s.state.Stage = "add_fence_longitude"
-		s.SendMessage(ctx, *selfAcc.ChatID, "Введите долготу:")
-	case "add_fence_longitude":
-		value, err := strconv.ParseFloat(text, 64)
-		if value == 0 || err != nil {
-			s.SendMessage(ctx, *selfAcc.ChatID, "Неверное число, попробуйте еще раз")
-			return
-		}
-
-		s.state.FenceParams.Longitude = value
-		s.state.Stage = "add_fence_radius"
-		s.SendMessage(ctx, *selfAcc.ChatID, "Введите радиус (в метрах):")
-	case "add_fence_radius":
-		value, err := strconv.ParseFloat(text, 64)
-		if value == 0 || err != nil {
-			s.SendMessage(ctx, *selfAcc.ChatID, "Неверное число, попробуйте еще раз")
-			return
-		}
-
-		s.state.FenceParams.Radius = value
-
-		jsonBytes, _ := json.Marshal(&s.state.FenceParams)
-
-		if _, err = s.queries.CreateFence(ctx, s.state.FenceParams); err != nil {
-			slog.ErrorContext(ctx, "Failed to create fence",
-				slog.Any("error", err),
-			)
-			return
-		}
-
-		s.SendMessage(ctx, *selfAcc.ChatID, string(jsonBytes))
-		s.SendMessage(ctx, *selfAcc.ChatID, "Ограда успешно создана")
-
-		s.state.Stage = "idle"
-	}
+	s.handleStateText(ctx, selfAcc, text)
 }
@@ -9,7 +9,86 @@ type HistoryCallbackDTO struct {
 	ID   int64  `json:"id"`
 }
 
+// HistoryPageCallbackDTO pins the range as a start and a length so that paging does not shift with time.
+type HistoryPageCallbackDTO struct {
+	Type      string `json:"type"`
+	AccountID int64  `json:"a"`
+	Since     int64  `json:"f"`
+	Hours     int    `json:"h"`
+	Page      int    `json:"p,omitempty"`
+}
+
 type DeleteFenceCallbackDTO struct {
 	Type string `json:"type"`
 	ID   int64  `json:"id"`
 }
+
+type SOSAckCallbackDTO struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+// WatchCallbackDTO uses short keys to stay within the 64 byte callback data limit.
+type WatchCallbackDTO struct {
+	Type      string `json:"type"`
+	AccountID int64  `json:"a"`
+	Direction string `json:"d,omitempty"`
+	FenceID   int64  `json:"f,omitempty"`
+	Distance  int    `json:"m,omitempty"`
+}
+
+type DeleteWatchCallbackDTO struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+// ShareCallbackDTO collects the share link options step by step, keys are short for the same reason.
+type ShareCallbackDTO struct {
+	Type      string `json:"type"`
+	TTL       int    `json:"t,omitempty"`
+	Precision int    `json:"p,omitempty"`
+	Scope     string `json:"s,omitempty"`
+}
+
+type RevokeShareCallbackDTO struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+type FollowCallbackDTO struct {
+	Type      string `json:"type"`
+	AccountID int64  `json:"a"`
+	Period    int    `json:"p,omitempty"`
+}
+
+type UnfollowCallbackDTO struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+type LanguageCallbackDTO struct {
+	Type     string `json:"type"`
+	Language string `json:"l"`
+}
+
+type ThresholdCallbackDTO struct {
+	Type      string `json:"type"`
+	AccountID int64  `json:"a"`
+	Key       string `json:"k,omitempty"`
+}
+
+type MapsCallbackDTO struct {
+	Type     string `json:"type"`
+	Provider string `json:"p"`
+}
+
+type FenceRadiusCallbackDTO struct {
+	Type   string `json:"type"`
+	Radius int    `json:"r"`
+}
+
+type EditFenceCallbackDTO struct {
+	Type   string `json:"type"`
+	ID     int64  `json:"id"`
+	Action string `json:"a,omitempty"`
+}
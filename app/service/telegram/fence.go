@@ -0,0 +1,301 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"regexp"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+var (
+	fenceRadiusPresets = []int{50, 100, 200, 500, 1000}
+
+	// maxFenceRadius is in meters, a larger fence would cover a whole region
+	maxFenceRadius = 100_000.0
+
+	coordinatesRegexp = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*[,;\s]\s*(-?\d+(?:\.\d+)?)\s*$`)
+)
+
+func parseCoordinates(text string) (float64, float64, bool) {
+	match := coordinatesRegexp.FindStringSubmatch(text)
+	if match == nil {
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+
+	lon, err := strconv.ParseFloat(match[2], 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+
+	return lat, lon, true
+}
+
+func (s *Service) handleEditFence(ctx context.Context, selfAcc *database.Account) {
+	if *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "common.forbidden"))
+		return
+	}
+
+	fences, err := s.queries.GetAllFences(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all fences",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+
+	for _, f := range fences {
+		rows = append(rows, []models.InlineKeyboardButton{s.editFenceButton(f.Name, EditFenceCallbackDTO{
+			Type: "edit_fence",
+			ID:   f.ID,
+		})})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "fence.pick_edit"), rows)
+}
+
+// handleEditFenceCallback first offers the actions for the chosen fence and then starts the chosen one.
+func (s *Service) handleEditFenceCallback(ctx context.Context, acc *database.Account, dto EditFenceCallbackDTO, query *models.CallbackQuery) {
+	if *acc.ChatID != s.cfg.Telegram.AdminChatID {
+		return
+	}
+
+	fence, err := s.queries.GetFence(ctx, dto.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get fence",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if dto.Action == "" {
+		text := tr(acc, "fence.describe", fence.Name, fence.Latitude, fence.Longitude, i18n.Distance(fence.Radius))
+
+		s.editKeyboard(ctx, acc, query, text, [][]models.InlineKeyboardButton{
+			{
+				s.editFenceButton(tr(acc, "fence.move"), EditFenceCallbackDTO{Type: "edit_fence", ID: fence.ID, Action: "move"}),
+				s.editFenceButton(tr(acc, "fence.resize"), EditFenceCallbackDTO{Type: "edit_fence", ID: fence.ID, Action: "resize"}),
+				s.editFenceButton(tr(acc, "fence.rename"), EditFenceCallbackDTO{Type: "edit_fence", ID: fence.ID, Action: "rename"}),
+			},
+			{s.editFenceButton(tr(acc, "fence.accuracy"), EditFenceCallbackDTO{Type: "edit_fence", ID: fence.ID, Action: "accuracy"})},
+			{s.cancelButton(acc)},
+		})
+
+		return
+	}
+
+	if _, err = s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	state := BotState{
+		FenceID: fence.ID,
+		FenceParams: database.CreateFenceParams{
+			Name:      fence.Name,
+			Longitude: fence.Longitude,
+			Latitude:  fence.Latitude,
+			Radius:    fence.Radius,
+		},
+	}
+
+	switch dto.Action {
+	case "move":
+		state.Stage = "edit_fence_move"
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.center_prompt"))
+	case "resize":
+		state.Stage = "edit_fence_radius"
+		s.sendRadiusPresets(ctx, acc)
+	case "rename":
+		state.Stage = "edit_fence_name"
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.enter_new_name"))
+	case "accuracy":
+		state.Stage = "edit_fence_accuracy"
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.accuracy_prompt"))
+	default:
+		return
+	}
+
+	s.startStage(ctx, acc, state)
+}
+
+func (s *Service) handleFenceRadiusCallback(ctx context.Context, acc *database.Account, dto FenceRadiusCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.withState(ctx, acc, func(state *BotState, _ bool) {
+		if state.Stage != "add_fence_radius" && state.Stage != "edit_fence_radius" {
+			s.SendMessage(ctx, *acc.ChatID, tr(acc, "common.outdated"))
+			return
+		}
+
+		s.handleFenceRadius(ctx, acc, state, float64(dto.Radius))
+	})
+}
+
+func (s *Service) handleAddFenceName(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	state.FenceParams.Name = text
+	state.Stage = "add_fence_center"
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.center_prompt"))
+}
+
+func (s *Service) handleEditFenceName(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	state.FenceParams.Name = text
+
+	s.saveEditedFence(ctx, acc, state)
+}
+
+func (s *Service) handleFenceCenterText(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	lat, lon, ok := parseCoordinates(text)
+	if !ok {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.bad_coordinates"))
+		return
+	}
+
+	s.handleFenceCenter(ctx, acc, state, lat, lon)
+}
+
+func (s *Service) handleFenceCenter(ctx context.Context, acc *database.Account, state *BotState, lat, lon float64) {
+	state.FenceParams.Latitude = lat
+	state.FenceParams.Longitude = lon
+
+	if state.Stage == "edit_fence_move" {
+		s.saveEditedFence(ctx, acc, state)
+		return
+	}
+
+	state.Stage = "add_fence_radius"
+	s.sendRadiusPresets(ctx, acc)
+}
+
+// parseRadius parses a radius in meters, ParseFloat also accepts NaN and infinities.
+func parseRadius(text string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 || value > maxFenceRadius {
+		return 0, false
+	}
+
+	return value, true
+}
+
+func (s *Service) handleFenceRadiusText(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	value, ok := parseRadius(text)
+	if !ok {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.bad_radius"))
+		return
+	}
+
+	s.handleFenceRadius(ctx, acc, state, value)
+}
+
+func (s *Service) handleFenceRadius(ctx context.Context, acc *database.Account, state *BotState, radius float64) {
+	state.FenceParams.Radius = radius
+
+	if state.Stage == "edit_fence_radius" {
+		s.saveEditedFence(ctx, acc, state)
+		return
+	}
+
+	if _, err := s.queries.CreateFence(ctx, state.FenceParams); err != nil {
+		slog.ErrorContext(ctx, "Failed to create fence",
+			slog.Any("error", err),
+		)
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.create_failed"))
+		return
+	}
+
+	s.sendFence(ctx, acc, state.FenceParams, "fence.created")
+
+	state.Stage = idleStage
+}
+
+func (s *Service) saveEditedFence(ctx context.Context, acc *database.Account, state *BotState) {
+	if _, err := s.queries.UpdateFence(ctx, database.UpdateFenceParams{
+		ID:        state.FenceID,
+		Name:      state.FenceParams.Name,
+		Longitude: state.FenceParams.Longitude,
+		Latitude:  state.FenceParams.Latitude,
+		Radius:    state.FenceParams.Radius,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update fence",
+			slog.Any("error", err),
+		)
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.update_failed"))
+		return
+	}
+
+	s.sendFence(ctx, acc, state.FenceParams, "fence.updated")
+
+	state.Stage = idleStage
+}
+
+func (s *Service) sendFence(ctx context.Context, acc *database.Account, params database.CreateFenceParams, titleKey string) {
+	if _, err := s.tgBot.SendVenue(ctx, &bot.SendVenueParams{
+		ChatID:    *acc.ChatID,
+		Latitude:  params.Latitude,
+		Longitude: params.Longitude,
+		Title:     tr(acc, titleKey, params.Name),
+		Address:   tr(acc, "fence.radius", i18n.Distance(params.Radius)),
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send venue",
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) sendRadiusPresets(ctx context.Context, acc *database.Account) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, radius := range fenceRadiusPresets {
+		callbackBytes, _ := json.Marshal(&FenceRadiusCallbackDTO{
+			Type:   "fence_radius",
+			Radius: radius,
+		})
+
+		buttons = append(buttons, models.InlineKeyboardButton{
+			Text:         i18n.FormatDistance(acc.Lang(), float64(radius)),
+			CallbackData: string(callbackBytes),
+		})
+	}
+
+	s.sendKeyboard(ctx, acc, tr(acc, "fence.pick_radius"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(acc)}})
+}
+
+func (s *Service) editFenceButton(text string, dto EditFenceCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
@@ -0,0 +1,71 @@
+package telegram
+
+import "testing"
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		lat     float64
+		lon     float64
+		wantErr bool
+	}{
+		{name: "comma", text: "55.7558, 37.6173", lat: 55.7558, lon: 37.6173},
+		{name: "semicolon", text: "55.7558;37.6173", lat: 55.7558, lon: 37.6173},
+		{name: "space", text: "  -33.8688 151.2093 ", lat: -33.8688, lon: 151.2093},
+		{name: "integers", text: "10,-20", lat: 10, lon: -20},
+		{name: "bounds", text: "-90, 180", lat: -90, lon: 180},
+		{name: "latitude out of range", text: "91, 0", wantErr: true},
+		{name: "longitude out of range", text: "0, -180.5", wantErr: true},
+		{name: "single number", text: "55.7558", wantErr: true},
+		{name: "three numbers", text: "1, 2, 3", wantErr: true},
+		{name: "text", text: "home", wantErr: true},
+		{name: "empty", text: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, ok := parseCoordinates(tt.text)
+			if ok == tt.wantErr {
+				t.Fatalf("parseCoordinates(%q) ok = %v, want %v", tt.text, ok, !tt.wantErr)
+			}
+
+			if ok && (lat != tt.lat || lon != tt.lon) {
+				t.Errorf("parseCoordinates(%q) = %v, %v, want %v, %v", tt.text, lat, lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestParseRadius(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "integer", text: "150", want: 150},
+		{name: "fraction", text: " 75.5 ", want: 75.5},
+		{name: "upper bound", text: "100000", want: 100000},
+		{name: "zero", text: "0", wantErr: true},
+		{name: "negative", text: "-10", wantErr: true},
+		{name: "too large", text: "100001", wantErr: true},
+		{name: "nan", text: "NaN", wantErr: true},
+		{name: "infinity", text: "Inf", wantErr: true},
+		{name: "negative infinity", text: "-Inf", wantErr: true},
+		{name: "text", text: "big", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRadius(tt.text)
+			if ok == tt.wantErr {
+				t.Fatalf("parseRadius(%q) ok = %v, want %v", tt.text, ok, !tt.wantErr)
+			}
+
+			if ok && got != tt.want {
+				t.Errorf("parseRadius(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/jellydator/ttlcache/v3"
+)
+
+const (
+	historyPageSize = 10
+
+	// the collapsed history is kept for paging, so that every page press doesn't reload the range
+	historyCacheTTL      = 10 * time.Minute
+	historyCacheCapacity = 100
+
+	// stationaryRadius is how far in meters consecutive points may drift and still count as one stop.
+	stationaryRadius = 75
+)
+
+// historyEntry is a single point or a stop made of consecutive points close to the first one.
+type historyEntry struct {
+	First database.Update
+	Last  database.Update
+}
+
+// historyKey identifies the history a chat is paging through.
+type historyKey struct {
+	ChatID    int64
+	AccountID int64
+	Since     int64
+	Hours     int
+}
+
+type history struct {
+	Account database.Account
+	Entries []historyEntry
+}
+
+func newHistoryCache() *ttlcache.Cache[historyKey, *history] {
+	// expired items are skipped by Get and the capacity evicts them, no janitor is needed
+	return ttlcache.New[historyKey, *history](
+		ttlcache.WithTTL[historyKey, *history](historyCacheTTL),
+		ttlcache.WithCapacity[historyKey, *history](historyCacheCapacity),
+		ttlcache.WithDisableTouchOnHit[historyKey, *history](),
+	)
+}
+
+func historyCacheKey(chatID int64, dto HistoryPageCallbackDTO) historyKey {
+	return historyKey{ChatID: chatID, AccountID: dto.AccountID, Since: dto.Since, Hours: dto.Hours}
+}
+
+func (s *Service) handleHistoryCallback(ctx context.Context, acc *database.Account, dto HistoryCallbackDTO, query *models.CallbackQuery) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	ranges := []struct {
+		Key   string
+		Since time.Time
+		Hours int
+	}{
+		{"history.last_hour", now.Add(-time.Hour).Truncate(time.Minute), 1},
+		{"history.today", today, 24},
+		{"history.yesterday", today.AddDate(0, 0, -1), 24},
+		{"history.three_days", today.AddDate(0, 0, -2), 72},
+		{"history.week", today.AddDate(0, 0, -6), 168},
+	}
+
+	var rows [][]models.InlineKeyboardButton
+
+	for _, r := range ranges {
+		rows = append(rows, []models.InlineKeyboardButton{s.historyButton(tr(acc, r.Key), HistoryPageCallbackDTO{
+			Type:      "history_range",
+			AccountID: dto.ID,
+			Since:     r.Since.Unix(),
+			Hours:     r.Hours,
+		})})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(acc)})
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "history.pick_range"), rows)
+}
+
+func (s *Service) handleHistoryRangeCallback(ctx context.Context, acc *database.Account, dto HistoryPageCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	targetAcc, updates, err := s.loadHistory(ctx, dto)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load history",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(updates) == 0 {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "history.empty", targetAcc.Name, formatHistoryRange(dto)))
+		return
+	}
+
+	caption := tr(acc, "history.caption", targetAcc.Name, formatHistoryRange(dto), len(updates))
+
+	if err = s.sendHistoryMap(ctx, *acc.ChatID, updates, caption); err != nil {
+		slog.WarnContext(ctx, "Failed to send history map",
+			slog.Any("error", err),
+		)
+	}
+
+	s.sendVenue(ctx, acc, &targetAcc, updates[len(updates)-1])
+
+	entries := collapseStationary(updates)
+	s.historyCache.Set(historyCacheKey(*acc.ChatID, dto), &history{Account: targetAcc, Entries: entries}, ttlcache.DefaultTTL)
+
+	text, rows := s.historyPage(acc, &targetAcc, dto, entries)
+
+	if _, err = s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    acc.ChatID,
+		Text:      text,
+		ParseMode: "Markdown",
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: util.ToPtr(true),
+		},
+		ReplyMarkup: models.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send message",
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) handleHistoryPageCallback(ctx context.Context, acc *database.Account, dto HistoryPageCallbackDTO, query *models.CallbackQuery) {
+	key := historyCacheKey(*acc.ChatID, dto)
+
+	var cached *history
+
+	if item := s.historyCache.Get(key); item != nil {
+		cached = item.Value()
+	} else {
+		targetAcc, updates, err := s.loadHistory(ctx, dto)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load history",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		cached = &history{Account: targetAcc, Entries: collapseStationary(updates)}
+		s.historyCache.Set(key, cached, ttlcache.DefaultTTL)
+	}
+
+	text, rows := s.historyPage(acc, &cached.Account, dto, cached.Entries)
+
+	if _, err := s.tgBot.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+		Text:      text,
+		ParseMode: "Markdown",
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: util.ToPtr(true),
+		},
+		ReplyMarkup: models.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to edit message",
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) loadHistory(ctx context.Context, dto HistoryPageCallbackDTO) (database.Account, []database.Update, error) {
+	acc, err := s.queries.GetAccount(ctx, dto.AccountID)
+	if err != nil {
+		return database.Account{}, nil, fmt.Errorf("get account: %w", err)
+	}
+
+	since := time.Unix(dto.Since, 0)
+
+	updates, err := s.queries.GetLocationUpdatesByAccountIDBetween(ctx, database.GetLocationUpdatesByAccountIDBetweenParams{
+		AccountID: dto.AccountID,
+		Since:     since,
+		Until:     since.Add(time.Duration(dto.Hours) * time.Hour),
+	})
+	if err != nil {
+		return database.Account{}, nil, fmt.Errorf("get location updates: %w", err)
+	}
+
+	return acc, updates, nil
+}
+
+// historyPage renders the requested page of the target's collapsed history (newest entries first) and its navigation.
+func (s *Service) historyPage(acc, target *database.Account, dto HistoryPageCallbackDTO, entries []historyEntry) (string, [][]models.InlineKeyboardButton) {
+	pages := max((len(entries)+historyPageSize-1)/historyPageSize, 1)
+	page := min(max(dto.Page, 0), pages-1)
+
+	var builder strings.Builder
+
+	builder.WriteString(tr(acc, "history.page", target.Name, formatHistoryRange(dto), page+1, pages) + "\n")
+
+	withDate := dto.Hours > 24
+
+	for i := page * historyPageSize; i < min((page+1)*historyPageSize, len(entries)); i++ {
+		builder.WriteString("\n")
+		builder.WriteString(formatHistoryEntry(acc.Locale(), entries[len(entries)-1-i], withDate))
+	}
+
+	var nav []models.InlineKeyboardButton
+
+	if page < pages-1 {
+		older := dto
+		older.Type = "history_page"
+		older.Page = page + 1
+
+		nav = append(nav, s.historyButton("◀️", older))
+	}
+	if page > 0 {
+		newer := dto
+		newer.Type = "history_page"
+		newer.Page = page - 1
+
+		nav = append(nav, s.historyButton("▶️", newer))
+	}
+
+	rows := [][]models.InlineKeyboardButton{{s.cancelButton(acc)}}
+	if len(nav) > 0 {
+		rows = append([][]models.InlineKeyboardButton{nav}, rows...)
+	}
+
+	return builder.String(), rows
+}
+
+func (s *Service) historyButton(text string, dto HistoryPageCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
+
+// collapseStationary merges runs of location updates (oldest first) that stay within stationaryRadius of the run's first point.
+func collapseStationary(updates []database.Update) []historyEntry {
+	var entries []historyEntry
+
+	for _, update := range updates {
+		loc := update.Data.Location
+		if loc == nil {
+			continue
+		}
+
+		if len(entries) > 0 {
+			last := &entries[len(entries)-1]
+			anchor := last.First.Data.Location
+
+			if util.HaversineDistance(anchor.Latitude, anchor.Longitude, loc.Latitude, loc.Longitude) <= stationaryRadius {
+				last.Last = update
+				continue
+			}
+		}
+
+		entries = append(entries, historyEntry{First: update, Last: update})
+	}
+
+	return entries
+}
+
+func formatHistoryEntry(locale i18n.Locale, entry historyEntry, withDate bool) string {
+	var builder strings.Builder
+
+	loc := entry.First.Data.Location
+	layout := util.Ternary(withDate, "02.01 15:04", "15:04")
+
+	stay := entry.Last.Created.Sub(entry.First.Created)
+
+	if stay >= time.Minute {
+		builder.WriteString(locale.T("history.stop",
+			entry.First.Created.Format(layout), entry.Last.Created.Format("15:04"), i18n.Duration(stay)) + "\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("🕒 %s", entry.First.Created.Format(layout)))
+
+		if loc.Speed != nil && *loc.Speed >= minEtaSpeed {
+			builder.WriteString(", " + locale.T("distance.kmh", util.MpsToKmh(*loc.Speed)))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	mapLink := locale.MapLinks().Point(loc.Latitude, loc.Longitude)
+
+	if loc.Address != nil {
+		builder.WriteString(fmt.Sprintf("📍 [%s](%s)\n", *loc.Address, mapLink))
+	} else {
+		builder.WriteString("📍 " + locale.T("common.on_map", mapLink) + "\n")
+	}
+
+	return builder.String()
+}
+
+func formatHistoryRange(dto HistoryPageCallbackDTO) string {
+	since := time.Unix(dto.Since, 0)
+	until := since.Add(time.Duration(dto.Hours) * time.Hour)
+
+	return fmt.Sprintf("%s – %s", since.Format("02.01 15:04"), until.Format("02.01 15:04"))
+}
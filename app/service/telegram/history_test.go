@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+func locationUpdate(minute int, lat, lon float64) database.Update {
+	return database.Update{
+		Created: time.Date(2026, 1, 1, 12, minute, 0, 0, time.UTC),
+		Data:    api.UpdateData{Location: &api.LocationData{Latitude: lat, Longitude: lon}},
+	}
+}
+
+func TestCollapseStationary(t *testing.T) {
+	// 0.0005° of latitude is about 55 m, 0.001° about 111 m
+	tests := []struct {
+		name    string
+		updates []database.Update
+		// want holds the minutes of the first and the last update of every entry
+		want [][2]int
+	}{
+		{name: "empty"},
+		{name: "single", updates: []database.Update{locationUpdate(0, 55, 37)}, want: [][2]int{{0, 0}}},
+		{
+			name:    "stop",
+			updates: []database.Update{locationUpdate(0, 55, 37), locationUpdate(5, 55.0005, 37), locationUpdate(10, 55, 37)},
+			want:    [][2]int{{0, 10}},
+		},
+		{
+			name:    "moving",
+			updates: []database.Update{locationUpdate(0, 55, 37), locationUpdate(1, 55.001, 37), locationUpdate(2, 55.002, 37)},
+			want:    [][2]int{{0, 0}, {1, 1}, {2, 2}},
+		},
+		{
+			// the drift is measured from the first point of the stop, not the previous one
+			name:    "slow drift",
+			updates: []database.Update{locationUpdate(0, 55, 37), locationUpdate(1, 55.0005, 37), locationUpdate(2, 55.001, 37)},
+			want:    [][2]int{{0, 1}, {2, 2}},
+		},
+		{
+			name:    "stop between moves",
+			updates: []database.Update{locationUpdate(0, 55, 37), locationUpdate(1, 55.01, 37), locationUpdate(30, 55.01, 37), locationUpdate(31, 55.02, 37)},
+			want:    [][2]int{{0, 0}, {1, 30}, {31, 31}},
+		},
+		{
+			name:    "updates without location are skipped",
+			updates: []database.Update{locationUpdate(0, 55, 37), {Created: time.Now()}, locationUpdate(2, 55, 37)},
+			want:    [][2]int{{0, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := collapseStationary(tt.updates)
+
+			if len(entries) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d", len(entries), len(tt.want))
+			}
+
+			for i, entry := range entries {
+				if got := [2]int{entry.First.Created.Minute(), entry.Last.Created.Minute()}; got != tt.want[i] {
+					t.Errorf("entry %d spans minutes %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHistoryPage(t *testing.T) {
+	entries := func(n int) []historyEntry {
+		var result []historyEntry
+
+		for i := range n {
+			update := locationUpdate(i, 55+float64(i), 37)
+			result = append(result, historyEntry{First: update, Last: update})
+		}
+
+		return result
+	}
+
+	acc := &database.Account{Settings: api.AccountSettings{Language: util.ToPtr(api.LanguageEn)}}
+	target := &database.Account{Name: "Alice"}
+
+	tests := []struct {
+		name      string
+		entries   int
+		page      int
+		wantTitle string
+		wantFirst string
+		wantNav   []string
+	}{
+		{name: "empty", entries: 0, wantTitle: "(page 1/1)"},
+		{name: "single page", entries: 3, wantTitle: "(page 1/1)", wantFirst: "12:02"},
+		{name: "newest first", entries: 25, wantTitle: "(page 1/3)", wantFirst: "12:24", wantNav: []string{"◀️"}},
+		{name: "middle", entries: 25, page: 1, wantTitle: "(page 2/3)", wantFirst: "12:14", wantNav: []string{"◀️", "▶️"}},
+		{name: "last", entries: 25, page: 2, wantTitle: "(page 3/3)", wantFirst: "12:04", wantNav: []string{"▶️"}},
+		{name: "clamped", entries: 25, page: 7, wantTitle: "(page 3/3)", wantFirst: "12:04", wantNav: []string{"▶️"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := HistoryPageCallbackDTO{AccountID: 1, Since: 0, Hours: 1, Page: tt.page}
+
+			text, rows := (&Service{}).historyPage(acc, target, dto, entries(tt.entries))
+
+			if !strings.Contains(text, tt.wantTitle) {
+				t.Errorf("text %q doesn't contain %q", text, tt.wantTitle)
+			}
+
+			if tt.wantFirst != "" {
+				if lines := strings.Split(text, "\n"); len(lines) < 3 || !strings.Contains(lines[2], tt.wantFirst) {
+					t.Errorf("first entry of %q isn't at %s", text, tt.wantFirst)
+				}
+			}
+
+			var nav []string
+			if len(rows) > 1 {
+				for _, button := range rows[0] {
+					nav = append(nav, button.Text)
+				}
+			}
+
+			if strings.Join(nav, " ") != strings.Join(tt.wantNav, " ") {
+				t.Errorf("navigation = %v, want %v", nav, tt.wantNav)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// inlineCacheTTL is how long the rendered results of a member are reused, both here and by Telegram
+	inlineCacheTTL = 15 * time.Second
+
+	// inlineMaxResults is the Telegram limit of results per answer
+	inlineMaxResults = 50
+)
+
+type inlineResult struct {
+	Name  string
+	Venue *models.InlineQueryResultVenue
+}
+
+// handleInlineQuery answers "@bot name" with the last locations of the matching members.
+// Inline mode has to be enabled for the bot with BotFather.
+func (s *Service) handleInlineQuery(ctx context.Context, query *models.InlineQuery) {
+	var results []models.InlineQueryResult
+
+	// the private chat with a user has the same ID as the user
+	acc, err := s.queries.GetAccountByChatID(ctx, &query.From.ID)
+	if err == nil {
+		candidates, err := s.inlineResults(ctx, &acc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to build inline results",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		needle := strings.ToLower(strings.TrimSpace(query.Query))
+
+		for _, candidate := range candidates {
+			if len(results) == inlineMaxResults {
+				break
+			}
+
+			if strings.Contains(strings.ToLower(candidate.Name), needle) {
+				results = append(results, candidate.Venue)
+			}
+		}
+	}
+
+	if _, err = s.tgBot.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     int(inlineCacheTTL.Seconds()),
+		IsPersonal:    true,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to answer inline query",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// inlineResults renders the last location of every member visible to acc, cached for inlineCacheTTL.
+func (s *Service) inlineResults(ctx context.Context, acc *database.Account) ([]inlineResult, error) {
+	if item := s.inlineCache.Get(acc.ID); item != nil {
+		return item.Value(), nil
+	}
+
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get all accounts: %w", err)
+	}
+
+	var myLocation *database.Update
+
+	myUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get last location update: %w", err)
+	}
+	if len(myUpdates) > 0 {
+		myLocation = &myUpdates[0]
+	}
+
+	var results []inlineResult
+
+	for _, target := range accounts {
+		if target.ID == acc.ID || !util.GetPtrOrDefault(target.Settings.InlineVisible, false) {
+			continue
+		}
+
+		updates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, target.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get last location update: %w", err)
+		}
+		if len(updates) == 0 {
+			continue
+		}
+
+		results = append(results, inlineResult{
+			Name:  target.Name,
+			Venue: inlineVenue(acc, &target, updates[0], myLocation),
+		})
+	}
+
+	s.inlineCache.Set(acc.ID, results, inlineCacheTTL)
+
+	return results, nil
+}
+
+func inlineVenue(acc, target *database.Account, update database.Update, myLocation *database.Update) *models.InlineQueryResultVenue {
+	loc := update.Data.Location
+
+	title := tr(acc, "venue.title", target.Name, i18n.Ago(update.Created), loc.Accuracy)
+	if myLocation != nil {
+		myLoc := myLocation.Data.Location
+		distance := util.HaversineDistance(myLoc.Latitude, myLoc.Longitude, loc.Latitude, loc.Longitude)
+
+		title = tr(acc, "inline.title", target.Name, i18n.Distance(distance), i18n.Ago(update.Created))
+	}
+
+	address := fmt.Sprintf("%.5f, %.5f", loc.Latitude, loc.Longitude)
+	if loc.Address != nil {
+		address = *loc.Address
+	}
+
+	return &models.InlineQueryResultVenue{
+		ID:        strconv.FormatInt(target.ID, 10),
+		Latitude:  loc.Latitude,
+		Longitude: loc.Longitude,
+		Title:     title,
+		Address:   address,
+	}
+}
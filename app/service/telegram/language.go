@@ -0,0 +1,62 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+func (s *Service) handleLanguage(ctx context.Context, selfAcc *database.Account) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, lang := range i18n.Langs {
+		callbackBytes, _ := json.Marshal(&LanguageCallbackDTO{
+			Type:     "language",
+			Language: string(lang),
+		})
+
+		buttons = append(buttons, models.InlineKeyboardButton{
+			Text:         i18n.T(lang, "language.name"),
+			CallbackData: string(callbackBytes),
+		})
+	}
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "language.pick"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(selfAcc)}})
+}
+
+func (s *Service) handleLanguageCallback(ctx context.Context, acc *database.Account, dto LanguageCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if i18n.Parse(dto.Language) != i18n.Lang(dto.Language) {
+		return
+	}
+
+	updated, err := s.settingsService.UpdateAccountSettings(ctx, acc.ID, func(settings *api.AccountSettings) error {
+		settings.Language = (*api.Language)(&dto.Language)
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update account settings",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	acc.Settings = updated.Settings
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "language.picked"))
+}
@@ -0,0 +1,371 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const maxHorizontalAccuracy = 1500
+
+// followPeriods are in minutes, Telegram allows live locations for up to a day
+var followPeriods = []int{60, 8 * 60, 24 * 60}
+
+// sendVenue sends the account's update to the reader as a native venue, so that it opens in the user's map app.
+func (s *Service) sendVenue(ctx context.Context, reader *database.Account, acc *database.Account, update database.Update) {
+	loc := update.Data.Location
+	if loc == nil {
+		return
+	}
+
+	address := fmt.Sprintf("%.5f, %.5f", loc.Latitude, loc.Longitude)
+	if loc.Address != nil {
+		address = *loc.Address
+	}
+
+	if _, err := s.tgBot.SendVenue(ctx, &bot.SendVenueParams{
+		ChatID:              *reader.ChatID,
+		Latitude:            loc.Latitude,
+		Longitude:           loc.Longitude,
+		Title:               tr(reader, "venue.title", acc.Name, i18n.Ago(update.Created), loc.Accuracy),
+		Address:             address,
+		DisableNotification: true,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send venue",
+			slog.Int64("chat_id", *reader.ChatID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) handleFollow(ctx context.Context, selfAcc *database.Account) {
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all accounts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var buttons []models.InlineKeyboardButton
+
+	for _, acc := range accounts {
+		if acc.ID == selfAcc.ID {
+			continue
+		}
+
+		buttons = append(buttons, s.followButton(acc.Name, FollowCallbackDTO{
+			Type:      "follow_acc",
+			AccountID: acc.ID,
+		}))
+	}
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "follow.pick_account"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(selfAcc)}})
+}
+
+func (s *Service) handleUnfollow(ctx context.Context, selfAcc *database.Account) {
+	liveLocations, err := s.queries.GetActiveLiveLocationsByChatID(ctx, database.GetActiveLiveLocationsByChatIDParams{
+		ChatID:  *selfAcc.ChatID,
+		Expires: time.Now(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get live locations",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(liveLocations) == 0 {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "follow.none"))
+		return
+	}
+
+	var lines []string
+	var rows [][]models.InlineKeyboardButton
+
+	for i, liveLocation := range liveLocations {
+		acc, err := s.queries.GetAccount(ctx, liveLocation.AccountID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get account",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		lines = append(lines, tr(selfAcc, "common.item_left", i+1, acc.Name, i18n.Duration(time.Until(liveLocation.Expires))))
+
+		callbackBytes, _ := json.Marshal(&UnfollowCallbackDTO{
+			Type: "unfollow",
+			ID:   liveLocation.ID,
+		})
+
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         tr(selfAcc, "follow.stop_button", i+1),
+			CallbackData: string(callbackBytes),
+		}})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, strings.Join(lines, "\n"), rows)
+}
+
+func (s *Service) handleFollowAccountCallback(ctx context.Context, acc *database.Account, dto FollowCallbackDTO, query *models.CallbackQuery) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, period := range followPeriods {
+		buttons = append(buttons, s.followButton(i18n.FormatDuration(acc.Lang(), time.Duration(period)*time.Minute), FollowCallbackDTO{
+			Type:      "follow_set",
+			AccountID: dto.AccountID,
+			Period:    period,
+		}))
+	}
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "follow.pick_period"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(acc)}})
+}
+
+func (s *Service) handleFollowSetCallback(ctx context.Context, acc *database.Account, dto FollowCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	targetAcc, err := s.queries.GetAccount(ctx, dto.AccountID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get account",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	lastUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, targetAcc.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last location update",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(lastUpdates) == 0 {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "follow.unknown_location", targetAcc.Name))
+		return
+	}
+
+	loc := lastUpdates[0].Data.Location
+	period := time.Duration(dto.Period) * time.Minute
+
+	msg, err := s.tgBot.SendLocation(ctx, &bot.SendLocationParams{
+		ChatID:             *acc.ChatID,
+		Latitude:           loc.Latitude,
+		Longitude:          loc.Longitude,
+		HorizontalAccuracy: min(loc.Accuracy, maxHorizontalAccuracy),
+		LivePeriod:         int(period.Seconds()),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send live location",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	now := time.Now()
+
+	if _, err = s.queries.CreateLiveLocation(ctx, database.CreateLiveLocationParams{
+		ChatID:    *acc.ChatID,
+		AccountID: targetAcc.ID,
+		MessageID: int64(msg.ID),
+		Created:   now,
+		Expires:   now.Add(period),
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to create live location",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "follow.started", targetAcc.Name, i18n.Duration(period)))
+}
+
+func (s *Service) handleUnfollowCallback(ctx context.Context, acc *database.Account, dto UnfollowCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	liveLocation, err := s.queries.GetLiveLocation(ctx, dto.ID)
+	if err != nil || liveLocation.ChatID != *acc.ChatID {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "follow.already_stopped"))
+		return
+	}
+
+	if _, err = s.tgBot.StopMessageLiveLocation(ctx, &bot.StopMessageLiveLocationParams{
+		ChatID:    liveLocation.ChatID,
+		MessageID: int(liveLocation.MessageID),
+	}); err != nil {
+		slog.WarnContext(ctx, "Failed to stop live location",
+			slog.Any("error", err),
+		)
+	}
+
+	if err = s.queries.DeleteLiveLocation(ctx, liveLocation.ID); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete live location",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "follow.stopped"))
+}
+
+const (
+	liveLocationEditTimeout     = 30 * time.Second
+	liveLocationCleanupInterval = 5 * time.Minute
+)
+
+// liveLocationQueue keeps the latest location of every account whose live location messages
+// are due an edit, so that a burst of updates results in a single edit.
+type liveLocationQueue struct {
+	m       sync.Mutex
+	pending map[int64]*api.LocationData
+	ready   chan struct{}
+}
+
+func (q *liveLocationQueue) init() {
+	if q.pending == nil {
+		q.pending = map[int64]*api.LocationData{}
+		q.ready = make(chan struct{}, 1)
+	}
+}
+
+func (q *liveLocationQueue) push(accountID int64, loc *api.LocationData) {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	q.init()
+	q.pending[accountID] = loc
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// wait returns a channel that receives once there are pending locations.
+func (q *liveLocationQueue) wait() <-chan struct{} {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	q.init()
+
+	return q.ready
+}
+
+func (q *liveLocationQueue) take() map[int64]*api.LocationData {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	pending := q.pending
+	q.pending = map[int64]*api.LocationData{}
+
+	return pending
+}
+
+// UpdateLiveLocations queues moving the live location messages that follow the account
+// to the new location, the edits are made by RunLiveLocations.
+func (s *Service) UpdateLiveLocations(accountID int64, loc *api.LocationData) {
+	s.liveLocations.push(accountID, loc)
+}
+
+// RunLiveLocations edits the live location messages queued by UpdateLiveLocations
+// and periodically forgets the expired ones.
+func (s *Service) RunLiveLocations(ctx context.Context) {
+	ticker := time.NewTicker(liveLocationCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.queries.DeleteExpiredLiveLocations(ctx, time.Now()); err != nil {
+				slog.ErrorContext(ctx, "Failed to delete expired live locations",
+					slog.Any("error", err),
+				)
+			}
+		case <-s.liveLocations.wait():
+			for accountID, loc := range s.liveLocations.take() {
+				editCtx, cancel := context.WithTimeout(ctx, liveLocationEditTimeout)
+				s.editLiveLocations(editCtx, accountID, loc)
+				cancel()
+			}
+		}
+	}
+}
+
+// editLiveLocations moves the live location messages that follow the account to the new location.
+// Messages that can no longer be edited, e.g. stopped by the user, are forgotten.
+func (s *Service) editLiveLocations(ctx context.Context, accountID int64, loc *api.LocationData) {
+	liveLocations, err := s.queries.GetActiveLiveLocationsByAccountID(ctx, database.GetActiveLiveLocationsByAccountIDParams{
+		AccountID: accountID,
+		Expires:   time.Now(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get live locations",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, liveLocation := range liveLocations {
+		_, err = s.tgBot.EditMessageLiveLocation(ctx, &bot.EditMessageLiveLocationParams{
+			ChatID:             liveLocation.ChatID,
+			MessageID:          int(liveLocation.MessageID),
+			Latitude:           loc.Latitude,
+			Longitude:          loc.Longitude,
+			HorizontalAccuracy: min(loc.Accuracy, maxHorizontalAccuracy),
+		})
+		if err == nil || strings.Contains(err.Error(), "message is not modified") {
+			continue
+		}
+
+		slog.WarnContext(ctx, "Failed to edit live location, forgetting it",
+			slog.Int64("chat_id", liveLocation.ChatID),
+			slog.Any("error", err),
+		)
+
+		if err = s.queries.DeleteLiveLocation(ctx, liveLocation.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to delete live location",
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func (s *Service) followButton(text string, dto FollowCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"roflbeacon2/app/api"
+	"testing"
+)
+
+func TestLiveLocationQueue(t *testing.T) {
+	loc := func(lat float64) *api.LocationData {
+		return &api.LocationData{Latitude: lat}
+	}
+
+	type push struct {
+		accountID int64
+		loc       *api.LocationData
+	}
+
+	tests := []struct {
+		name   string
+		pushes []push
+		want   map[int64]float64
+	}{
+		{name: "empty", want: map[int64]float64{}},
+		{name: "single", pushes: []push{{1, loc(1)}}, want: map[int64]float64{1: 1}},
+		{name: "latest wins", pushes: []push{{1, loc(1)}, {1, loc(2)}, {1, loc(3)}}, want: map[int64]float64{1: 3}},
+		{name: "per account", pushes: []push{{1, loc(1)}, {2, loc(5)}, {1, loc(2)}}, want: map[int64]float64{1: 2, 2: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q liveLocationQueue
+
+			for _, p := range tt.pushes {
+				q.push(p.accountID, p.loc)
+			}
+
+			select {
+			case <-q.wait():
+				if len(tt.pushes) == 0 {
+					t.Error("ready without pushes")
+				}
+			default:
+				if len(tt.pushes) > 0 {
+					t.Error("not ready after pushes")
+				}
+			}
+
+			got := q.take()
+			if len(got) != len(tt.want) {
+				t.Fatalf("took %d accounts, want %d", len(got), len(tt.want))
+			}
+
+			for accountID, lat := range tt.want {
+				if got[accountID] == nil || got[accountID].Latitude != lat {
+					t.Errorf("account %d: got %v, want latitude %v", accountID, got[accountID], lat)
+				}
+			}
+
+			if left := q.take(); len(left) != 0 {
+				t.Errorf("%d accounts left after take", len(left))
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/mapimage"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// memberColors pairs marker colors with emojis, so that the caption can serve as the legend.
+var memberColors = []struct {
+	Emoji string
+	Color color.NRGBA
+}{
+	{"🔴", color.NRGBA{R: 0xe5, G: 0x39, B: 0x35, A: 0xff}},
+	{"🔵", color.NRGBA{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff}},
+	{"🟢", color.NRGBA{R: 0x43, G: 0xa0, B: 0x47, A: 0xff}},
+	{"🟠", color.NRGBA{R: 0xfb, G: 0x8c, B: 0x00, A: 0xff}},
+	{"🟣", color.NRGBA{R: 0x8e, G: 0x24, B: 0xaa, A: 0xff}},
+	{"🟡", color.NRGBA{R: 0xfd, G: 0xd8, B: 0x35, A: 0xff}},
+	{"🟤", color.NRGBA{R: 0x6d, G: 0x4c, B: 0x41, A: 0xff}},
+	{"⚫", color.NRGBA{R: 0x21, G: 0x21, B: 0x21, A: 0xff}},
+}
+
+var fenceColor = color.NRGBA{R: 0x00, G: 0x89, B: 0x7b, A: 0xff}
+
+func (s *Service) handleMap(ctx context.Context, selfAcc *database.Account) {
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all accounts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	scene, err := s.newScene(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create map scene",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var legend []string
+
+	for i, acc := range accounts {
+		lastUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, acc.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get last location update",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		if len(lastUpdates) == 0 {
+			continue
+		}
+
+		memberColor := memberColors[i%len(memberColors)]
+		loc := lastUpdates[0].Data.Location
+
+		scene.Markers = append(scene.Markers, mapimage.Marker{
+			Point: mapimage.Point{Latitude: loc.Latitude, Longitude: loc.Longitude},
+			Color: memberColor.Color,
+		})
+
+		legend = append(legend, fmt.Sprintf("%s %s (%s)", memberColor.Emoji, acc.Name, i18n.TimeAgo(selfAcc.Lang(), lastUpdates[0].Created)))
+	}
+
+	if len(scene.Markers) == 0 {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "map.empty"))
+		return
+	}
+
+	if err = s.sendMap(ctx, *selfAcc.ChatID, scene, strings.Join(legend, "\n")); err != nil {
+		slog.ErrorContext(ctx, "Failed to send map",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// sendHistoryMap sends the track of the updates (oldest first) with the latest position marked.
+func (s *Service) sendHistoryMap(ctx context.Context, chatID int64, updates []database.Update, caption string) error {
+	scene, err := s.newScene(ctx)
+	if err != nil {
+		return err
+	}
+
+	var track mapimage.Track
+	track.Color = memberColors[0].Color
+
+	for _, update := range updates {
+		loc := update.Data.Location
+		if loc == nil {
+			continue
+		}
+
+		track.Points = append(track.Points, mapimage.Point{Latitude: loc.Latitude, Longitude: loc.Longitude})
+	}
+
+	if len(track.Points) == 0 {
+		return fmt.Errorf("no locations")
+	}
+
+	scene.Tracks = append(scene.Tracks, track)
+	scene.Markers = append(scene.Markers, mapimage.Marker{
+		Point: track.Points[len(track.Points)-1],
+		Color: memberColors[1].Color,
+	})
+
+	return s.sendMap(ctx, chatID, scene, caption)
+}
+
+// newScene creates a scene of the configured size with all fences on it.
+func (s *Service) newScene(ctx context.Context) (mapimage.Scene, error) {
+	fences, err := s.queries.GetAllFences(ctx)
+	if err != nil {
+		return mapimage.Scene{}, fmt.Errorf("get all fences: %w", err)
+	}
+
+	scene := mapimage.Scene{
+		Width:  s.cfg.Map.Width,
+		Height: s.cfg.Map.Height,
+	}
+
+	for _, fence := range fences {
+		scene.Circles = append(scene.Circles, mapimage.Circle{
+			Center: mapimage.Point{Latitude: fence.Latitude, Longitude: fence.Longitude},
+			Radius: fence.Radius,
+			Color:  fenceColor,
+		})
+	}
+
+	return scene, nil
+}
+
+func (s *Service) sendMap(ctx context.Context, chatID int64, scene mapimage.Scene, caption string) error {
+	image, err := s.mapRenderer.Render(ctx, scene)
+	if err != nil {
+		return fmt.Errorf("render map: %w", err)
+	}
+
+	if _, err = s.tgBot.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID: chatID,
+		Photo: &models.InputFileUpload{
+			Filename: "map.png",
+			Data:     bytes.NewReader(image),
+		},
+		Caption:   caption,
+		ParseMode: "Markdown",
+	}); err != nil {
+		return fmt.Errorf("send photo: %w", err)
+	}
+
+	return nil
+}
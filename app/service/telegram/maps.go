@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/maplink"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+func (s *Service) handleMaps(ctx context.Context, selfAcc *database.Account) {
+	var rows [][]models.InlineKeyboardButton
+
+	for _, name := range maplink.Names {
+		callbackBytes, _ := json.Marshal(&MapsCallbackDTO{
+			Type:     "maps",
+			Provider: name,
+		})
+
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         tr(selfAcc, "maps."+name),
+			CallbackData: string(callbackBytes),
+		}})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "maps.pick"), rows)
+}
+
+func (s *Service) handleMapsCallback(ctx context.Context, acc *database.Account, dto MapsCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if !maplink.Valid(dto.Provider) {
+		return
+	}
+
+	updated, err := s.settingsService.UpdateAccountSettings(ctx, acc.ID, func(settings *api.AccountSettings) error {
+		settings.MapProvider = (*api.MapProvider)(&dto.Provider)
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update account settings",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	acc.Settings = updated.Settings
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "maps.picked", tr(acc, "maps."+dto.Provider)))
+}
@@ -0,0 +1,62 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"roflbeacon2/pkg/metrics"
+	"strconv"
+	"time"
+)
+
+// pollTimeout is the library default, getUpdates holds the request for up to that long.
+const pollTimeout = time.Minute
+
+// metricsClient counts the Bot API calls by method and result.
+type metricsClient struct {
+	client *http.Client
+}
+
+func (c *metricsClient) Do(req *http.Request) (*http.Response, error) {
+	// the path is /bot<token>/<method>, only the method is recorded
+	method := path.Base(req.URL.Path)
+
+	resp, err := c.client.Do(req)
+
+	metrics.TelegramRequests.WithLabelValues(method, requestResult(resp, err)).Inc()
+
+	return resp, err //nolint:wrapcheck
+}
+
+// requestResult names the error type, the Bot API mirrors its error codes in the HTTP status.
+func requestResult(resp *http.Response, err error) string {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return "canceled"
+		}
+
+		return "network"
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusOK:
+		return "ok"
+	case code == http.StatusBadRequest:
+		return "bad_request"
+	case code == http.StatusUnauthorized:
+		return "unauthorized"
+	case code == http.StatusForbidden:
+		return "forbidden"
+	case code == http.StatusNotFound:
+		return "not_found"
+	case code == http.StatusConflict:
+		return "conflict"
+	case code == http.StatusTooManyRequests:
+		return "too_many_requests"
+	case code >= http.StatusInternalServerError:
+		return "server_error"
+	default:
+		return "http_" + strconv.Itoa(code)
+	}
+}
@@ -0,0 +1,43 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRequestResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   string
+	}{
+		{name: "ok", status: http.StatusOK, want: "ok"},
+		{name: "bad request", status: http.StatusBadRequest, want: "bad_request"},
+		{name: "unauthorized", status: http.StatusUnauthorized, want: "unauthorized"},
+		{name: "forbidden", status: http.StatusForbidden, want: "forbidden"},
+		{name: "not found", status: http.StatusNotFound, want: "not_found"},
+		{name: "conflict", status: http.StatusConflict, want: "conflict"},
+		{name: "flood", status: http.StatusTooManyRequests, want: "too_many_requests"},
+		{name: "server error", status: http.StatusBadGateway, want: "server_error"},
+		{name: "other status", status: http.StatusRequestEntityTooLarge, want: "http_413"},
+		{name: "canceled", err: fmt.Errorf("post: %w", context.Canceled), want: "canceled"},
+		{name: "network", err: errors.New("connection refused"), want: "network"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+
+			if got := requestResult(resp, tt.err); got != tt.want {
+				t.Errorf("requestResult() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
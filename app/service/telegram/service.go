@@ -4,38 +4,66 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/share"
 	"roflbeacon2/pkg/config"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/mapimage"
 	"roflbeacon2/pkg/util"
-	"sync"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"github.com/jellydator/ttlcache/v3"
 	"github.com/samber/do"
 )
 
 type Service struct {
-	tgBot   *bot.Bot
-	cfg     *config.Config
-	queries *database.Queries
+	tgBot           *bot.Bot
+	cfg             *config.Config
+	queries         *database.Queries
+	shareService    *share.Service
+	settingsService *settings.Service
+	mapRenderer     *mapimage.Renderer
 
-	m     sync.Mutex
-	state BotState
+	stages        map[string]stageHandler
+	chatLocks     chatLocks
+	inlineCache   *ttlcache.Cache[int64, []inlineResult]
+	historyCache  *ttlcache.Cache[historyKey, *history]
+	liveLocations liveLocationQueue
 }
 
 func New(di *do.Injector) (*Service, error) {
 	cfg := do.MustInvoke[*config.Config](di)
 
 	service := &Service{
-		cfg:     cfg,
-		queries: do.MustInvoke[*database.Queries](di),
-		state: BotState{
-			Stage: "idle",
-		},
+		cfg:             cfg,
+		queries:         do.MustInvoke[*database.Queries](di),
+		shareService:    do.MustInvoke[*share.Service](di),
+		settingsService: do.MustInvoke[*settings.Service](di),
+		inlineCache:     ttlcache.New[int64, []inlineResult](),
+		historyCache:    newHistoryCache(),
+	}
+
+	go service.inlineCache.Start()
+
+	service.registerStages()
+
+	var tiles mapimage.TileSource
+	if !cfg.Map.DisableTiles {
+		tiles = mapimage.NewHTTPTileSource(cfg.Map.TileURL, fmt.Sprintf("roflbeacon2 (%s)", cfg.BaseApiURL), cfg.Map.TileTimeout)
 	}
 
+	service.mapRenderer = mapimage.New(tiles)
+
 	opts := []bot.Option{
 		bot.WithDefaultHandler(service.handleUpdates),
+		bot.WithHTTPClient(pollTimeout, &metricsClient{client: &http.Client{Timeout: pollTimeout}}),
+	}
+
+	if cfg.Telegram.ServerURL != "" {
+		opts = append(opts, bot.WithServerURL(cfg.Telegram.ServerURL))
 	}
 
 	b, err := bot.New(cfg.Telegram.Token, opts...)
@@ -48,7 +76,8 @@ func New(di *do.Injector) (*Service, error) {
 	return service, nil
 }
 
-func (s *Service) SendMessage(ctx context.Context, chatID int64, text string) {
+// Send delivers a Markdown message to the chat and reports the failure to the caller.
+func (s *Service) Send(ctx context.Context, chatID int64, text string) error {
 	if _, err := s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:    chatID,
 		Text:      text,
@@ -57,6 +86,14 @@ func (s *Service) SendMessage(ctx context.Context, chatID int64, text string) {
 			IsDisabled: util.ToPtr(true),
 		},
 	}); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) SendMessage(ctx context.Context, chatID int64, text string) {
+	if err := s.Send(ctx, chatID, text); err != nil {
 		slog.ErrorContext(ctx, "Failed to send message",
 			slog.Int64("chat_id", chatID),
 			slog.String("text", text),
@@ -65,40 +102,93 @@ func (s *Service) SendMessage(ctx context.Context, chatID int64, text string) {
 	}
 }
 
+var commands = []string{
+	"list",
+	"map",
+	"follow",
+	"unfollow",
+	"history",
+	"watch",
+	"watches",
+	"share",
+	"shares",
+	"language",
+	"maps",
+	"thresholds",
+	"addfence",
+	"editfence",
+	"deletefence",
+	"cancel",
+}
+
+// initCommands registers the command descriptions of every language, the default one is
+// shown to users whose Telegram language has no translation.
 func (s *Service) initCommands(ctx context.Context) {
-	cmds := []models.BotCommand{
-		{
-			Command:     "/list",
-			Description: "Показать всех",
-		},
-		{
-			Command:     "/history",
-			Description: "История",
-		},
-		{
-			Command:     "/addfence",
-			Description: "Добавить ограду",
-		},
-		{
-			Command:     "/deletefence",
-			Description: "Удалить ограду",
-		},
-		{
-			Command:     "/cancel",
-			Description: "Отменить текущее действие",
-		},
-	}
+	for _, lang := range i18n.Langs {
+		var cmds []models.BotCommand
 
-	if _, err := s.tgBot.SetMyCommands(ctx, &bot.SetMyCommandsParams{
-		Commands: cmds,
-	}); err != nil {
-		slog.ErrorContext(ctx, "Failed to set commands",
-			slog.Any("error", err),
-		)
+		for _, command := range commands {
+			cmds = append(cmds, models.BotCommand{
+				Command:     "/" + command,
+				Description: i18n.T(lang, "command."+command),
+			})
+		}
+
+		params := &bot.SetMyCommandsParams{
+			Commands: cmds,
+		}
+		if lang != i18n.Default {
+			params.LanguageCode = string(lang)
+		}
+
+		if _, err := s.tgBot.SetMyCommands(ctx, params); err != nil {
+			slog.ErrorContext(ctx, "Failed to set commands",
+				slog.String("language", string(lang)),
+				slog.Any("error", err),
+			)
+		}
 	}
 }
 
+// Run registers the commands and starts receiving updates. In webhook mode it only registers the webhook,
+// the updates are then delivered through HandleUpdate.
 func (s *Service) Run(ctx context.Context) {
 	s.initCommands(ctx)
+
+	if s.cfg.Telegram.Mode == config.TelegramModeWebhook {
+		if _, err := s.tgBot.SetWebhook(ctx, &bot.SetWebhookParams{
+			URL:         s.cfg.Telegram.WebhookURL,
+			SecretToken: s.cfg.Telegram.WebhookSecret,
+		}); err != nil {
+			slog.ErrorContext(ctx, "Failed to set webhook",
+				slog.String("url", s.cfg.Telegram.WebhookURL),
+				slog.Any("error", err),
+			)
+		}
+
+		return
+	}
+
+	// getUpdates is rejected while a webhook is set, e.g. after switching back from webhook mode
+	if _, err := s.tgBot.DeleteWebhook(ctx, &bot.DeleteWebhookParams{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete webhook",
+			slog.Any("error", err),
+		)
+	}
+
 	s.tgBot.Start(ctx)
 }
+
+// Ping checks that the Bot API is reachable and accepts the token.
+func (s *Service) Ping(ctx context.Context) error {
+	if _, err := s.tgBot.GetMe(ctx); err != nil {
+		return fmt.Errorf("get me: %w", err)
+	}
+
+	return nil
+}
+
+// HandleUpdate processes an update received by the webhook asynchronously.
+func (s *Service) HandleUpdate(ctx context.Context, update *models.Update) {
+	s.tgBot.ProcessUpdate(ctx, update)
+}
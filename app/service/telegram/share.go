@@ -0,0 +1,171 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"roflbeacon2/app/service/share"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// shareTTLs are in minutes
+var shareTTLs = []int{60, 8 * 60, 24 * 60, 7 * 24 * 60}
+
+// sharePrecisions are in meters, 0 means exact
+var sharePrecisions = []int{0, 500, 2000}
+
+func (s *Service) handleShare(ctx context.Context, selfAcc *database.Account) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, ttl := range shareTTLs {
+		buttons = append(buttons, s.shareButton(i18n.FormatDuration(selfAcc.Lang(), time.Duration(ttl)*time.Minute), ShareCallbackDTO{
+			Type: "share_ttl",
+			TTL:  ttl,
+		}))
+	}
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "share.pick_ttl"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(selfAcc)}})
+}
+
+func (s *Service) handleShares(ctx context.Context, selfAcc *database.Account) {
+	links, err := s.shareService.ListActive(ctx, selfAcc)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get share links",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(links) == 0 {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "share.none"))
+		return
+	}
+
+	var lines []string
+	var rows [][]models.InlineKeyboardButton
+
+	for i, link := range links {
+		lines = append(lines, tr(selfAcc, "share.item", i+1, describeShareScope(link.Scope), describeSharePrecision(int(link.Precision)),
+			i18n.Duration(time.Until(link.Expires)), i18n.P("share.opened", int(link.AccessCount))))
+
+		callbackBytes, _ := json.Marshal(&RevokeShareCallbackDTO{
+			Type: "revoke_share",
+			ID:   link.ID,
+		})
+
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         tr(selfAcc, "share.revoke_button", i+1),
+			CallbackData: string(callbackBytes),
+		}})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, strings.Join(lines, "\n"), rows)
+}
+
+func (s *Service) handleShareTTLCallback(ctx context.Context, acc *database.Account, dto ShareCallbackDTO, query *models.CallbackQuery) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, precision := range sharePrecisions {
+		buttons = append(buttons, s.shareButton(describeSharePrecision(precision).Localize(acc.Locale()), ShareCallbackDTO{
+			Type:      "share_prec",
+			TTL:       dto.TTL,
+			Precision: precision,
+		}))
+	}
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "share.pick_precision"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(acc)}})
+}
+
+func (s *Service) handleSharePrecisionCallback(ctx context.Context, acc *database.Account, dto ShareCallbackDTO, query *models.CallbackQuery) {
+	var buttons []models.InlineKeyboardButton
+
+	for _, scope := range []string{share.ScopeLive, share.ScopeToday} {
+		buttons = append(buttons, s.shareButton(describeShareScope(scope).Localize(acc.Locale()), ShareCallbackDTO{
+			Type:      "share_scope",
+			TTL:       dto.TTL,
+			Precision: dto.Precision,
+			Scope:     scope,
+		}))
+	}
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "share.pick_scope"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(acc)}})
+}
+
+func (s *Service) handleShareScopeCallback(ctx context.Context, acc *database.Account, dto ShareCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	ttl := time.Duration(dto.TTL) * time.Minute
+
+	link, err := s.shareService.Create(ctx, acc, dto.Scope, dto.Precision, ttl)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create share link",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "share.created",
+		i18n.Duration(ttl), describeShareScope(dto.Scope), describeSharePrecision(dto.Precision), s.shareService.URL(&link)))
+}
+
+func (s *Service) handleRevokeShareCallback(ctx context.Context, acc *database.Account, dto RevokeShareCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if _, err := s.shareService.Revoke(ctx, acc, dto.ID); err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke share link",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "share.revoked"))
+}
+
+func (s *Service) shareButton(text string, dto ShareCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
+
+func describeShareScope(scope string) i18n.Text {
+	if scope == share.ScopeToday {
+		return i18n.M("share.scope_today")
+	}
+
+	return i18n.M("share.scope_live")
+}
+
+func describeSharePrecision(precision int) i18n.Text {
+	if precision == 0 {
+		return i18n.M("share.exact")
+	}
+
+	return i18n.M("share.approx", i18n.Distance(precision))
+}
@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// SendSOS sends the SOS location and text with an acknowledgement button to the recipient's chat.
+func (s *Service) SendSOS(ctx context.Context, recipient *database.Account, sosID int64, text i18n.Text, loc *api.LocationData) error {
+	chatID := *recipient.ChatID
+
+	if loc != nil {
+		if _, err := s.tgBot.SendLocation(ctx, &bot.SendLocationParams{
+			ChatID:             chatID,
+			Latitude:           loc.Latitude,
+			Longitude:          loc.Longitude,
+			HorizontalAccuracy: min(loc.Accuracy, 1500),
+		}); err != nil {
+			return fmt.Errorf("send location: %w", err)
+		}
+	}
+
+	callbackDTO := SOSAckCallbackDTO{
+		Type: "sos_ack",
+		ID:   sosID,
+	}
+
+	callbackBytes, _ := json.Marshal(&callbackDTO)
+
+	if _, err := s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text.Localize(recipient.Locale()),
+		ParseMode: "Markdown",
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: util.ToPtr(true),
+		},
+		ReplyMarkup: models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{{
+				{
+					Text:         tr(recipient, "sos.ack_button"),
+					CallbackData: string(callbackBytes),
+				},
+			}},
+		},
+	}); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleSOSAckCallback(ctx context.Context, acc *database.Account, dto SOSAckCallbackDTO, query *models.CallbackQuery) {
+	now := time.Now()
+
+	incident, err := s.queries.AcknowledgeSosIncident(ctx, database.AcknowledgeSosIncidentParams{
+		ID:             dto.ID,
+		AcknowledgedBy: &acc.ID,
+		Acknowledged:   &now,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.answerCallback(ctx, query, tr(acc, "sos.already_ack"))
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to acknowledge SOS",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if err = s.queries.CreateSosEvent(ctx, database.CreateSosEventParams{
+		SosID:     incident.ID,
+		Created:   now,
+		Type:      string(api.SosEventTypeAcknowledged),
+		AccountID: &acc.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to create SOS event",
+			slog.Any("error", err),
+		)
+	}
+
+	s.answerCallback(ctx, query, tr(acc, "sos.thanks"))
+
+	sender, err := s.queries.GetAccount(ctx, incident.AccountID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get account",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all accounts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, a := range accounts {
+		if a.ChatID == nil {
+			continue
+		}
+
+		s.SendMessage(ctx, *a.ChatID, tr(&a, "sos.acked", i18n.Escaped(acc.Name), i18n.Escaped(sender.Name)))
+	}
+}
+
+func (s *Service) answerCallback(ctx context.Context, query *models.CallbackQuery, text string) {
+	if _, err := s.tgBot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: query.ID,
+		Text:            text,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to answer callback query",
+			slog.Any("error", err),
+		)
+	}
+}
@@ -1,9 +1,228 @@
 package telegram
 
-import "roflbeacon2/pkg/database"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/pkg/database"
+	"sync"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+)
+
+const idleStage = "idle"
+
+// BotState is the per-chat conversation state, persisted between messages and restarts.
 type BotState struct {
-	Stage string
+	Stage string `json:"-"`
+
+	FenceID     int64                      `json:"fenceId,omitempty"`
+	FenceParams database.CreateFenceParams `json:"fenceParams"`
+
+	// AccountID is the account whose thresholds are edited
+	AccountID    int64  `json:"accountId,omitempty"`
+	ThresholdKey string `json:"thresholdKey,omitempty"`
+}
+
+// stageHandler reacts to the input a stage waits for, a nil handler means that the input is not expected.
+// Handlers move the conversation on by changing state.Stage, setting it to idleStage finishes it.
+type stageHandler struct {
+	onText     func(ctx context.Context, acc *database.Account, state *BotState, text string)
+	onLocation func(ctx context.Context, acc *database.Account, state *BotState, lat, lon float64)
+}
+
+// chatLocks serializes the state changes of every chat. A chat's mutex lives only while
+// someone holds or waits for it, so the map doesn't grow with every chat ever seen.
+type chatLocks struct {
+	m     sync.Mutex
+	locks map[int64]*chatLock
+}
+
+type chatLock struct {
+	sync.Mutex
+	// holders counts the goroutines holding or waiting for the lock, guarded by chatLocks.m
+	holders int
+}
+
+func (l *chatLocks) lock(chatID int64) func() {
+	l.m.Lock()
+
+	if l.locks == nil {
+		l.locks = map[int64]*chatLock{}
+	}
+
+	lock, ok := l.locks[chatID]
+	if !ok {
+		lock = &chatLock{}
+		l.locks[chatID] = lock
+	}
+
+	lock.holders++
+
+	l.m.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		l.m.Lock()
+		defer l.m.Unlock()
+
+		lock.holders--
+		if lock.holders == 0 {
+			delete(l.locks, chatID)
+		}
+	}
+}
+
+func (s *Service) registerStages() {
+	s.stages = map[string]stageHandler{
+		"add_fence_name": {
+			onText: s.handleAddFenceName,
+		},
+		"add_fence_center": {
+			onText:     s.handleFenceCenterText,
+			onLocation: s.handleFenceCenter,
+		},
+		"add_fence_radius": {
+			onText: s.handleFenceRadiusText,
+		},
+		"edit_fence_move": {
+			onText:     s.handleFenceCenterText,
+			onLocation: s.handleFenceCenter,
+		},
+		"edit_fence_radius": {
+			onText: s.handleFenceRadiusText,
+		},
+		"edit_fence_name": {
+			onText: s.handleEditFenceName,
+		},
+		"edit_fence_accuracy": {
+			onText: s.handleFenceAccuracyText,
+		},
+		"edit_threshold": {
+			onText: s.handleThresholdText,
+		},
+	}
+}
+
+// loadState returns the chat's state, idle if there is none. An expired state is removed
+// and reported so that the user can be told why the input is not accepted anymore.
+func (s *Service) loadState(ctx context.Context, chatID int64) (BotState, bool, error) {
+	row, err := s.queries.GetBotState(ctx, chatID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return BotState{Stage: idleStage}, false, nil
+	}
+	if err != nil {
+		return BotState{}, false, fmt.Errorf("get bot state: %w", err)
+	}
+
+	if time.Now().After(row.Expires) {
+		if err = s.queries.DeleteBotState(ctx, chatID); err != nil {
+			return BotState{}, false, fmt.Errorf("delete bot state: %w", err)
+		}
+
+		return BotState{Stage: idleStage}, true, nil
+	}
+
+	state := BotState{Stage: row.Stage}
+	if err = json.Unmarshal(row.Data, &state); err != nil {
+		return BotState{}, false, fmt.Errorf("unmarshal bot state: %w", err)
+	}
+
+	return state, false, nil
+}
+
+func (s *Service) saveState(ctx context.Context, chatID int64, state *BotState) error {
+	if state.Stage == idleStage {
+		if err := s.queries.DeleteBotState(ctx, chatID); err != nil {
+			return fmt.Errorf("delete bot state: %w", err)
+		}
+
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal bot state: %w", err)
+	}
+
+	if err = s.queries.UpsertBotState(ctx, database.UpsertBotStateParams{
+		ChatID:  chatID,
+		Stage:   state.Stage,
+		Data:    data,
+		Expires: time.Now().Add(s.cfg.Telegram.StateTimeout),
+	}); err != nil {
+		return fmt.Errorf("upsert bot state: %w", err)
+	}
+
+	return nil
+}
+
+// withState runs fn on the chat's state and persists the result, the chat is locked meanwhile.
+func (s *Service) withState(ctx context.Context, acc *database.Account, fn func(state *BotState, expired bool)) {
+	unlock := s.chatLocks.lock(*acc.ChatID)
+	defer unlock()
+
+	state, expired, err := s.loadState(ctx, *acc.ChatID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load bot state",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	fn(&state, expired)
+
+	if err = s.saveState(ctx, *acc.ChatID, &state); err != nil {
+		slog.ErrorContext(ctx, "Failed to save bot state",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// startStage begins a multi-step command in the chat, replacing whatever was in progress.
+func (s *Service) startStage(ctx context.Context, acc *database.Account, state BotState) {
+	s.withState(ctx, acc, func(current *BotState, _ bool) {
+		*current = state
+	})
+}
+
+func (s *Service) handleStateText(ctx context.Context, acc *database.Account, text string) {
+	s.withState(ctx, acc, func(state *BotState, expired bool) {
+		handler := s.stages[state.Stage]
+
+		if handler.onText == nil {
+			s.replyUnexpected(ctx, acc, expired)
+			return
+		}
+
+		handler.onText(ctx, acc, state, text)
+	})
+}
+
+func (s *Service) handleStateLocation(ctx context.Context, acc *database.Account, lat, lon float64) {
+	s.withState(ctx, acc, func(state *BotState, expired bool) {
+		handler := s.stages[state.Stage]
+
+		if handler.onLocation == nil {
+			s.replyUnexpected(ctx, acc, expired)
+			return
+		}
+
+		handler.onLocation(ctx, acc, state, lat, lon)
+	})
+}
+
+func (s *Service) replyUnexpected(ctx context.Context, acc *database.Account, expired bool) {
+	if expired {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "common.state_expired"))
+		return
+	}
 
-	FenceParams database.CreateFenceParams
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "common.unknown_command"))
 }
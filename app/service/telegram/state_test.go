@@ -0,0 +1,58 @@
+package telegram
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestChatLocks(t *testing.T) {
+	tests := []struct {
+		name       string
+		chats      int
+		goroutines int
+	}{
+		{name: "single chat", chats: 1, goroutines: 50},
+		{name: "many chats", chats: 20, goroutines: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				locks chatLocks
+				wg    sync.WaitGroup
+			)
+
+			// counters are only changed under the chat's lock, the race detector catches a missing exclusion
+			counters := make([]int, tt.chats)
+
+			for i := range tt.goroutines {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					chat := i % tt.chats
+
+					unlock := locks.lock(int64(chat))
+					counters[chat]++
+					unlock()
+				}()
+			}
+
+			wg.Wait()
+
+			total := 0
+			for _, n := range counters {
+				total += n
+			}
+
+			if total != tt.goroutines {
+				t.Errorf("counted %d lock holders, want %d", total, tt.goroutines)
+			}
+
+			if len(locks.locks) != 0 {
+				t.Errorf("%d locks left after every holder unlocked", len(locks.locks))
+			}
+		})
+	}
+}
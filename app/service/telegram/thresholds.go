@@ -0,0 +1,266 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// threshold is an account override of a config tunable, editable with /thresholds.
+// The bounds match the AccountSettings schema.
+type threshold struct {
+	Key      string
+	Min, Max float64
+	// describe renders the effective value of target for viewer, set stores the override, nil resets it
+	describe func(s *Service, viewer, target *database.Account) string
+	set      func(settings *api.AccountSettings, value *float64)
+}
+
+var thresholds = []threshold{
+	{
+		Key: "offline", Min: 1, Max: 10080,
+		describe: func(s *Service, viewer, target *database.Account) string {
+			return tr(viewer, "thresholds.offline", i18n.Duration(s.settingsService.OfflineThreshold(target)))
+		},
+		set: func(settings *api.AccountSettings, value *float64) {
+			settings.OfflineThresholdMinutes = roundPtr(value)
+		},
+	},
+	{
+		Key: "battery", Min: 1, Max: 99,
+		describe: func(s *Service, viewer, target *database.Account) string {
+			return tr(viewer, "thresholds.battery", s.settingsService.LowBatteryThreshold(target))
+		},
+		set: func(settings *api.AccountSettings, value *float64) {
+			settings.LowBatteryThreshold = roundPtr(value)
+		},
+	},
+	{
+		Key: "speed", Min: 0, Max: 300,
+		describe: func(s *Service, viewer, target *database.Account) string {
+			limit := s.settingsService.SpeedLimitKmh(target)
+			if limit == 0 {
+				return tr(viewer, "thresholds.speed", tr(viewer, "thresholds.off"))
+			}
+
+			return tr(viewer, "thresholds.speed", tr(viewer, "distance.kmh", limit))
+		},
+		set: func(settings *api.AccountSettings, value *float64) {
+			settings.SpeedLimitKmh = roundPtr(value)
+		},
+	},
+	{
+		Key: "accuracy", Min: 0, Max: 10,
+		describe: func(s *Service, viewer, target *database.Account) string {
+			return tr(viewer, "thresholds.accuracy", s.settingsService.AccuracyFactor(target, nil))
+		},
+		set: func(settings *api.AccountSettings, value *float64) {
+			settings.AccuracyFactor = value
+		},
+	},
+}
+
+func roundPtr(value *float64) *int {
+	if value == nil {
+		return nil
+	}
+
+	rounded := int(math.Round(*value))
+
+	return &rounded
+}
+
+func findThreshold(key string) (threshold, bool) {
+	for _, t := range thresholds {
+		if t.Key == key {
+			return t, true
+		}
+	}
+
+	return threshold{}, false
+}
+
+// parseOverride parses a value within the bounds, "-" means the default.
+func parseOverride(text string, minValue, maxValue float64) (*float64, bool) {
+	text = strings.TrimSpace(text)
+	if text == "-" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil || value < minValue || value > maxValue {
+		return nil, false
+	}
+
+	return &value, true
+}
+
+// handleThresholds lets the admin pick the account whose thresholds to change,
+// the monitored accounts must not be able to turn their own alerts off.
+func (s *Service) handleThresholds(ctx context.Context, selfAcc *database.Account) {
+	if *selfAcc.ChatID != s.cfg.Telegram.AdminChatID {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "common.forbidden"))
+		return
+	}
+
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all accounts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+
+	for _, acc := range accounts {
+		rows = append(rows, []models.InlineKeyboardButton{s.thresholdButton(acc.Name, ThresholdCallbackDTO{
+			Type:      "threshold_acc",
+			AccountID: acc.ID,
+		})})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "thresholds.pick_account"), rows)
+}
+
+func (s *Service) handleThresholdAccountCallback(ctx context.Context, acc *database.Account, dto ThresholdCallbackDTO, query *models.CallbackQuery) {
+	if *acc.ChatID != s.cfg.Telegram.AdminChatID {
+		return
+	}
+
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	target, err := s.queries.GetAccount(ctx, dto.AccountID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get account",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+
+	for _, t := range thresholds {
+		rows = append(rows, []models.InlineKeyboardButton{s.thresholdButton(t.describe(s, acc, &target), ThresholdCallbackDTO{
+			Type:      "threshold",
+			AccountID: target.ID,
+			Key:       t.Key,
+		})})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(acc)})
+
+	s.sendKeyboard(ctx, acc, tr(acc, "thresholds.pick", target.Name), rows)
+}
+
+func (s *Service) handleThresholdCallback(ctx context.Context, acc *database.Account, dto ThresholdCallbackDTO, query *models.CallbackQuery) {
+	if *acc.ChatID != s.cfg.Telegram.AdminChatID {
+		return
+	}
+
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	t, ok := findThreshold(dto.Key)
+	if !ok {
+		return
+	}
+
+	s.startStage(ctx, acc, BotState{Stage: "edit_threshold", AccountID: dto.AccountID, ThresholdKey: t.Key})
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "thresholds.enter", t.Min, t.Max))
+}
+
+func (s *Service) handleThresholdText(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	t, ok := findThreshold(state.ThresholdKey)
+	if !ok || *acc.ChatID != s.cfg.Telegram.AdminChatID {
+		state.Stage = idleStage
+		return
+	}
+
+	value, ok := parseOverride(text, t.Min, t.Max)
+	if !ok {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "thresholds.bad_value"))
+		return
+	}
+
+	target, err := s.settingsService.UpdateAccountSettings(ctx, state.AccountID, func(settings *api.AccountSettings) error {
+		t.set(settings, value)
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update account settings",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if target.ID == acc.ID {
+		acc.Settings = target.Settings
+	}
+
+	state.Stage = idleStage
+
+	s.SendMessage(ctx, *acc.ChatID, i18n.EscapeMarkdown(target.Name)+": "+t.describe(s, acc, &target))
+}
+
+func (s *Service) thresholdButton(text string, dto ThresholdCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
+
+func (s *Service) handleFenceAccuracyText(ctx context.Context, acc *database.Account, state *BotState, text string) {
+	value, ok := parseOverride(text, 0, 10)
+	if !ok {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "thresholds.bad_value"))
+		return
+	}
+
+	fence, err := s.settingsService.UpdateFenceSettings(ctx, state.FenceID, api.FenceSettings{AccuracyFactor: value})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update fence settings",
+			slog.Any("error", err),
+		)
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.update_failed"))
+		return
+	}
+
+	state.Stage = idleStage
+
+	if value == nil {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.accuracy_reset", fence.Name))
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "fence.accuracy_updated", fence.Name, *value))
+}
@@ -4,17 +4,59 @@ import (
 	"fmt"
 	"roflbeacon2/app/api"
 	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/maplink"
 	"roflbeacon2/pkg/util"
 	"strings"
+	"time"
 )
 
-func (s *Service) resetState() {
-	s.state = BotState{
-		Stage: "idle",
+const (
+	etaSpeedWindow = 10 * time.Minute
+
+	// minEtaSpeed is the slowest speed in m/s at which an ETA still makes sense.
+	minEtaSpeed    = 0.5
+	minEtaDistance = 100
+)
+
+// recentSpeed averages the speed of the location updates (newest first) sent within the last few minutes.
+func recentSpeed(updates []database.Update) float64 {
+	if len(updates) == 0 || time.Since(updates[0].Created) > etaSpeedWindow {
+		return 0
+	}
+
+	since := updates[0].Created.Add(-etaSpeedWindow)
+
+	var sum float64
+	var count int
+
+	for _, update := range updates {
+		if update.Created.Before(since) {
+			break
+		}
+
+		loc := update.Data.Location
+		if loc == nil || loc.Speed == nil {
+			continue
+		}
+
+		sum += *loc.Speed
+		count++
+	}
+
+	if count == 0 {
+		return 0
 	}
+
+	return sum / float64(count)
+}
+
+// tr renders the message in the account's language.
+func tr(acc *database.Account, key string, args ...any) string {
+	return acc.Locale().T(key, args...)
 }
 
-func (s *Service) formatUpdate(acc *database.Account, lastUpdate database.Update, myLastLocation *api.LocationData) string {
+func (s *Service) formatUpdate(locale i18n.Locale, acc *database.Account, lastUpdate database.Update, myLastLocation *api.LocationData, speed float64) string {
 	var builder strings.Builder
 
 	loc := lastUpdate.Data.Location
@@ -22,18 +64,18 @@ func (s *Service) formatUpdate(acc *database.Account, lastUpdate database.Update
 	builder.WriteString("*")
 	builder.WriteString(acc.Name)
 	builder.WriteString("* (")
-	builder.WriteString(util.TimeAgo(lastUpdate.Created))
+	builder.WriteString(i18n.TimeAgo(locale.Lang, lastUpdate.Created))
 	builder.WriteString(")\n")
 
 	if loc == nil {
-		builder.WriteString("⚠️ Местоположение не определено")
+		builder.WriteString(locale.T("common.no_location"))
 	} else {
-		mapLink := util.GenerateYandexLinkForPoint(loc.Latitude, loc.Longitude)
+		mapLink := i18n.MapPoint{Lat: loc.Latitude, Lon: loc.Longitude}
 
-		builder.WriteString(fmt.Sprintf("[На карте](%s)", mapLink))
+		builder.WriteString(locale.T("common.on_map", mapLink))
 		if myLastLocation != nil {
-			routeLink := util.GenerateYandexLinkForRoute(myLastLocation.Latitude, myLastLocation.Longitude, loc.Latitude, loc.Longitude, "mt")
-			builder.WriteString(fmt.Sprintf(" | [Маршрут до меня](%s)", routeLink))
+			routeLink := i18n.MapRoute{FromLat: loc.Latitude, FromLon: loc.Longitude, ToLat: myLastLocation.Latitude, ToLon: myLastLocation.Longitude, Mode: maplink.Transit}
+			builder.WriteString(" | " + locale.T("common.route_to_me", routeLink))
 		}
 
 		builder.WriteString("\n")
@@ -41,18 +83,23 @@ func (s *Service) formatUpdate(acc *database.Account, lastUpdate database.Update
 		if myLastLocation != nil {
 			distToMe := util.HaversineDistance(myLastLocation.Latitude, myLastLocation.Longitude, loc.Latitude, loc.Longitude)
 
-			if distToMe >= 1000 {
-				builder.WriteString(fmt.Sprintf("📐 %.1f км | ", distToMe/1000))
-			} else {
-				builder.WriteString(fmt.Sprintf("📐 %.0f м | ", distToMe))
+			builder.WriteString(fmt.Sprintf("📐 %s | ", i18n.FormatDistance(locale.Lang, distToMe)))
+		}
+		builder.WriteString(locale.T("common.accuracy", loc.Accuracy) + "\n")
+
+		if myLastLocation != nil && speed >= minEtaSpeed {
+			distToMe := util.HaversineDistance(myLastLocation.Latitude, myLastLocation.Longitude, loc.Latitude, loc.Longitude)
+
+			if distToMe >= minEtaDistance {
+				eta := time.Duration(distToMe / speed * float64(time.Second))
+				builder.WriteString(locale.T("update.eta", i18n.Duration(eta), util.MpsToKmh(speed)) + "\n")
 			}
 		}
-		builder.WriteString(fmt.Sprintf("±%.0f м\n", loc.Accuracy))
 
 		if loc.Address != nil {
 			builder.WriteString(fmt.Sprintf("📍 %s\n", *loc.Address))
 		} else {
-			builder.WriteString("📍 Адрес не определен\n")
+			builder.WriteString(locale.T("common.no_address") + "\n")
 		}
 	}
 
@@ -0,0 +1,342 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+var watchDistances = []int{500, 1000, 5000}
+
+func (s *Service) handleWatch(ctx context.Context, selfAcc *database.Account) {
+	accounts, err := s.queries.GetAllAccounts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all accounts",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var buttons []models.InlineKeyboardButton
+
+	for _, acc := range accounts {
+		if acc.ID == selfAcc.ID {
+			continue
+		}
+
+		buttons = append(buttons, s.watchButton(acc.Name, WatchCallbackDTO{
+			Type:      "watch_acc",
+			AccountID: acc.ID,
+		}))
+	}
+
+	s.sendKeyboard(ctx, selfAcc, tr(selfAcc, "watch.pick_account"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(selfAcc)}})
+}
+
+func (s *Service) handleWatches(ctx context.Context, selfAcc *database.Account) {
+	watches, err := s.queries.GetActiveWatchesByOwnerID(ctx, database.GetActiveWatchesByOwnerIDParams{
+		OwnerID: selfAcc.ID,
+		Expires: time.Now(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get watches",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(watches) == 0 {
+		s.SendMessage(ctx, *selfAcc.ChatID, tr(selfAcc, "watch.none"))
+		return
+	}
+
+	var lines []string
+	var rows [][]models.InlineKeyboardButton
+
+	for i, watch := range watches {
+		description, err := s.describeWatch(ctx, selfAcc, &watch)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to describe watch",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		lines = append(lines, tr(selfAcc, "common.item_left", i+1, description, i18n.Duration(time.Until(watch.Expires))))
+
+		callbackBytes, _ := json.Marshal(&DeleteWatchCallbackDTO{
+			Type: "delete_watch",
+			ID:   watch.ID,
+		})
+
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         tr(selfAcc, "watch.cancel_button", i+1),
+			CallbackData: string(callbackBytes),
+		}})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{s.cancelButton(selfAcc)})
+
+	s.sendKeyboard(ctx, selfAcc, strings.Join(lines, "\n"), rows)
+}
+
+func (s *Service) handleWatchAccountCallback(ctx context.Context, acc *database.Account, dto WatchCallbackDTO, query *models.CallbackQuery) {
+	buttons := []models.InlineKeyboardButton{
+		s.watchButton(tr(acc, "watch.arrive"), WatchCallbackDTO{
+			Type:      "watch_dir",
+			AccountID: dto.AccountID,
+			Direction: string(api.WatchDirectionArrive),
+		}),
+		s.watchButton(tr(acc, "watch.leave"), WatchCallbackDTO{
+			Type:      "watch_dir",
+			AccountID: dto.AccountID,
+			Direction: string(api.WatchDirectionLeave),
+		}),
+	}
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "watch.pick_direction"), [][]models.InlineKeyboardButton{buttons, {s.cancelButton(acc)}})
+}
+
+func (s *Service) handleWatchDirectionCallback(ctx context.Context, acc *database.Account, dto WatchCallbackDTO, query *models.CallbackQuery) {
+	fences, err := s.queries.GetAllFences(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get all fences",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+
+	for _, fence := range fences {
+		rows = append(rows, []models.InlineKeyboardButton{s.watchButton(fence.Name, WatchCallbackDTO{
+			Type:      "watch_set",
+			AccountID: dto.AccountID,
+			Direction: dto.Direction,
+			FenceID:   fence.ID,
+		})})
+	}
+
+	var distanceButtons []models.InlineKeyboardButton
+
+	for _, distance := range watchDistances {
+		distanceButtons = append(distanceButtons, s.watchButton(tr(acc, "watch.from_me", i18n.Distance(distance)), WatchCallbackDTO{
+			Type:      "watch_set",
+			AccountID: dto.AccountID,
+			Direction: dto.Direction,
+			Distance:  distance,
+		}))
+	}
+
+	rows = append(rows, distanceButtons, []models.InlineKeyboardButton{s.cancelButton(acc)})
+
+	s.editKeyboard(ctx, acc, query, tr(acc, "watch.pick_place"), rows)
+}
+
+func (s *Service) handleWatchSetCallback(ctx context.Context, acc *database.Account, dto WatchCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	now := time.Now()
+
+	params := database.CreateWatchParams{
+		OwnerID:   acc.ID,
+		TargetID:  dto.AccountID,
+		Direction: dto.Direction,
+		Created:   now,
+		Expires:   now.Add(s.cfg.Tune().Watch.DefaultTTL),
+	}
+
+	if dto.FenceID != 0 {
+		params.FenceID = &dto.FenceID
+	} else {
+		params.Distance = util.ToPtr(float64(dto.Distance))
+	}
+
+	watch := database.Watch{
+		OwnerID:   params.OwnerID,
+		TargetID:  params.TargetID,
+		FenceID:   params.FenceID,
+		Distance:  params.Distance,
+		Direction: params.Direction,
+	}
+
+	holds, err := s.watchHolds(ctx, &watch)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check watch",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	description, err := s.describeWatch(ctx, acc, &watch)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to describe watch",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if holds {
+		s.SendMessage(ctx, *acc.ChatID, tr(acc, "watch.already", description))
+		return
+	}
+
+	if _, err = s.queries.CreateWatch(ctx, params); err != nil {
+		slog.ErrorContext(ctx, "Failed to create watch",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "watch.created", description, i18n.Duration(s.cfg.Tune().Watch.DefaultTTL)))
+}
+
+// watchHolds reports whether the watch condition is already met, such a watch would never fire.
+func (s *Service) watchHolds(ctx context.Context, watch *database.Watch) (bool, error) {
+	target, err := s.queries.GetAccount(ctx, watch.TargetID)
+	if err != nil {
+		return false, fmt.Errorf("get account: %w", err)
+	}
+
+	ownerUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, watch.OwnerID)
+	if err != nil {
+		return false, fmt.Errorf("get last location update: %w", err)
+	}
+
+	targetUpdates, err := s.queries.GetLastLocationUpdateByAccountID(ctx, watch.TargetID)
+	if err != nil {
+		return false, fmt.Errorf("get last location update: %w", err)
+	}
+
+	var ownerLoc, targetLoc *api.LocationData
+	if len(ownerUpdates) > 0 {
+		ownerLoc = ownerUpdates[0].Data.Location
+	}
+	if len(targetUpdates) > 0 {
+		targetLoc = targetUpdates[0].Data.Location
+	}
+
+	return watch.Holds(&target, ownerLoc, targetLoc), nil
+}
+
+func (s *Service) handleDeleteWatchCallback(ctx context.Context, acc *database.Account, dto DeleteWatchCallbackDTO, query *models.CallbackQuery) {
+	if _, err := s.tgBot.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete message",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if _, err := s.queries.DeleteWatch(ctx, database.DeleteWatchParams{
+		ID:      dto.ID,
+		OwnerID: acc.ID,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete watch",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	s.SendMessage(ctx, *acc.ChatID, tr(acc, "watch.deleted"))
+}
+
+// describeWatch describes the watch in the language of the account reading it.
+func (s *Service) describeWatch(ctx context.Context, acc *database.Account, watch *database.Watch) (string, error) {
+	target, err := s.queries.GetAccount(ctx, watch.TargetID)
+	if err != nil {
+		return "", fmt.Errorf("get account: %w", err)
+	}
+
+	arrive := watch.Direction == string(api.WatchDirectionArrive)
+
+	if watch.FenceID != nil {
+		fence, err := s.queries.GetFence(ctx, *watch.FenceID)
+		if err != nil {
+			return "", fmt.Errorf("get fence: %w", err)
+		}
+
+		if arrive {
+			return tr(acc, "watch.arrive_fence", target.Name, fence.Name), nil
+		}
+
+		return tr(acc, "watch.leave_fence", target.Name, fence.Name), nil
+	}
+
+	distance := i18n.Distance(util.GetPtrOrZero(watch.Distance))
+
+	if arrive {
+		return tr(acc, "watch.arrive_near", target.Name, distance), nil
+	}
+
+	return tr(acc, "watch.leave_far", target.Name, distance), nil
+}
+
+func (s *Service) watchButton(text string, dto WatchCallbackDTO) models.InlineKeyboardButton {
+	callbackBytes, _ := json.Marshal(&dto)
+
+	return models.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: string(callbackBytes),
+	}
+}
+
+func (s *Service) cancelButton(acc *database.Account) models.InlineKeyboardButton {
+	cancelBytes, _ := json.Marshal(&GenericCallbackDTO{
+		Type: "cancel",
+	})
+
+	return models.InlineKeyboardButton{
+		Text:         tr(acc, "common.cancel"),
+		CallbackData: string(cancelBytes),
+	}
+}
+
+func (s *Service) sendKeyboard(ctx context.Context, acc *database.Account, text string, rows [][]models.InlineKeyboardButton) {
+	if _, err := s.tgBot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: acc.ChatID,
+		Text:   text,
+		ReplyMarkup: models.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send message",
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) editKeyboard(ctx context.Context, acc *database.Account, query *models.CallbackQuery, text string, rows [][]models.InlineKeyboardButton) {
+	if _, err := s.tgBot.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    acc.ChatID,
+		MessageID: query.Message.Message.ID,
+		Text:      text,
+		ReplyMarkup: models.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		},
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to edit message",
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,342 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/app/service/alert"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/maplink"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+type Service struct {
+	cfg          *config.Config
+	dbConn       *pgxpool.Pool
+	queries      *database.Queries
+	alertService *alert.Service
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:          do.MustInvoke[*config.Config](di),
+		dbConn:       do.MustInvoke[*pgxpool.Pool](di),
+		queries:      do.MustInvoke[*database.Queries](di),
+		alertService: do.MustInvoke[*alert.Service](di),
+	}, nil
+}
+
+func (s *Service) List(ctx context.Context, owner *database.Account) ([]database.Watch, error) {
+	watches, err := s.queries.GetActiveWatchesByOwnerID(ctx, database.GetActiveWatchesByOwnerIDParams{
+		OwnerID: owner.ID,
+		Expires: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get watches: %w", err)
+	}
+
+	return watches, nil
+}
+
+func (s *Service) Create(ctx context.Context, owner *database.Account, input api.WatchInput) (database.Watch, error) {
+	if input.AccountId == owner.ID {
+		return database.Watch{}, oops.With("statusCode", http.StatusBadRequest).New("Can't watch yourself")
+	}
+
+	if (input.FenceId == nil) == (input.Distance == nil) {
+		return database.Watch{}, oops.With("statusCode", http.StatusBadRequest).New("Exactly one of fenceId and distance must be set")
+	}
+
+	if _, err := s.queries.GetAccount(ctx, input.AccountId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.Watch{}, oops.With("statusCode", http.StatusBadRequest).New("Account not found")
+		}
+
+		return database.Watch{}, fmt.Errorf("get account: %w", err)
+	}
+
+	if input.FenceId != nil {
+		if _, err := s.queries.GetFence(ctx, *input.FenceId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return database.Watch{}, oops.With("statusCode", http.StatusBadRequest).New("Fence not found")
+			}
+
+			return database.Watch{}, fmt.Errorf("get fence: %w", err)
+		}
+	}
+
+	now := time.Now()
+
+	ttl := s.cfg.Tune().Watch.DefaultTTL
+	if input.ExpiresInMinutes != nil {
+		ttl = time.Duration(*input.ExpiresInMinutes) * time.Minute
+	}
+
+	if err := s.queries.DeleteExpiredWatches(ctx, now); err != nil {
+		return database.Watch{}, fmt.Errorf("delete expired watches: %w", err)
+	}
+
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return database.Watch{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.queries.WithTx(tx)
+
+	watch, err := qtx.CreateWatch(ctx, database.CreateWatchParams{
+		OwnerID:   owner.ID,
+		TargetID:  input.AccountId,
+		FenceID:   input.FenceId,
+		Distance:  input.Distance,
+		Direction: string(input.Direction),
+		Created:   now,
+		Expires:   now.Add(ttl),
+	})
+	if err != nil {
+		return database.Watch{}, fmt.Errorf("create watch: %w", err)
+	}
+
+	if err = s.fireIfHolds(ctx, qtx, &watch); err != nil {
+		return database.Watch{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return database.Watch{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return watch, nil
+}
+
+// Delete removes the owner's watch and reports whether it existed.
+func (s *Service) Delete(ctx context.Context, owner *database.Account, id int64) (bool, error) {
+	count, err := s.queries.DeleteWatch(ctx, database.DeleteWatchParams{
+		ID:      id,
+		OwnerID: owner.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete watch: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Evaluate fires the active watches matched by the account's new location and removes them,
+// so that every watch triggers at most once. The account may be the target of a watch or,
+// for distance watches, its owner since the owner moving changes the distance as well.
+func (s *Service) Evaluate(ctx context.Context, qtx *database.Queries, acc *database.Account, loc *api.LocationData, enteredFences, leftFences mapset.Set[database.Fence], now time.Time) error {
+	targetWatches, err := qtx.GetActiveWatchesByTargetID(ctx, database.GetActiveWatchesByTargetIDParams{
+		TargetID: acc.ID,
+		Expires:  now,
+	})
+	if err != nil {
+		return fmt.Errorf("get watches: %w", err)
+	}
+
+	ownerWatches, err := qtx.GetActiveWatchesByOwnerID(ctx, database.GetActiveWatchesByOwnerIDParams{
+		OwnerID: acc.ID,
+		Expires: now,
+	})
+	if err != nil {
+		return fmt.Errorf("get watches: %w", err)
+	}
+
+	if len(targetWatches) == 0 && len(ownerWatches) == 0 {
+		return nil
+	}
+
+	// nothing is stored for this update yet, so the last one is the previous location
+	prevUpdate, err := lastLocation(ctx, qtx, acc.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, watch := range targetWatches {
+		var text i18n.Text
+
+		if watch.FenceID != nil {
+			text = s.matchFence(&watch, acc, enteredFences, leftFences)
+		} else if prevUpdate != nil {
+			ownerUpdate, err := lastLocation(ctx, qtx, watch.OwnerID)
+			if err != nil {
+				return err
+			}
+
+			if ownerUpdate != nil {
+				ownerLoc := ownerUpdate.Data.Location
+				text = matchDistance(&watch, acc, ownerLoc, ownerLoc, prevUpdate.Data.Location, loc)
+			}
+		}
+
+		if err = s.fire(ctx, qtx, &watch, text); err != nil {
+			return err
+		}
+	}
+
+	if prevUpdate == nil {
+		return nil
+	}
+
+	for _, watch := range ownerWatches {
+		if watch.Distance == nil {
+			continue
+		}
+
+		targetUpdate, err := lastLocation(ctx, qtx, watch.TargetID)
+		if err != nil {
+			return err
+		}
+
+		if targetUpdate == nil {
+			continue
+		}
+
+		target, err := qtx.GetAccount(ctx, watch.TargetID)
+		if err != nil {
+			return fmt.Errorf("get account: %w", err)
+		}
+
+		targetLoc := targetUpdate.Data.Location
+		text := matchDistance(&watch, &target, prevUpdate.Data.Location, loc, targetLoc, targetLoc)
+
+		if err = s.fire(ctx, qtx, &watch, text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fireIfHolds fires a new watch right away if its condition is already met,
+// e.g. the target is already inside the fence of an arrive watch and would never enter it.
+func (s *Service) fireIfHolds(ctx context.Context, qtx *database.Queries, watch *database.Watch) error {
+	target, err := qtx.GetAccount(ctx, watch.TargetID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	ownerUpdate, err := lastLocation(ctx, qtx, watch.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	targetUpdate, err := lastLocation(ctx, qtx, watch.TargetID)
+	if err != nil {
+		return err
+	}
+
+	var ownerLoc, targetLoc *api.LocationData
+	if ownerUpdate != nil {
+		ownerLoc = ownerUpdate.Data.Location
+	}
+	if targetUpdate != nil {
+		targetLoc = targetUpdate.Data.Location
+	}
+
+	if !watch.Holds(&target, ownerLoc, targetLoc) {
+		return nil
+	}
+
+	if watch.FenceID == nil {
+		return s.fire(ctx, qtx, watch, distanceText(watch, &target, ownerLoc, targetLoc))
+	}
+
+	fence, err := qtx.GetFence(ctx, *watch.FenceID)
+	if err != nil {
+		return fmt.Errorf("get fence: %w", err)
+	}
+
+	if watch.Direction == string(api.WatchDirectionArrive) {
+		return s.fire(ctx, qtx, watch, i18n.M("alert.watch_inside", target.Name, fence.Name))
+	}
+
+	return s.fire(ctx, qtx, watch, i18n.M("alert.watch_outside", target.Name, fence.Name))
+}
+
+// fire alerts the owner and removes the watch, nothing happens if the text is nil.
+func (s *Service) fire(ctx context.Context, qtx *database.Queries, watch *database.Watch, text i18n.Text) error {
+	if text == nil {
+		return nil
+	}
+
+	owner, err := qtx.GetAccount(ctx, watch.OwnerID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	if err = s.alertService.AlertAccount(ctx, qtx, &owner, api.AlertEventTypeWatch, text); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+
+	if err = qtx.DeleteFiredWatch(ctx, watch.ID); err != nil {
+		return fmt.Errorf("delete watch: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) matchFence(watch *database.Watch, target *database.Account, enteredFences, leftFences mapset.Set[database.Fence]) i18n.Text {
+	fences := util.Ternary(watch.Direction == string(api.WatchDirectionArrive), enteredFences, leftFences)
+
+	for fence := range fences.Iter() {
+		if fence.ID != *watch.FenceID {
+			continue
+		}
+
+		if watch.Direction == string(api.WatchDirectionArrive) {
+			return i18n.M("alert.watch_arrived", target.Name, fence.Name)
+		}
+
+		return i18n.M("alert.watch_left", target.Name, fence.Name)
+	}
+
+	return nil
+}
+
+// matchDistance returns the alert if the distance between the owner and the target crossed
+// the watched one in the watched direction between the previous and the current locations.
+func matchDistance(watch *database.Watch, target *database.Account, prevOwnerLoc, ownerLoc, prevTargetLoc, targetLoc *api.LocationData) i18n.Text {
+	distance := *watch.Distance
+
+	prevDist := util.HaversineDistance(prevOwnerLoc.Latitude, prevOwnerLoc.Longitude, prevTargetLoc.Latitude, prevTargetLoc.Longitude)
+	curDist := util.HaversineDistance(ownerLoc.Latitude, ownerLoc.Longitude, targetLoc.Latitude, targetLoc.Longitude)
+
+	if watch.Direction == string(api.WatchDirectionArrive) && prevDist > distance && curDist <= distance {
+		return distanceText(watch, target, ownerLoc, targetLoc)
+	}
+
+	if watch.Direction == string(api.WatchDirectionLeave) && prevDist <= distance && curDist > distance {
+		return distanceText(watch, target, ownerLoc, targetLoc)
+	}
+
+	return nil
+}
+
+func distanceText(watch *database.Watch, target *database.Account, ownerLoc, targetLoc *api.LocationData) i18n.Text {
+	curDist := util.HaversineDistance(ownerLoc.Latitude, ownerLoc.Longitude, targetLoc.Latitude, targetLoc.Longitude)
+	routeLink := i18n.MapRoute{FromLat: targetLoc.Latitude, FromLon: targetLoc.Longitude, ToLat: ownerLoc.Latitude, ToLon: ownerLoc.Longitude, Mode: maplink.Transit}
+
+	key := util.Ternary(watch.Direction == string(api.WatchDirectionArrive), "alert.watch_near", "alert.watch_far")
+
+	return i18n.Lines(i18n.M(key, target.Name, i18n.Distance(curDist)), i18n.M("common.route_to_me", routeLink))
+}
+
+func lastLocation(ctx context.Context, qtx *database.Queries, accountID int64) (*database.Update, error) {
+	updates, err := qtx.GetLastLocationUpdateByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get last location update: %w", err)
+	}
+
+	return util.FirstOrNil(updates), nil
+}
@@ -0,0 +1,58 @@
+package watch
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/util"
+	"strings"
+	"testing"
+)
+
+func TestMatchDistance(t *testing.T) {
+	// about 1.1 km apart
+	home := &api.LocationData{Latitude: 55.7558, Longitude: 37.6173}
+	near := &api.LocationData{Latitude: 55.7658, Longitude: 37.6173}
+	// about 11 km from home
+	far := &api.LocationData{Latitude: 55.8558, Longitude: 37.6173}
+
+	arrive := database.Watch{Distance: util.ToPtr(2000.0), Direction: string(api.WatchDirectionArrive)}
+	leave := database.Watch{Distance: util.ToPtr(2000.0), Direction: string(api.WatchDirectionLeave)}
+
+	tests := []struct {
+		name               string
+		watch              database.Watch
+		prevOwner, owner   *api.LocationData
+		prevTarget, target *api.LocationData
+		want               string
+	}{
+		{name: "target approaches", watch: arrive, prevOwner: home, owner: home, prevTarget: far, target: near, want: "is 1.1 km away"},
+		{name: "owner approaches", watch: arrive, prevOwner: far, owner: near, prevTarget: home, target: home, want: "is 1.1 km away"},
+		{name: "target already near", watch: arrive, prevOwner: home, owner: home, prevTarget: near, target: near},
+		{name: "target still far", watch: arrive, prevOwner: home, owner: home, prevTarget: far, target: far},
+		{name: "target leaves", watch: leave, prevOwner: home, owner: home, prevTarget: near, target: far, want: "moved 11.1 km away"},
+		{name: "owner leaves", watch: leave, prevOwner: near, owner: far, prevTarget: home, target: home, want: "moved 11.1 km away"},
+		{name: "leave but approaching", watch: leave, prevOwner: home, owner: home, prevTarget: far, target: near},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := matchDistance(&tt.watch, &database.Account{Name: "Alice"}, tt.prevOwner, tt.owner, tt.prevTarget, tt.target)
+
+			if tt.want == "" {
+				if text != nil {
+					t.Errorf("unexpected alert %q", text.Localize(i18n.Locale{Lang: i18n.EN}))
+				}
+				return
+			}
+
+			if text == nil {
+				t.Fatalf("expected an alert containing %q", tt.want)
+			}
+
+			if got := text.Localize(i18n.Locale{Lang: i18n.EN}); !strings.Contains(got, tt.want) {
+				t.Errorf("alert %q doesn't contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"time"
+)
+
+type UpdateAcceptedData struct {
+	UpdateID int64          `json:"updateId"`
+	Created  time.Time      `json:"created"`
+	Update   api.UpdateData `json:"update"`
+}
+
+type FenceData struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius"`
+}
+
+type FenceEventData struct {
+	Fence FenceData `json:"fence"`
+}
+
+type OfflineEventData struct {
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+type OnlineEventData struct {
+	Created time.Time `json:"created"`
+}
+
+func NewFenceEventData(fence database.Fence) FenceEventData {
+	return FenceEventData{
+		Fence: FenceData{
+			ID:        fence.ID,
+			Name:      fence.Name,
+			Latitude:  fence.Latitude,
+			Longitude: fence.Longitude,
+			Radius:    fence.Radius,
+		},
+	}
+}
@@ -0,0 +1,260 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+)
+
+const (
+	SignatureHeader = "X-Roflbeacon-Signature"
+	TimestampHeader = "X-Roflbeacon-Timestamp"
+	EventHeader     = "X-Roflbeacon-Event"
+	DeliveryHeader  = "X-Roflbeacon-Delivery"
+
+	deliveryBatchSize = 50
+	maxBackoff        = 6 * time.Hour
+)
+
+type Service struct {
+	cfg     *config.Config
+	queries *database.Queries
+	client  *http.Client
+}
+
+func New(di *do.Injector) (*Service, error) {
+	cfg := do.MustInvoke[*config.Config](di)
+
+	return &Service{
+		cfg:     cfg,
+		queries: do.MustInvoke[*database.Queries](di),
+		client:  &http.Client{},
+	}, nil
+}
+
+type event struct {
+	ID      string               `json:"id"`
+	Type    api.WebhookEventType `json:"type"`
+	Created time.Time            `json:"created"`
+	Account api.WebhookAccount   `json:"account"`
+	Data    any                  `json:"data"`
+}
+
+// Publish records a delivery of the event for every enabled subscription that listens to it
+// using the given queries, so that it is committed together with the caller's transaction.
+// Deliveries are sent asynchronously by RunDeliveries.
+func (s *Service) Publish(ctx context.Context, queries *database.Queries, eventType api.WebhookEventType, acc *database.Account, data any) error {
+	subscriptions, err := queries.GetEnabledWebhookSubscriptionsByEvent(ctx, string(eventType))
+	if err != nil {
+		return fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	payload, err := json.Marshal(&event{
+		ID:      uuid.NewString(),
+		Type:    eventType,
+		Created: now,
+		Account: api.WebhookAccount{
+			Id:   acc.ID,
+			Name: acc.Name,
+		},
+		Data: data,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	for _, subscription := range subscriptions {
+		if _, err = queries.CreateWebhookDelivery(ctx, database.CreateWebhookDeliveryParams{
+			SubscriptionID: subscription.ID,
+			Event:          string(eventType),
+			Payload:        payload,
+			NextAttempt:    now,
+		}); err != nil {
+			return fmt.Errorf("create delivery: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) RunDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Tune().Webhooks.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.deliverDue(ctx)
+			ticker.Reset(s.cfg.Tune().Webhooks.PollInterval)
+		}
+	}
+}
+
+// deliverDue claims a batch of due deliveries and sends them. Claiming leases the rows
+// long enough to send the whole batch, so concurrent instances skip them, and the rows
+// of an instance that died mid-batch become due again once the lease expires.
+func (s *Service) deliverDue(ctx context.Context) {
+	now := time.Now()
+
+	rows, err := s.queries.ClaimDueWebhookDeliveries(ctx, database.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: now.Add(s.lease()),
+		Now:        now,
+		MaxCount:   deliveryBatchSize,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim due webhook deliveries",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, row := range rows {
+		s.deliver(ctx, &row.WebhookDelivery, &row.WebhookSubscription)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, delivery *database.WebhookDelivery, subscription *database.WebhookSubscription) {
+	statusCode, err := s.send(ctx, delivery, subscription)
+	if err == nil {
+		if err = s.queries.MarkWebhookDeliveryDelivered(ctx, database.MarkWebhookDeliveryDeliveredParams{
+			ID:             delivery.ID,
+			LastStatusCode: statusCode,
+			Delivered:      util.ToPtr(time.Now()),
+		}); err != nil {
+			slog.ErrorContext(ctx, "Failed to mark webhook delivery as delivered",
+				slog.Int64("delivery_id", delivery.ID),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	attempts := int(delivery.Attempts) + 1
+
+	slog.WarnContext(ctx, "Webhook delivery attempt failed",
+		slog.Int64("delivery_id", delivery.ID),
+		slog.String("url", subscription.Url),
+		slog.Int("attempts", attempts),
+		slog.Any("error", err),
+	)
+
+	errText := err.Error()
+
+	if err = s.queries.MarkWebhookDeliveryAttemptFailed(ctx, database.MarkWebhookDeliveryAttemptFailedParams{
+		ID:             delivery.ID,
+		Status:         string(s.failedAttemptStatus(attempts)),
+		NextAttempt:    time.Now().Add(s.backoff(attempts)),
+		LastError:      &errText,
+		LastStatusCode: statusCode,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to mark webhook delivery attempt as failed",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// lease is how long claimed deliveries stay hidden from other instances, enough to send a whole batch.
+func (s *Service) lease() time.Duration {
+	return deliveryBatchSize*s.cfg.Tune().Webhooks.Timeout + time.Minute
+}
+
+// failedAttemptStatus is the status of a delivery after its attempts-th attempt failed,
+// it is given up once it runs out of attempts.
+func (s *Service) failedAttemptStatus(attempts int) api.WebhookDeliveryStatus {
+	if attempts >= s.cfg.Tune().Webhooks.MaxAttempts {
+		return api.WebhookDeliveryStatusFailed
+	}
+
+	return api.WebhookDeliveryStatusPending
+}
+
+// backoff doubles the delay after every failed attempt, starting from the configured base.
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := float64(s.cfg.Tune().Webhooks.BaseBackoff) * math.Pow(2, float64(attempts-1))
+	if delay > float64(maxBackoff) {
+		return maxBackoff
+	}
+
+	return time.Duration(delay)
+}
+
+func (s *Service) send(ctx context.Context, delivery *database.WebhookDelivery, subscription *database.WebhookSubscription) (*int32, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Tune().Webhooks.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	statusCode := int32(resp.StatusCode) //nolint:gosec
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return &statusCode, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return &statusCode, nil
+}
+
+// Sign computes the hex HMAC-SHA256 of "<timestamp>.<body>" with the subscription secret.
+// Receivers should recompute it and compare it with the signature header.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("read random: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/config"
+	"roflbeacon2/pkg/database"
+	"testing"
+	"time"
+)
+
+const testConfig = `
+telegram:
+  token: test
+  adminChatID: 1
+webhooks:
+  timeout: 10s
+  baseBackoff: 30s
+  maxAttempts: 5
+`
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	return &Service{cfg: cfg, client: &http.Client{}}
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "payload",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"id":"1"}`,
+			want:      "086f6aff7bd084c98679825129c5a64dbad88c760016d6d2c0fb123f27951d54",
+		},
+		{
+			name:      "other secret",
+			secret:    "other",
+			timestamp: "1700000000",
+			body:      `{"id":"1"}`,
+			want:      "0c9dcd041b074d1b31727e0c1f821d11366e9db9f94c18bf202eb66cd0bd4d40",
+		},
+		{
+			name:      "empty",
+			secret:    "",
+			timestamp: "0",
+			body:      "",
+			want:      "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 5, want: 8 * time.Minute},
+		{attempts: 10, want: 256 * time.Minute},
+		{attempts: 11, want: maxBackoff},
+		{attempts: 100, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := s.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestLease(t *testing.T) {
+	s := newTestService(t)
+
+	// every delivery of a batch may run into the timeout before the lease expires
+	if got, timeouts := s.lease(), deliveryBatchSize*10*time.Second; got <= timeouts {
+		t.Errorf("lease() = %v, want more than %v", got, timeouts)
+	}
+}
+
+func TestFailedAttemptStatus(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		attempts int
+		want     api.WebhookDeliveryStatus
+	}{
+		{attempts: 1, want: api.WebhookDeliveryStatusPending},
+		{attempts: 4, want: api.WebhookDeliveryStatusPending},
+		{attempts: 5, want: api.WebhookDeliveryStatusFailed},
+		{attempts: 6, want: api.WebhookDeliveryStatusFailed},
+	}
+
+	for _, tt := range tests {
+		if got := s.failedAttemptStatus(tt.attempts); got != tt.want {
+			t.Errorf("failedAttemptStatus(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	s := newTestService(t)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "ok", statusCode: http.StatusOK},
+		{name: "no content", statusCode: http.StatusNoContent},
+		{name: "not modified", statusCode: http.StatusNotModified, wantErr: true},
+		{name: "server error", statusCode: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var signatureErr string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				want := "sha256=" + Sign("secret", r.Header.Get(TimestampHeader), body)
+				if got := r.Header.Get(SignatureHeader); got != want {
+					signatureErr = "signature " + got + ", want " + want
+				}
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			delivery := &database.WebhookDelivery{ID: 1, Event: "test", Payload: []byte(`{"id":"1"}`)}
+			subscription := &database.WebhookSubscription{Url: server.URL, Secret: "secret"}
+
+			statusCode, err := s.send(context.Background(), delivery, subscription)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if statusCode == nil || int(*statusCode) != tt.statusCode {
+				t.Errorf("send() status code = %v, want %d", statusCode, tt.statusCode)
+			}
+
+			if signatureErr != "" {
+				t.Error(signatureErr)
+			}
+		})
+	}
+}
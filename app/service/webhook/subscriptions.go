@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/database"
+	"roflbeacon2/pkg/util"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+)
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]database.WebhookSubscription, error) {
+	subscriptions, err := s.queries.GetAllWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *Service) CreateSubscription(ctx context.Context, input api.WebhookSubscriptionInput) (database.WebhookSubscription, error) {
+	secret := util.GetPtrOrZero(input.Secret)
+	if secret == "" {
+		generated, err := GenerateSecret()
+		if err != nil {
+			return database.WebhookSubscription{}, fmt.Errorf("generate secret: %w", err)
+		}
+
+		secret = generated
+	}
+
+	subscription, err := s.queries.CreateWebhookSubscription(ctx, database.CreateWebhookSubscriptionParams{
+		Url:     input.Url,
+		Secret:  secret,
+		Events:  eventsToStrings(input.Events),
+		Enabled: util.GetPtrOrDefault(input.Enabled, true),
+		Created: time.Now(),
+	})
+	if err != nil {
+		return database.WebhookSubscription{}, fmt.Errorf("create subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, id int64, input api.WebhookSubscriptionInput) (database.WebhookSubscription, error) {
+	existing, err := s.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return database.WebhookSubscription{}, fmt.Errorf("get subscription: %w", err)
+	}
+
+	subscription, err := s.queries.UpdateWebhookSubscription(ctx, database.UpdateWebhookSubscriptionParams{
+		ID:      id,
+		Url:     input.Url,
+		Secret:  util.GetPtrOrDefault(input.Secret, existing.Secret),
+		Events:  eventsToStrings(input.Events),
+		Enabled: util.GetPtrOrDefault(input.Enabled, existing.Enabled),
+	})
+	if err != nil {
+		return database.WebhookSubscription{}, fmt.Errorf("update subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription removes the subscription with its delivery log and reports whether it existed.
+func (s *Service) DeleteSubscription(ctx context.Context, id int64) (bool, error) {
+	count, err := s.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("delete subscription: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, subscriptionID int64, status *api.WebhookDeliveryStatus, limit int) ([]database.WebhookDelivery, error) {
+	if _, err := s.queries.GetWebhookSubscription(ctx, subscriptionID); err != nil {
+		return nil, fmt.Errorf("get subscription: %w", err)
+	}
+
+	var statusStr *string
+	if status != nil {
+		statusStr = util.ToPtr(string(*status))
+	}
+
+	deliveries, err := s.queries.GetWebhookDeliveriesBySubscriptionID(ctx, database.GetWebhookDeliveriesBySubscriptionIDParams{
+		SubscriptionID: subscriptionID,
+		Status:         statusStr,
+		MaxCount:       int32(limit), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery schedules the delivery to be sent again right away, regardless of its status.
+func (s *Service) ReplayDelivery(ctx context.Context, id int64) (database.WebhookDelivery, error) {
+	delivery, err := s.queries.ReplayWebhookDelivery(ctx, database.ReplayWebhookDeliveryParams{
+		ID:          id,
+		NextAttempt: time.Now(),
+	})
+	if err != nil {
+		return database.WebhookDelivery{}, fmt.Errorf("replay delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ReplayFailed reschedules every failed delivery of the subscription and returns their count.
+func (s *Service) ReplayFailed(ctx context.Context, subscriptionID int64) (int64, error) {
+	if _, err := s.queries.GetWebhookSubscription(ctx, subscriptionID); err != nil {
+		return 0, fmt.Errorf("get subscription: %w", err)
+	}
+
+	count, err := s.queries.ReplayFailedWebhookDeliveries(ctx, database.ReplayFailedWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		NextAttempt:    time.Now(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("replay deliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+func eventsToStrings(events []api.WebhookEventType) []string {
+	return pie.Map(events, func(e api.WebhookEventType) string {
+		return string(e)
+	})
+}
@@ -1,3 +1,27 @@
 package build
 
+import (
+	"runtime/debug"
+	"time"
+)
+
 var Tag = "?"
+
+// Started is roughly when the process started.
+var Started = time.Now()
+
+// Revision is the VCS commit the toolchain recorded in the binary, empty if there is none.
+func Revision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+
+	return ""
+}
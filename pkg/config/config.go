@@ -1,16 +1,33 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"reflect"
+	"roflbeacon2/pkg/i18n"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	TelegramModePolling = "polling"
+	TelegramModeWebhook = "webhook"
+)
+
+// Config holds the settings that are fixed for the lifetime of the process, the reloadable ones are behind Tune.
 type Config struct {
 	BaseApiURL string `yaml:"baseApiURL" validate:"required"`
 
+	Server struct {
+		Port        int `yaml:"port" validate:"min=1,max=65535"`
+		MetricsPort int `yaml:"metricsPort" validate:"min=1,max=65535"`
+	} `yaml:"server"`
+
 	Log struct {
 		TelegramToken  string `yaml:"telegramToken"`
 		TelegramChatID string `yaml:"telegramChatID"`
@@ -19,30 +36,276 @@ type Config struct {
 	Telegram struct {
 		Token       string `yaml:"token" validate:"required"`
 		AdminChatID int64  `yaml:"adminChatID" validate:"required"`
+		ServerURL   string `yaml:"serverURL"`
+
+		// StateTimeout is how long a multi-step command waits for the next answer
+		StateTimeout time.Duration `yaml:"stateTimeout"`
+
+		// Mode is how updates are received: TelegramModePolling or TelegramModeWebhook
+		Mode string `yaml:"mode" validate:"oneof=polling webhook"`
+		// WebhookURL is the public URL Telegram posts updates to, BaseApiURL + WebhookPath by default
+		WebhookURL    string `yaml:"webhookURL"`
+		WebhookPath   string `yaml:"webhookPath" validate:"startswith=/"`
+		WebhookSecret string `yaml:"webhookSecret" validate:"required_if=Mode webhook,max=256"`
 	} `yaml:"telegram"`
 
+	Map struct {
+		TileURL      string        `yaml:"tileURL"`
+		DisableTiles bool          `yaml:"disableTiles"`
+		TileTimeout  time.Duration `yaml:"tileTimeout"`
+		Width        int           `yaml:"width"`
+		Height       int           `yaml:"height"`
+	} `yaml:"map"`
+
+	Notify struct {
+		WebhookTimeout time.Duration `yaml:"webhookTimeout"`
+
+		SMTP struct {
+			Host     string `yaml:"host"`
+			Port     int    `yaml:"port"`
+			Username string `yaml:"username"`
+			Password string `yaml:"password"`
+			From     string `yaml:"from"`
+		} `yaml:"smtp"`
+
+		Ntfy struct {
+			ServerURL string `yaml:"serverURL"`
+			Token     string `yaml:"token"`
+		} `yaml:"ntfy"`
+
+		Gotify struct {
+			ServerURL string `yaml:"serverURL"`
+		} `yaml:"gotify"`
+	} `yaml:"notify"`
+
 	DB struct {
 		User     string `yaml:"user" validate:"required"`
 		Pass     string `yaml:"pass" validate:"required"`
 		Host     string `yaml:"host" validate:"required"`
 		Database string `yaml:"database" validate:"required"`
+		SSLMode  string `yaml:"sslMode" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+
+		MaxConns          int           `yaml:"maxConns" validate:"min=1,max=1000"`
+		MinConns          int           `yaml:"minConns" validate:"min=0,ltefield=MaxConns"`
+		MaxConnLifetime   time.Duration `yaml:"maxConnLifetime"`
+		MaxConnIdleTime   time.Duration `yaml:"maxConnIdleTime"`
+		HealthCheckPeriod time.Duration `yaml:"healthCheckPeriod"`
+		ConnectTimeout    time.Duration `yaml:"connectTimeout"`
+
+		StatementTimeout         time.Duration `yaml:"statementTimeout"`
+		IdleInTransactionTimeout time.Duration `yaml:"idleInTransactionTimeout"`
 	} `yaml:"db"`
+
+	path     string
+	tunables atomic.Pointer[Tunables]
+}
+
+// Tunables are the settings that are reloaded on SIGHUP without a restart.
+type Tunables struct {
+	Offline struct {
+		Threshold     time.Duration `yaml:"threshold" validate:"min=1m"`
+		CheckInterval time.Duration `yaml:"checkInterval" validate:"min=1s"`
+	} `yaml:"offline"`
+
+	Battery struct {
+		LowThreshold int `yaml:"lowThreshold" validate:"min=0,max=99"`
+	} `yaml:"battery"`
+
+	Driving struct {
+		SpeedLimitKmh float64       `yaml:"speedLimitKmh" validate:"min=0,max=300"`
+		StartSpeedKmh float64       `yaml:"startSpeedKmh" validate:"gt=0"`
+		StopAfter     time.Duration `yaml:"stopAfter" validate:"min=1s"`
+	} `yaml:"driving"`
+
+	SOS struct {
+		FirstInterval time.Duration `yaml:"firstInterval" validate:"min=1s"`
+		MaxInterval   time.Duration `yaml:"maxInterval" validate:"gtefield=FirstInterval"`
+	} `yaml:"sos"`
+
+	Watch struct {
+		DefaultTTL time.Duration `yaml:"defaultTTL" validate:"min=1m"`
+	} `yaml:"watch"`
+
+	Alerts struct {
+		PollInterval time.Duration `yaml:"pollInterval" validate:"min=100ms"`
+		BaseBackoff  time.Duration `yaml:"baseBackoff" validate:"min=1s"`
+		MaxAttempts  int           `yaml:"maxAttempts" validate:"min=1"`
+	} `yaml:"alerts"`
+
+	Webhooks struct {
+		Timeout      time.Duration `yaml:"timeout" validate:"min=1s"`
+		PollInterval time.Duration `yaml:"pollInterval" validate:"min=100ms"`
+		BaseBackoff  time.Duration `yaml:"baseBackoff" validate:"min=1s"`
+		MaxAttempts  int           `yaml:"maxAttempts" validate:"min=1"`
+	} `yaml:"webhooks"`
+
+	Matching struct {
+		// AccuracyFactor is how many reported accuracies are added to fence and proximity radii
+		AccuracyFactor float64 `yaml:"accuracyFactor" validate:"min=0,max=10"`
+	} `yaml:"matching"`
+
+	// RateLimits are per client IP
+	RateLimits struct {
+		IngestRps int `yaml:"ingestRps" validate:"min=1"`
+		SosRpm    int `yaml:"sosRpm" validate:"min=1"`
+		ShareRpm  int `yaml:"shareRpm" validate:"min=1"`
+	} `yaml:"rateLimits"`
+
+	// Texts override the bot and alert messages: language → message key → format
+	Texts map[string]map[string]string `yaml:"texts"`
+}
+
+// file is the layout of the config file, the tunables share the top level with the rest.
+type file struct {
+	Config   `yaml:",inline"`
+	Tunables `yaml:",inline"`
+}
+
+// Load reads the config file at path, a missing file is treated as empty so that
+// the whole config can come from the environment, see applyEnv.
+func Load(path string) (*Config, error) {
+	result, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &result.Config
+	cfg.path = path
+	cfg.tunables.Store(&result.Tunables)
+
+	return cfg, nil
 }
 
-func Load() (*Config, error) {
-	data, err := os.ReadFile("config.yaml")
+// Reload re-reads the config file and applies its tunables, changes to the other settings need a restart.
+func (c *Config) Reload() (*Tunables, error) {
+	result, err := read(c.path)
 	if err != nil {
+		return nil, err
+	}
+
+	c.tunables.Store(&result.Tunables)
+
+	return &result.Tunables, nil
+}
+
+// Tune returns the current tunables, they may change between calls.
+func (c *Config) Tune() *Tunables {
+	return c.tunables.Load()
+}
+
+func read(path string) (*file, error) {
+	var result file
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var result Config
+	// zero is a valid value for these, so their defaults are set before decoding instead of after it
+	result.Battery.LowThreshold = 15
+	result.Matching.AccuracyFactor = 2
+
 	if err := yaml.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML config: %w", err)
 	}
 
+	if err := applyEnv(reflect.ValueOf(&result).Elem(), envPrefix); err != nil {
+		return nil, fmt.Errorf("failed to apply environment: %w", err)
+	}
+
 	if result.BaseApiURL == "" {
 		result.BaseApiURL = "https://beacon.rofleksey.ru"
 	}
+	if result.Server.Port == 0 {
+		result.Server.Port = 8080
+	}
+	if result.Server.MetricsPort == 0 {
+		result.Server.MetricsPort = 8081
+	}
+	if result.Telegram.StateTimeout == 0 {
+		result.Telegram.StateTimeout = 15 * time.Minute
+	}
+	if result.Telegram.Mode == "" {
+		result.Telegram.Mode = TelegramModePolling
+	}
+	if result.Telegram.WebhookPath == "" {
+		result.Telegram.WebhookPath = "/telegram/webhook"
+	}
+	if result.Telegram.WebhookURL == "" {
+		result.Telegram.WebhookURL = result.BaseApiURL + result.Telegram.WebhookPath
+	}
+	if result.Map.TileURL == "" {
+		result.Map.TileURL = "https://tile.openstreetmap.org/{z}/{x}/{y}.png"
+	}
+	if result.Map.TileTimeout == 0 {
+		result.Map.TileTimeout = 5 * time.Second
+	}
+	if result.Map.Width == 0 {
+		result.Map.Width = 800
+	}
+	if result.Map.Height == 0 {
+		result.Map.Height = 600
+	}
+	if result.Notify.WebhookTimeout == 0 {
+		result.Notify.WebhookTimeout = 10 * time.Second
+	}
+	if result.Notify.SMTP.Port == 0 {
+		result.Notify.SMTP.Port = 587
+	}
+	if result.Notify.Ntfy.ServerURL == "" {
+		result.Notify.Ntfy.ServerURL = "https://ntfy.sh"
+	}
+	if result.Offline.Threshold == 0 {
+		result.Offline.Threshold = 30 * time.Minute
+	}
+	if result.Offline.CheckInterval == 0 {
+		result.Offline.CheckInterval = 15 * time.Minute
+	}
+	if result.Driving.StartSpeedKmh == 0 {
+		result.Driving.StartSpeedKmh = 20
+	}
+	if result.Driving.StopAfter == 0 {
+		result.Driving.StopAfter = 5 * time.Minute
+	}
+	if result.SOS.FirstInterval == 0 {
+		result.SOS.FirstInterval = time.Minute
+	}
+	if result.SOS.MaxInterval == 0 {
+		result.SOS.MaxInterval = 15 * time.Minute
+	}
+	if result.Watch.DefaultTTL == 0 {
+		result.Watch.DefaultTTL = 24 * time.Hour
+	}
+	if result.Alerts.PollInterval == 0 {
+		result.Alerts.PollInterval = 2 * time.Second
+	}
+	if result.Alerts.BaseBackoff == 0 {
+		result.Alerts.BaseBackoff = 10 * time.Second
+	}
+	if result.Alerts.MaxAttempts == 0 {
+		result.Alerts.MaxAttempts = 12
+	}
+	if result.Webhooks.Timeout == 0 {
+		result.Webhooks.Timeout = 10 * time.Second
+	}
+	if result.Webhooks.PollInterval == 0 {
+		result.Webhooks.PollInterval = 5 * time.Second
+	}
+	if result.Webhooks.BaseBackoff == 0 {
+		result.Webhooks.BaseBackoff = 30 * time.Second
+	}
+	if result.Webhooks.MaxAttempts == 0 {
+		result.Webhooks.MaxAttempts = 10
+	}
+	if result.RateLimits.IngestRps == 0 {
+		result.RateLimits.IngestRps = 3
+	}
+	if result.RateLimits.SosRpm == 0 {
+		result.RateLimits.SosRpm = 5
+	}
+	if result.RateLimits.ShareRpm == 0 {
+		result.RateLimits.ShareRpm = 60
+	}
 	if result.DB.User == "" {
 		result.DB.User = "postgres"
 	}
@@ -55,11 +318,42 @@ func Load() (*Config, error) {
 	if result.DB.Database == "" {
 		result.DB.Database = "roflbeacon2"
 	}
+	if result.DB.SSLMode == "" {
+		result.DB.SSLMode = "disable"
+	}
+	if result.DB.MaxConns == 0 {
+		result.DB.MaxConns = 30
+	}
+	if result.DB.MinConns == 0 {
+		result.DB.MinConns = min(5, result.DB.MaxConns)
+	}
+	if result.DB.MaxConnLifetime == 0 {
+		result.DB.MaxConnLifetime = time.Hour
+	}
+	if result.DB.MaxConnIdleTime == 0 {
+		result.DB.MaxConnIdleTime = 30 * time.Minute
+	}
+	if result.DB.HealthCheckPeriod == 0 {
+		result.DB.HealthCheckPeriod = time.Minute
+	}
+	if result.DB.ConnectTimeout == 0 {
+		result.DB.ConnectTimeout = 10 * time.Second
+	}
+	if result.DB.StatementTimeout == 0 {
+		result.DB.StatementTimeout = 30 * time.Second
+	}
+	if result.DB.IdleInTransactionTimeout == 0 {
+		result.DB.IdleInTransactionTimeout = time.Minute
+	}
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err := validate.Struct(result); err != nil {
+	if err := validate.Struct(&result); err != nil {
 		return nil, fmt.Errorf("failed to validate config: %w", err)
 	}
 
+	if err := i18n.ValidateOverrides(result.Texts); err != nil {
+		return nil, fmt.Errorf("failed to validate texts: %w", err)
+	}
+
 	return &result, nil
 }
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadKeepsExplicitZero(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want func(*file) bool
+	}{
+		{
+			name: "battery default",
+			want: func(f *file) bool { return f.Battery.LowThreshold == 15 },
+		},
+		{
+			name: "battery explicit zero",
+			yaml: "battery:\n  lowThreshold: 0\n",
+			want: func(f *file) bool { return f.Battery.LowThreshold == 0 },
+		},
+		{
+			name: "battery explicit value",
+			yaml: "battery:\n  lowThreshold: 30\n",
+			want: func(f *file) bool { return f.Battery.LowThreshold == 30 },
+		},
+		{
+			name: "accuracy factor default",
+			want: func(f *file) bool { return f.Matching.AccuracyFactor == 2 },
+		},
+		{
+			name: "accuracy factor explicit zero",
+			yaml: "matching:\n  accuracyFactor: 0\n",
+			want: func(f *file) bool { return f.Matching.AccuracyFactor == 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := read(writeConfig(t, tt.yaml))
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+
+			if !tt.want(result) {
+				t.Errorf("unexpected tunables: battery %+v, matching %+v", result.Battery, result.Matching)
+			}
+		})
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content = "telegram:\n  token: test\n  adminChatID: 1\n" + content
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	return path
+}
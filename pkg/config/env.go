@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const envPrefix = "BEACON_"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides the fields with the environment variables named after their YAML path,
+// e.g. BEACON_TELEGRAM_ADMIN_CHAT_ID for telegram.adminChatID. A variable with the _FILE suffix
+// holds the path of a file with the value instead, for secrets mounted by Docker or Kubernetes.
+func applyEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		value := v.Field(i)
+
+		if slices.Contains(tag[1:], "inline") {
+			if err := applyEnv(value, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := tag[0]
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		name := prefix + envName(key)
+
+		if value.Kind() == reflect.Struct {
+			if err := applyEnv(value, name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, ok, err := lookupEnv(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if err = setValue(value, raw); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func lookupEnv(name string) (string, bool, error) {
+	if raw, ok := os.LookupEnv(name); ok {
+		return raw, true, nil
+	}
+
+	path, ok := os.LookupEnv(name + "_FILE")
+	if !ok {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", name, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
+
+// envName converts a camelCase YAML key to UPPER_SNAKE_CASE keeping acronyms together, adminChatID → ADMIN_CHAT_ID.
+func envName(key string) string {
+	var builder strings.Builder
+
+	runes := []rune(key)
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				builder.WriteByte('_')
+			}
+		}
+
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// schemaModels are the tables the generated queries are compiled against, keep in sync with models.go.
+var schemaModels = map[string]any{
+	"account":              Account{},
+	"alert_outbox":         AlertOutbox{},
+	"bot_state":            BotState{},
+	"fence":                Fence{},
+	"live_location":        LiveLocation{},
+	"migration":            Migration{},
+	"proximity_rule":       ProximityRule{},
+	"share_access":         ShareAccess{},
+	"share_link":           ShareLink{},
+	"sos_event":            SosEvent{},
+	"sos_incident":         SosIncident{},
+	"updates":              Update{},
+	"watch":                Watch{},
+	"webhook_delivery":     WebhookDelivery{},
+	"webhook_subscription": WebhookSubscription{},
+}
+
+type column struct {
+	dataType string
+	nullable bool
+}
+
+// CheckSchema reports the columns of the live database that the generated models can't scan:
+// missing ones, incompatible types and nullable columns behind non-pointer fields.
+// Extra columns are fine, the queries name the columns they read.
+func CheckSchema(ctx context.Context, queries *Queries) error {
+	rows, err := queries.GetSchemaColumns(ctx)
+	if err != nil {
+		return fmt.Errorf("get schema columns: %w", err)
+	}
+
+	live := make(map[string]map[string]column)
+	for _, row := range rows {
+		if live[row.TableName] == nil {
+			live[row.TableName] = make(map[string]column)
+		}
+		live[row.TableName][row.ColumnName] = column{dataType: row.DataType, nullable: row.Nullable}
+	}
+
+	var problems []error
+
+	for _, table := range slices.Sorted(maps.Keys(schemaModels)) {
+		model := schemaModels[table]
+
+		columns, ok := live[table]
+		if !ok {
+			problems = append(problems, fmt.Errorf("table %s is missing", table))
+			continue
+		}
+
+		modelType := reflect.TypeOf(model)
+
+		for i := range modelType.NumField() {
+			field := modelType.Field(i)
+			name := field.Tag.Get("db")
+
+			col, ok := columns[name]
+			if !ok {
+				problems = append(problems, fmt.Errorf("column %s.%s is missing", table, name))
+				continue
+			}
+
+			if expected := dataTypes(field.Type); !slices.Contains(expected, col.dataType) {
+				problems = append(problems, fmt.Errorf("column %s.%s is %s, expected %v", table, name, col.dataType, expected))
+			}
+
+			if col.nullable && field.Type.Kind() != reflect.Pointer && field.Type.Kind() != reflect.Slice {
+				problems = append(problems, fmt.Errorf("column %s.%s is nullable, expected NOT NULL", table, name))
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// dataTypes lists the information_schema types that scan into the Go type.
+func dataTypes(t reflect.Type) []string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return []string{"timestamp without time zone", "timestamp with time zone", "date"}
+	}
+
+	switch t.Kind() {
+	case reflect.Int64:
+		return []string{"bigint"}
+	case reflect.Int32:
+		return []string{"integer"}
+	case reflect.Float64:
+		return []string{"double precision"}
+	case reflect.Bool:
+		return []string{"boolean"}
+	case reflect.String:
+		return []string{"character varying", "text"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return []string{"jsonb", "json", "bytea"}
+		}
+
+		return []string{"ARRAY"}
+	default:
+		return []string{"jsonb", "json"}
+	}
+}
@@ -11,29 +11,150 @@ import (
 )
 
 type Account struct {
-	ID     int64
-	Token  string
-	Name   string
-	ChatID *int64
-	Status api.AccountStatus
+	ID       int64               `db:"id"`
+	Token    string              `db:"token"`
+	Name     string              `db:"name"`
+	ChatID   *int64              `db:"chat_id"`
+	Status   api.AccountStatus   `db:"status"`
+	Settings api.AccountSettings `db:"settings"`
+}
+
+type AlertOutbox struct {
+	ID          int64      `db:"id"`
+	AccountID   int64      `db:"account_id"`
+	Event       string     `db:"event"`
+	Channel     string     `db:"channel"`
+	Target      *string    `db:"target"`
+	Title       string     `db:"title"`
+	Text        string     `db:"text"`
+	Status      string     `db:"status"`
+	Attempts    int32      `db:"attempts"`
+	NextAttempt time.Time  `db:"next_attempt"`
+	LastError   *string    `db:"last_error"`
+	Created     time.Time  `db:"created"`
+	Sent        *time.Time `db:"sent"`
+}
+
+type BotState struct {
+	ChatID  int64     `db:"chat_id"`
+	Stage   string    `db:"stage"`
+	Data    []byte    `db:"data"`
+	Expires time.Time `db:"expires"`
 }
 
 type Fence struct {
-	ID        int64
-	Name      string
-	Longitude float64
-	Latitude  float64
-	Radius    float64
+	ID        int64             `db:"id"`
+	Name      string            `db:"name"`
+	Longitude float64           `db:"longitude"`
+	Latitude  float64           `db:"latitude"`
+	Radius    float64           `db:"radius"`
+	Settings  api.FenceSettings `db:"settings"`
+}
+
+type LiveLocation struct {
+	ID        int64     `db:"id"`
+	ChatID    int64     `db:"chat_id"`
+	AccountID int64     `db:"account_id"`
+	MessageID int64     `db:"message_id"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
 }
 
 type Migration struct {
-	ID      string
-	Applied time.Time
+	ID       string    `db:"id"`
+	Applied  time.Time `db:"applied"`
+	Checksum string    `db:"checksum"`
+}
+
+type ProximityRule struct {
+	ID              int64      `db:"id"`
+	FirstAccountID  int64      `db:"first_account_id"`
+	SecondAccountID int64      `db:"second_account_id"`
+	Distance        float64    `db:"distance"`
+	NotifyMeet      bool       `db:"notify_meet"`
+	NotifyPart      bool       `db:"notify_part"`
+	Together        bool       `db:"together"`
+	Changed         *time.Time `db:"changed"`
+	Created         time.Time  `db:"created"`
+}
+
+type ShareAccess struct {
+	ID        int64     `db:"id"`
+	ShareID   int64     `db:"share_id"`
+	Created   time.Time `db:"created"`
+	Kind      string    `db:"kind"`
+	Ip        string    `db:"ip"`
+	UserAgent string    `db:"user_agent"`
+}
+
+type ShareLink struct {
+	ID        int64      `db:"id"`
+	Token     string     `db:"token"`
+	AccountID int64      `db:"account_id"`
+	Scope     string     `db:"scope"`
+	Precision int32      `db:"precision"`
+	Created   time.Time  `db:"created"`
+	Expires   time.Time  `db:"expires"`
+	Revoked   *time.Time `db:"revoked"`
+}
+
+type SosEvent struct {
+	ID        int64     `db:"id"`
+	SosID     int64     `db:"sos_id"`
+	Created   time.Time `db:"created"`
+	Type      string    `db:"type"`
+	AccountID *int64    `db:"account_id"`
+	Details   *string   `db:"details"`
+}
+
+type SosIncident struct {
+	ID              int64      `db:"id"`
+	AccountID       int64      `db:"account_id"`
+	Created         time.Time  `db:"created"`
+	Message         *string    `db:"message"`
+	EscalationLevel int32      `db:"escalation_level"`
+	NextEscalation  time.Time  `db:"next_escalation"`
+	AcknowledgedBy  *int64     `db:"acknowledged_by"`
+	Acknowledged    *time.Time `db:"acknowledged"`
 }
 
 type Update struct {
-	ID        int64
-	AccountID int64
-	Created   time.Time
-	Data      api.UpdateData
+	ID        int64          `db:"id"`
+	AccountID int64          `db:"account_id"`
+	Created   time.Time      `db:"created"`
+	Data      api.UpdateData `db:"data"`
+}
+
+type Watch struct {
+	ID        int64     `db:"id"`
+	OwnerID   int64     `db:"owner_id"`
+	TargetID  int64     `db:"target_id"`
+	FenceID   *int64    `db:"fence_id"`
+	Distance  *float64  `db:"distance"`
+	Direction string    `db:"direction"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
+}
+
+type WebhookDelivery struct {
+	ID             int64      `db:"id"`
+	SubscriptionID int64      `db:"subscription_id"`
+	Event          string     `db:"event"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int32      `db:"attempts"`
+	NextAttempt    time.Time  `db:"next_attempt"`
+	LastError      *string    `db:"last_error"`
+	LastStatusCode *int32     `db:"last_status_code"`
+	Created        time.Time  `db:"created"`
+	Delivered      *time.Time `db:"delivered"`
+}
+
+type WebhookSubscription struct {
+	ID      int64     `db:"id"`
+	Url     string    `db:"url"`
+	Secret  string    `db:"secret"`
+	Events  []string  `db:"events"`
+	Enabled bool      `db:"enabled"`
+	Created time.Time `db:"created"`
 }
@@ -1,9 +1,65 @@
 package database
 
-import "roflbeacon2/pkg/util"
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/i18n"
+	"roflbeacon2/pkg/maplink"
+	"roflbeacon2/pkg/util"
+	"slices"
+)
 
 func (f *Fence) Contains(lat float64, lon float64, accuracy float64) bool {
 	distMeters := util.HaversineDistance(f.Latitude, f.Longitude, lat, lon)
 
 	return distMeters < f.Radius+accuracy
 }
+
+func (r *ProximityRule) Contains(lat1, lon1, lat2, lon2 float64, accuracy float64) bool {
+	distMeters := util.HaversineDistance(lat1, lon1, lat2, lon2)
+
+	return distMeters < r.Distance+accuracy
+}
+
+func (r *ProximityRule) OtherAccountID(accountID int64) int64 {
+	if r.FirstAccountID == accountID {
+		return r.SecondAccountID
+	}
+
+	return r.FirstAccountID
+}
+
+// Holds reports whether the watch condition is already met: the target is inside the fence
+// or within the distance from the owner for arrive watches and outside for leave watches.
+// Distance watches never hold while either location is unknown.
+func (w *Watch) Holds(target *Account, ownerLoc, targetLoc *api.LocationData) bool {
+	var inside bool
+
+	if w.FenceID != nil {
+		inside = slices.Contains(target.Status.InsideFences, *w.FenceID)
+	} else {
+		if ownerLoc == nil || targetLoc == nil {
+			return false
+		}
+
+		inside = util.HaversineDistance(ownerLoc.Latitude, ownerLoc.Longitude, targetLoc.Latitude, targetLoc.Longitude) <= util.GetPtrOrZero(w.Distance)
+	}
+
+	return inside == (w.Direction == string(api.WatchDirectionArrive))
+}
+
+// Lang is the language the account reads bot messages and alerts in.
+func (a *Account) Lang() i18n.Lang {
+	if a.Settings.Language == nil {
+		return i18n.Default
+	}
+
+	return i18n.Parse(string(*a.Settings.Language))
+}
+
+// Locale is how bot messages and alerts are rendered for the account.
+func (a *Account) Locale() i18n.Locale {
+	return i18n.Locale{
+		Lang: a.Lang(),
+		Maps: maplink.Get(string(util.GetPtrOrZero(a.Settings.MapProvider))),
+	}
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"roflbeacon2/app/api"
+	"roflbeacon2/pkg/util"
+	"testing"
+)
+
+func TestWatchHolds(t *testing.T) {
+	home := &api.LocationData{Latitude: 55.7558, Longitude: 37.6173}
+	// about 1.1 km north of home
+	near := &api.LocationData{Latitude: 55.7658, Longitude: 37.6173}
+
+	arrive, leave := string(api.WatchDirectionArrive), string(api.WatchDirectionLeave)
+	inside := &Account{Status: api.AccountStatus{InsideFences: []int64{1, 2}}}
+	outside := &Account{Status: api.AccountStatus{InsideFences: []int64{2}}}
+
+	tests := []struct {
+		name      string
+		watch     Watch
+		target    *Account
+		ownerLoc  *api.LocationData
+		targetLoc *api.LocationData
+		want      bool
+	}{
+		{name: "arrive fence inside", watch: Watch{FenceID: util.ToPtr[int64](1), Direction: arrive}, target: inside, want: true},
+		{name: "arrive fence outside", watch: Watch{FenceID: util.ToPtr[int64](1), Direction: arrive}, target: outside, want: false},
+		{name: "leave fence inside", watch: Watch{FenceID: util.ToPtr[int64](1), Direction: leave}, target: inside, want: false},
+		{name: "leave fence outside", watch: Watch{FenceID: util.ToPtr[int64](1), Direction: leave}, target: outside, want: true},
+		{name: "arrive within distance", watch: Watch{Distance: util.ToPtr(2000.0), Direction: arrive}, target: outside, ownerLoc: home, targetLoc: near, want: true},
+		{name: "arrive beyond distance", watch: Watch{Distance: util.ToPtr(500.0), Direction: arrive}, target: outside, ownerLoc: home, targetLoc: near, want: false},
+		{name: "leave beyond distance", watch: Watch{Distance: util.ToPtr(500.0), Direction: leave}, target: outside, ownerLoc: home, targetLoc: near, want: true},
+		{name: "leave within distance", watch: Watch{Distance: util.ToPtr(2000.0), Direction: leave}, target: outside, ownerLoc: home, targetLoc: near, want: false},
+		{name: "unknown owner location", watch: Watch{Distance: util.ToPtr(500.0), Direction: leave}, target: outside, targetLoc: near, want: false},
+		{name: "unknown target location", watch: Watch{Distance: util.ToPtr(2000.0), Direction: arrive}, target: outside, ownerLoc: home, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.watch.Holds(tt.target, tt.ownerLoc, tt.targetLoc); got != tt.want {
+				t.Errorf("Holds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
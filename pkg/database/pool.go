@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"roflbeacon2/pkg/config"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPool connects to the database described by the DB section of the config.
+func NewPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	db := cfg.DB
+
+	connURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(db.User, db.Pass),
+		Host:     db.Host,
+		Path:     "/" + db.Database,
+		RawQuery: url.Values{"sslmode": {db.SSLMode}}.Encode(),
+	}
+
+	poolConf, err := pgxpool.ParseConfig(connURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	poolConf.MaxConns = int32(db.MaxConns) //nolint:gosec
+	poolConf.MinConns = int32(db.MinConns) //nolint:gosec
+	poolConf.MaxConnLifetime = db.MaxConnLifetime
+	poolConf.MaxConnIdleTime = db.MaxConnIdleTime
+	poolConf.HealthCheckPeriod = db.HealthCheckPeriod
+	poolConf.ConnConfig.ConnectTimeout = db.ConnectTimeout
+
+	poolConf.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(db.StatementTimeout.Milliseconds(), 10)
+	poolConf.ConnConfig.RuntimeParams["idle_in_transaction_session_timeout"] = strconv.FormatInt(db.IdleInTransactionTimeout.Milliseconds(), 10)
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConf)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	return pool, nil
+}
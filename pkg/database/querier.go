@@ -6,71 +6,347 @@ package database
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	//AcknowledgeSosIncident
+	//
+	//  UPDATE sos_incident
+	//  SET acknowledged_by = $2,
+	//      acknowledged    = $3
+	//  WHERE id = $1
+	//    AND acknowledged IS NULL
+	//  RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+	AcknowledgeSosIncident(ctx context.Context, arg AcknowledgeSosIncidentParams) (SosIncident, error)
+	//AcquireMigrationLock
+	//
+	//  SELECT pg_advisory_lock($1::bigint)
+	AcquireMigrationLock(ctx context.Context, key int64) error
+	//ClaimDueAlertOutbox
+	//
+	//  UPDATE alert_outbox
+	//  SET status       = 'sending',
+	//      next_attempt = $1
+	//  FROM account
+	//  WHERE account.id = alert_outbox.account_id
+	//    AND alert_outbox.id IN (SELECT due.id
+	//                            FROM alert_outbox due
+	//                            WHERE due.status IN ('pending', 'sending')
+	//                              AND due.next_attempt <= $2
+	//                            ORDER BY due.id
+	//                            LIMIT $3 FOR UPDATE SKIP LOCKED)
+	//  RETURNING alert_outbox.id, alert_outbox.account_id, alert_outbox.event, alert_outbox.channel, alert_outbox.target, alert_outbox.title, alert_outbox.text, alert_outbox.status, alert_outbox.attempts, alert_outbox.next_attempt, alert_outbox.last_error, alert_outbox.created, alert_outbox.sent, account.id, account.token, account.name, account.chat_id, account.status, account.settings
+	ClaimDueAlertOutbox(ctx context.Context, arg ClaimDueAlertOutboxParams) ([]ClaimDueAlertOutboxRow, error)
+	//ClaimDueSosIncidents
+	//
+	//  UPDATE sos_incident
+	//  SET next_escalation = $1
+	//  WHERE id IN (SELECT due.id
+	//               FROM sos_incident due
+	//               WHERE due.acknowledged IS NULL
+	//                 AND due.next_escalation <= $2
+	//               ORDER BY due.id
+	//               FOR UPDATE SKIP LOCKED)
+	//  RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+	ClaimDueSosIncidents(ctx context.Context, arg ClaimDueSosIncidentsParams) ([]SosIncident, error)
+	//ClaimDueWebhookDeliveries
+	//
+	//  UPDATE webhook_delivery
+	//  SET status       = 'sending',
+	//      next_attempt = $1
+	//  FROM webhook_subscription
+	//  WHERE webhook_subscription.id = webhook_delivery.subscription_id
+	//    AND webhook_delivery.id IN (SELECT due.id
+	//                                FROM webhook_delivery due
+	//                                WHERE due.status IN ('pending', 'sending')
+	//                                  AND due.next_attempt <= $2
+	//                                ORDER BY due.next_attempt
+	//                                LIMIT $3 FOR UPDATE SKIP LOCKED)
+	//  RETURNING webhook_delivery.id, webhook_delivery.subscription_id, webhook_delivery.event, webhook_delivery.payload, webhook_delivery.status, webhook_delivery.attempts, webhook_delivery.next_attempt, webhook_delivery.last_error, webhook_delivery.last_status_code, webhook_delivery.created, webhook_delivery.delivered, webhook_subscription.id, webhook_subscription.url, webhook_subscription.secret, webhook_subscription.events, webhook_subscription.enabled, webhook_subscription.created
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
 	//CreateAccount
 	//
 	//  INSERT INTO account (token, name, chat_id, status)
 	//  VALUES ($1, $2, $3, $4)
 	//  RETURNING id
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (int64, error)
+	//CreateAlertOutbox
+	//
+	//  INSERT INTO alert_outbox (account_id, event, channel, target, title, text, status, attempts, next_attempt, created)
+	//  VALUES ($1, $2, $3, $4, $5, $6, 'pending', 0, $7, $7)
+	//  RETURNING id
+	CreateAlertOutbox(ctx context.Context, arg CreateAlertOutboxParams) (int64, error)
 	//CreateFence
 	//
 	//  INSERT INTO fence (name, longitude, latitude, radius)
 	//  VALUES ($1, $2, $3, $4)
 	//  RETURNING id
 	CreateFence(ctx context.Context, arg CreateFenceParams) (int64, error)
+	//CreateLiveLocation
+	//
+	//  INSERT INTO live_location (chat_id, account_id, message_id, created, expires)
+	//  VALUES ($1, $2, $3, $4, $5)
+	//  RETURNING id, chat_id, account_id, message_id, created, expires
+	CreateLiveLocation(ctx context.Context, arg CreateLiveLocationParams) (LiveLocation, error)
 	//CreateMigration
 	//
-	//  INSERT INTO migration (id, applied)
-	//  VALUES ($1, $2)
+	//  INSERT INTO migration (id, applied, checksum)
+	//  VALUES ($1, $2, $3)
 	//  RETURNING id
 	CreateMigration(ctx context.Context, arg CreateMigrationParams) (string, error)
+	//CreateProximityRule
+	//
+	//  INSERT INTO proximity_rule (first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created)
+	//  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	//  RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	CreateProximityRule(ctx context.Context, arg CreateProximityRuleParams) (ProximityRule, error)
+	//CreateShareAccess
+	//
+	//  INSERT INTO share_access (share_id, created, kind, ip, user_agent)
+	//  VALUES ($1, $2, $3, $4, $5)
+	CreateShareAccess(ctx context.Context, arg CreateShareAccessParams) error
+	//CreateShareLink
+	//
+	//  INSERT INTO share_link (token, account_id, scope, precision, created, expires)
+	//  VALUES ($1, $2, $3, $4, $5, $6)
+	//  RETURNING id, token, account_id, scope, precision, created, expires, revoked
+	CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error)
+	//CreateSosEvent
+	//
+	//  INSERT INTO sos_event (sos_id, created, type, account_id, details)
+	//  VALUES ($1, $2, $3, $4, $5)
+	CreateSosEvent(ctx context.Context, arg CreateSosEventParams) error
+	//CreateSosIncident
+	//
+	//  INSERT INTO sos_incident (account_id, created, message, escalation_level, next_escalation)
+	//  VALUES ($1, $2, $3, 0, $4)
+	//  RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+	CreateSosIncident(ctx context.Context, arg CreateSosIncidentParams) (SosIncident, error)
 	//CreateUpdate
 	//
 	//  INSERT INTO updates (account_id, created, data)
 	//  VALUES ($1, $2, $3)
 	//  RETURNING id
 	CreateUpdate(ctx context.Context, arg CreateUpdateParams) (int64, error)
+	//CreateWatch
+	//
+	//  INSERT INTO watch (owner_id, target_id, fence_id, distance, direction, created, expires)
+	//  VALUES ($1, $2, $3, $4, $5, $6, $7)
+	//  RETURNING id, owner_id, target_id, fence_id, distance, direction, created, expires
+	CreateWatch(ctx context.Context, arg CreateWatchParams) (Watch, error)
+	//CreateWebhookDelivery
+	//
+	//  INSERT INTO webhook_delivery (subscription_id, event, payload, status, attempts, next_attempt, created)
+	//  VALUES ($1, $2, $3, 'pending', 0, $4, $4)
+	//  RETURNING id
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (int64, error)
+	//CreateWebhookSubscription
+	//
+	//  INSERT INTO webhook_subscription (url, secret, events, enabled, created)
+	//  VALUES ($1, $2, $3, $4, $5)
+	//  RETURNING id, url, secret, events, enabled, created
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	//DeleteBotState
+	//
+	//  DELETE
+	//  FROM bot_state
+	//  WHERE chat_id = $1
+	DeleteBotState(ctx context.Context, chatID int64) error
+	//DeleteExpiredLiveLocations
+	//
+	//  DELETE
+	//  FROM live_location
+	//  WHERE expires <= $1
+	DeleteExpiredLiveLocations(ctx context.Context, expires time.Time) error
+	//DeleteExpiredWatches
+	//
+	//  DELETE
+	//  FROM watch
+	//  WHERE expires <= $1
+	DeleteExpiredWatches(ctx context.Context, expires time.Time) error
 	//DeleteFence
 	//
 	//  DELETE
 	//  FROM fence
 	//  WHERE id = $1
 	DeleteFence(ctx context.Context, id int64) error
+	//DeleteFiredWatch
+	//
+	//  DELETE
+	//  FROM watch
+	//  WHERE id = $1
+	DeleteFiredWatch(ctx context.Context, id int64) error
+	//DeleteLiveLocation
+	//
+	//  DELETE
+	//  FROM live_location
+	//  WHERE id = $1
+	DeleteLiveLocation(ctx context.Context, id int64) error
+	//DeleteMigration
+	//
+	//  DELETE
+	//  FROM migration
+	//  WHERE id = $1
+	DeleteMigration(ctx context.Context, id string) error
+	//DeleteProximityRule
+	//
+	//  DELETE
+	//  FROM proximity_rule
+	//  WHERE id = $1
+	DeleteProximityRule(ctx context.Context, id int64) (int64, error)
+	//DeleteWatch
+	//
+	//  DELETE
+	//  FROM watch
+	//  WHERE id = $1
+	//    AND owner_id = $2
+	DeleteWatch(ctx context.Context, arg DeleteWatchParams) (int64, error)
+	//DeleteWebhookSubscription
+	//
+	//  DELETE
+	//  FROM webhook_subscription
+	//  WHERE id = $1
+	DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error)
 	//GetAccount
 	//
-	//  SELECT id, token, name, chat_id, status
+	//  SELECT id, token, name, chat_id, status, settings
 	//  FROM account
 	//  WHERE id = $1
 	//  LIMIT 1
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	//GetAccountByChatID
 	//
-	//  SELECT id, token, name, chat_id, status
+	//  SELECT id, token, name, chat_id, status, settings
 	//  FROM account
 	//  WHERE chat_id = $1
 	//  LIMIT 1
 	GetAccountByChatID(ctx context.Context, chatID *int64) (Account, error)
+	//GetAccountByName
+	//
+	//  SELECT id, token, name, chat_id, status, settings
+	//  FROM account
+	//  WHERE name = $1
+	//  LIMIT 1
+	GetAccountByName(ctx context.Context, name string) (Account, error)
 	//GetAccountByToken
 	//
-	//  SELECT id, token, name, chat_id, status
+	//  SELECT id, token, name, chat_id, status, settings
 	//  FROM account
 	//  WHERE token = $1
 	//  LIMIT 1
 	GetAccountByToken(ctx context.Context, token string) (Account, error)
+	//GetAccountForUpdate
+	//
+	//  SELECT id, token, name, chat_id, status, settings
+	//  FROM account
+	//  WHERE id = $1
+	//  FOR UPDATE
+	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	//GetActiveLiveLocationsByAccountID
+	//
+	//  SELECT id, chat_id, account_id, message_id, created, expires
+	//  FROM live_location
+	//  WHERE account_id = $1
+	//    AND expires > $2
+	//  ORDER BY id
+	GetActiveLiveLocationsByAccountID(ctx context.Context, arg GetActiveLiveLocationsByAccountIDParams) ([]LiveLocation, error)
+	//GetActiveLiveLocationsByChatID
+	//
+	//  SELECT id, chat_id, account_id, message_id, created, expires
+	//  FROM live_location
+	//  WHERE chat_id = $1
+	//    AND expires > $2
+	//  ORDER BY id
+	GetActiveLiveLocationsByChatID(ctx context.Context, arg GetActiveLiveLocationsByChatIDParams) ([]LiveLocation, error)
+	//GetActiveShareLinksByAccountID
+	//
+	//  SELECT share_link.id, share_link.token, share_link.account_id, share_link.scope, share_link.precision, share_link.created, share_link.expires, share_link.revoked,
+	//         (SELECT COUNT(*) FROM share_access WHERE share_access.share_id = share_link.id) AS access_count
+	//  FROM share_link
+	//  WHERE account_id = $1
+	//    AND revoked IS NULL
+	//    AND expires > $2
+	//  ORDER BY id
+	GetActiveShareLinksByAccountID(ctx context.Context, arg GetActiveShareLinksByAccountIDParams) ([]GetActiveShareLinksByAccountIDRow, error)
+	//GetActiveWatchesByOwnerID
+	//
+	//  SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+	//  FROM watch
+	//  WHERE owner_id = $1
+	//    AND expires > $2
+	//  ORDER BY id
+	GetActiveWatchesByOwnerID(ctx context.Context, arg GetActiveWatchesByOwnerIDParams) ([]Watch, error)
+	//GetActiveWatchesByTargetID
+	//
+	//  SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+	//  FROM watch
+	//  WHERE target_id = $1
+	//    AND expires > $2
+	//  ORDER BY id
+	GetActiveWatchesByTargetID(ctx context.Context, arg GetActiveWatchesByTargetIDParams) ([]Watch, error)
+	//GetAlertOutbox
+	//
+	//  SELECT id, account_id, event, channel, target, title, text, status, attempts, next_attempt, last_error, created, sent
+	//  FROM alert_outbox
+	//  WHERE ($1::VARCHAR IS NULL OR status = $1::VARCHAR)
+	//  ORDER BY id DESC
+	//  LIMIT $2
+	GetAlertOutbox(ctx context.Context, arg GetAlertOutboxParams) ([]AlertOutbox, error)
 	//GetAllAccounts
 	//
-	//  SELECT id, token, name, chat_id, status
+	//  SELECT id, token, name, chat_id, status, settings
 	//  FROM account
 	//  ORDER BY id
 	GetAllAccounts(ctx context.Context) ([]Account, error)
 	//GetAllFences
 	//
-	//  SELECT id, name, longitude, latitude, radius
+	//  SELECT id, name, longitude, latitude, radius, settings
 	//  FROM fence
+	//  ORDER BY id
 	GetAllFences(ctx context.Context) ([]Fence, error)
+	//GetAllProximityRules
+	//
+	//  SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	//  FROM proximity_rule
+	//  ORDER BY id
+	GetAllProximityRules(ctx context.Context) ([]ProximityRule, error)
+	//GetAllWebhookSubscriptions
+	//
+	//  SELECT id, url, secret, events, enabled, created
+	//  FROM webhook_subscription
+	//  ORDER BY id
+	GetAllWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	//GetBotState
+	//
+	//  SELECT chat_id, stage, data, expires
+	//  FROM bot_state
+	//  WHERE chat_id = $1
+	//  LIMIT 1
+	GetBotState(ctx context.Context, chatID int64) (BotState, error)
+	//GetEnabledWebhookSubscriptionsByEvent
+	//
+	//  SELECT id, url, secret, events, enabled, created
+	//  FROM webhook_subscription
+	//  WHERE enabled
+	//    AND $1::VARCHAR = ANY (events)
+	GetEnabledWebhookSubscriptionsByEvent(ctx context.Context, event string) ([]WebhookSubscription, error)
+	//GetFence
+	//
+	//  SELECT id, name, longitude, latitude, radius, settings
+	//  FROM fence
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetFence(ctx context.Context, id int64) (Fence, error)
+	//GetLastLocationUpdateByAccountID
+	//
+	//  SELECT id, account_id, created, data
+	//  FROM updates
+	//  WHERE account_id = $1
+	//    AND data -> 'location' IS NOT NULL
+	//  ORDER BY id DESC
+	//  LIMIT 1
+	GetLastLocationUpdateByAccountID(ctx context.Context, accountID int64) ([]Update, error)
 	//GetLastUpdateByAccountID
 	//
 	//  SELECT id, account_id, created, data
@@ -87,18 +363,308 @@ type Querier interface {
 	//  ORDER BY id DESC
 	//  LIMIT 10
 	GetLatestUpdatesByAccountID(ctx context.Context, accountID int64) ([]Update, error)
+	//GetLiveLocation
+	//
+	//  SELECT id, chat_id, account_id, message_id, created, expires
+	//  FROM live_location
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetLiveLocation(ctx context.Context, id int64) (LiveLocation, error)
+	//GetLocationUpdatesByAccountIDBetween
+	//
+	//  SELECT id, account_id, created, data
+	//  FROM updates
+	//  WHERE account_id = $1
+	//    AND created >= $2
+	//    AND created < $3
+	//    AND data -> 'location' IS NOT NULL
+	//  ORDER BY id
+	GetLocationUpdatesByAccountIDBetween(ctx context.Context, arg GetLocationUpdatesByAccountIDBetweenParams) ([]Update, error)
+	//GetLocationUpdatesByAccountIDSince
+	//
+	//  SELECT id, account_id, created, data
+	//  FROM updates
+	//  WHERE account_id = $1
+	//    AND created >= $2
+	//    AND data -> 'location' IS NOT NULL
+	//  ORDER BY id
+	GetLocationUpdatesByAccountIDSince(ctx context.Context, arg GetLocationUpdatesByAccountIDSinceParams) ([]Update, error)
+	//GetMigrationTableState
+	//
+	//  SELECT (to_regclass('migration') IS NOT NULL)::boolean AS table_exists,
+	//         EXISTS (SELECT 1
+	//                 FROM information_schema.columns
+	//                 WHERE table_schema = current_schema()
+	//                   AND table_name = 'migration'
+	//                   AND column_name = 'checksum')::boolean AS has_checksum
+	GetMigrationTableState(ctx context.Context) (GetMigrationTableStateRow, error)
 	//GetMigrations
 	//
-	//  SELECT id, applied
+	//  SELECT id, applied, checksum
 	//  FROM migration
 	//  ORDER BY id
 	GetMigrations(ctx context.Context) ([]Migration, error)
+	//GetProximityRule
+	//
+	//  SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	//  FROM proximity_rule
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetProximityRule(ctx context.Context, id int64) (ProximityRule, error)
+	//GetProximityRuleByPair
+	//
+	//  SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	//  FROM proximity_rule
+	//  WHERE first_account_id = $1
+	//    AND second_account_id = $2
+	//  LIMIT 1
+	GetProximityRuleByPair(ctx context.Context, arg GetProximityRuleByPairParams) ([]ProximityRule, error)
+	//GetProximityRulesByAccountID
+	//
+	//  SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	//  FROM proximity_rule
+	//  WHERE first_account_id = $1
+	//     OR second_account_id = $1
+	//  ORDER BY id
+	GetProximityRulesByAccountID(ctx context.Context, accountID int64) ([]ProximityRule, error)
+	//GetQueueDepths
+	//
+	//  SELECT (SELECT count(*) FROM alert_outbox WHERE status IN ('pending', 'sending'))     AS pending_alerts,
+	//         (SELECT count(*) FROM webhook_delivery WHERE status IN ('pending', 'sending')) AS pending_webhooks,
+	//         (SELECT count(*) FROM webhook_delivery WHERE status = 'failed')                AS failed_webhooks,
+	//         (SELECT count(*) FROM sos_incident WHERE acknowledged IS NULL)                 AS open_sos
+	GetQueueDepths(ctx context.Context) (GetQueueDepthsRow, error)
+	//GetSchemaColumns
+	//
+	//  SELECT table_name::text  AS table_name,
+	//         column_name::text AS column_name,
+	//         data_type::text   AS data_type,
+	//         is_nullable = 'YES' AS nullable
+	//  FROM information_schema.columns
+	//  WHERE table_schema = current_schema()
+	GetSchemaColumns(ctx context.Context) ([]GetSchemaColumnsRow, error)
+	//GetShareLinkByToken
+	//
+	//  SELECT id, token, account_id, scope, precision, created, expires, revoked
+	//  FROM share_link
+	//  WHERE token = $1
+	//  LIMIT 1
+	GetShareLinkByToken(ctx context.Context, token string) (ShareLink, error)
+	//GetSosEvents
+	//
+	//  SELECT id, sos_id, created, type, account_id, details
+	//  FROM sos_event
+	//  WHERE sos_id = $1
+	//  ORDER BY id
+	GetSosEvents(ctx context.Context, sosID int64) ([]SosEvent, error)
+	//GetSosIncident
+	//
+	//  SELECT id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+	//  FROM sos_incident
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetSosIncident(ctx context.Context, id int64) (SosIncident, error)
+	//GetUpdatesByAccountIDBetween
+	//
+	//  SELECT id, account_id, created, data
+	//  FROM updates
+	//  WHERE account_id = $1
+	//    AND created >= $2
+	//    AND created < $3
+	//  ORDER BY id
+	GetUpdatesByAccountIDBetween(ctx context.Context, arg GetUpdatesByAccountIDBetweenParams) ([]Update, error)
+	//GetWebhookDeliveriesBySubscriptionID
+	//
+	//  SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+	//  FROM webhook_delivery
+	//  WHERE subscription_id = $1
+	//    AND ($2::VARCHAR IS NULL OR status = $2::VARCHAR)
+	//  ORDER BY id DESC
+	//  LIMIT $3
+	GetWebhookDeliveriesBySubscriptionID(ctx context.Context, arg GetWebhookDeliveriesBySubscriptionIDParams) ([]WebhookDelivery, error)
+	//GetWebhookDelivery
+	//
+	//  SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+	//  FROM webhook_delivery
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	//GetWebhookSubscription
+	//
+	//  SELECT id, url, secret, events, enabled, created
+	//  FROM webhook_subscription
+	//  WHERE id = $1
+	//  LIMIT 1
+	GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error)
+	//MarkAlertOutboxAttemptFailed
+	//
+	//  UPDATE alert_outbox
+	//  SET status       = $2,
+	//      attempts     = attempts + 1,
+	//      next_attempt = $3,
+	//      last_error   = $4
+	//  WHERE id = $1
+	MarkAlertOutboxAttemptFailed(ctx context.Context, arg MarkAlertOutboxAttemptFailedParams) error
+	//MarkAlertOutboxSent
+	//
+	//  UPDATE alert_outbox
+	//  SET status     = 'sent',
+	//      attempts   = attempts + 1,
+	//      last_error = NULL,
+	//      sent       = $2
+	//  WHERE id = $1
+	MarkAlertOutboxSent(ctx context.Context, arg MarkAlertOutboxSentParams) error
+	//MarkWebhookDeliveryAttemptFailed
+	//
+	//  UPDATE webhook_delivery
+	//  SET status           = $2,
+	//      attempts         = attempts + 1,
+	//      next_attempt     = $3,
+	//      last_error       = $4,
+	//      last_status_code = $5
+	//  WHERE id = $1
+	MarkWebhookDeliveryAttemptFailed(ctx context.Context, arg MarkWebhookDeliveryAttemptFailedParams) error
+	//MarkWebhookDeliveryDelivered
+	//
+	//  UPDATE webhook_delivery
+	//  SET status           = 'delivered',
+	//      attempts         = attempts + 1,
+	//      last_error       = NULL,
+	//      last_status_code = $2,
+	//      delivered        = $3
+	//  WHERE id = $1
+	MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error
+	//PostponeAlertOutbox
+	//
+	//  UPDATE alert_outbox
+	//  SET status       = 'pending',
+	//      next_attempt = $2,
+	//      last_error   = $3
+	//  WHERE id = $1
+	PostponeAlertOutbox(ctx context.Context, arg PostponeAlertOutboxParams) error
+	//ReleaseMigrationLock
+	//
+	//  SELECT pg_advisory_unlock($1::bigint)
+	ReleaseMigrationLock(ctx context.Context, key int64) error
+	//ReplayFailedWebhookDeliveries
+	//
+	//  UPDATE webhook_delivery
+	//  SET status       = 'pending',
+	//      attempts     = 0,
+	//      next_attempt = $2
+	//  WHERE subscription_id = $1
+	//    AND status = 'failed'
+	ReplayFailedWebhookDeliveries(ctx context.Context, arg ReplayFailedWebhookDeliveriesParams) (int64, error)
+	//ReplayWebhookDelivery
+	//
+	//  UPDATE webhook_delivery
+	//  SET status       = 'pending',
+	//      attempts     = 0,
+	//      next_attempt = $2,
+	//      delivered    = NULL
+	//  WHERE id = $1
+	//  RETURNING id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+	ReplayWebhookDelivery(ctx context.Context, arg ReplayWebhookDeliveryParams) (WebhookDelivery, error)
+	//RevokeShareLink
+	//
+	//  UPDATE share_link
+	//  SET revoked = $3
+	//  WHERE id = $1
+	//    AND account_id = $2
+	//    AND revoked IS NULL
+	RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (int64, error)
+	//UpdateAccountSettings
+	//
+	//  UPDATE account
+	//  SET settings = $2
+	//  WHERE id = $1
+	UpdateAccountSettings(ctx context.Context, arg UpdateAccountSettingsParams) error
 	//UpdateAccountStatus
 	//
 	//  UPDATE account
 	//  SET status = $2
 	//  WHERE id = $1
 	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) error
+	//UpdateAccountToken
+	//
+	//  UPDATE account
+	//  SET token = $2
+	//  WHERE id = $1
+	UpdateAccountToken(ctx context.Context, arg UpdateAccountTokenParams) error
+	//UpdateFence
+	//
+	//  UPDATE fence
+	//  SET name      = $2,
+	//      longitude = $3,
+	//      latitude  = $4,
+	//      radius    = $5
+	//  WHERE id = $1
+	//  RETURNING id, name, longitude, latitude, radius, settings
+	UpdateFence(ctx context.Context, arg UpdateFenceParams) (Fence, error)
+	//UpdateFenceSettings
+	//
+	//  UPDATE fence
+	//  SET settings = $2
+	//  WHERE id = $1
+	//  RETURNING id, name, longitude, latitude, radius, settings
+	UpdateFenceSettings(ctx context.Context, arg UpdateFenceSettingsParams) (Fence, error)
+	//UpdateProximityRule
+	//
+	//  UPDATE proximity_rule
+	//  SET distance    = $2,
+	//      notify_meet = $3,
+	//      notify_part = $4
+	//  WHERE id = $1
+	//  RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+	UpdateProximityRule(ctx context.Context, arg UpdateProximityRuleParams) (ProximityRule, error)
+	//UpdateProximityRuleState
+	//
+	//  UPDATE proximity_rule
+	//  SET together = $2,
+	//      changed  = $3
+	//  WHERE id = $1
+	UpdateProximityRuleState(ctx context.Context, arg UpdateProximityRuleStateParams) error
+	//UpdateSosIncidentEscalation
+	//
+	//  UPDATE sos_incident
+	//  SET escalation_level = $2,
+	//      next_escalation  = $3
+	//  WHERE id = $1
+	UpdateSosIncidentEscalation(ctx context.Context, arg UpdateSosIncidentEscalationParams) error
+	//UpdateWebhookSubscription
+	//
+	//  UPDATE webhook_subscription
+	//  SET url     = $2,
+	//      secret  = $3,
+	//      events  = $4,
+	//      enabled = $5
+	//  WHERE id = $1
+	//  RETURNING id, url, secret, events, enabled, created
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	//UpgradeMigrationTable
+	//
+	//  ALTER TABLE migration ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT ''
+	UpgradeMigrationTable(ctx context.Context) error
+	//UpsertBotState
+	//
+	//  INSERT INTO bot_state (chat_id, stage, data, expires)
+	//  VALUES ($1, $2, $3, $4)
+	//  ON CONFLICT (chat_id) DO UPDATE
+	//      SET stage   = excluded.stage,
+	//          data    = excluded.data,
+	//          expires = excluded.expires
+	UpsertBotState(ctx context.Context, arg UpsertBotStateParams) error
+	//UpsertFence
+	//
+	//  INSERT INTO fence (name, longitude, latitude, radius, settings)
+	//  VALUES ($1, $2, $3, $4, $5)
+	//  ON CONFLICT (name) DO UPDATE
+	//      SET longitude = excluded.longitude,
+	//          latitude  = excluded.latitude,
+	//          radius    = excluded.radius,
+	//          settings  = excluded.settings
+	//  RETURNING id, name, longitude, latitude, radius, settings
+	UpsertFence(ctx context.Context, arg UpsertFenceParams) (Fence, error)
 }
 
 var _ Querier = (*Queries)(nil)
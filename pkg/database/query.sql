@@ -9,6 +9,12 @@ FROM account
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetAccountForUpdate :one
+SELECT *
+FROM account
+WHERE id = $1
+FOR UPDATE;
+
 -- name: GetAccountByToken :one
 SELECT *
 FROM account
@@ -26,11 +32,27 @@ INSERT INTO account (token, name, chat_id, status)
 VALUES ($1, $2, $3, $4)
 RETURNING id;
 
+-- name: UpdateAccountToken :exec
+UPDATE account
+SET token = $2
+WHERE id = $1;
+
+-- name: GetAccountByName :one
+SELECT *
+FROM account
+WHERE name = $1
+LIMIT 1;
+
 -- name: UpdateAccountStatus :exec
 UPDATE account
 SET status = $2
 WHERE id = $1;
 
+-- name: UpdateAccountSettings :exec
+UPDATE account
+SET settings = $2
+WHERE id = $1;
+
 -- name: GetLastUpdateByAccountID :many
 SELECT *
 FROM updates
@@ -38,6 +60,39 @@ WHERE account_id = $1
 ORDER BY id DESC
 LIMIT 1;
 
+-- name: GetLastLocationUpdateByAccountID :many
+SELECT *
+FROM updates
+WHERE account_id = $1
+  AND data -> 'location' IS NOT NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetLocationUpdatesByAccountIDSince :many
+SELECT *
+FROM updates
+WHERE account_id = $1
+  AND created >= $2
+  AND data -> 'location' IS NOT NULL
+ORDER BY id;
+
+-- name: GetLocationUpdatesByAccountIDBetween :many
+SELECT *
+FROM updates
+WHERE account_id = @account_id
+  AND created >= @since
+  AND created < @until
+  AND data -> 'location' IS NOT NULL
+ORDER BY id;
+
+-- name: GetUpdatesByAccountIDBetween :many
+SELECT *
+FROM updates
+WHERE account_id = @account_id
+  AND created >= @since
+  AND created < @until
+ORDER BY id;
+
 -- name: GetLatestUpdatesByAccountID :many
 SELECT *
 FROM updates
@@ -52,24 +107,460 @@ RETURNING id;
 
 -- name: GetAllFences :many
 SELECT *
-FROM fence;
+FROM fence
+ORDER BY id;
+
+-- name: GetFence :one
+SELECT *
+FROM fence
+WHERE id = $1
+LIMIT 1;
 
 -- name: CreateFence :one
 INSERT INTO fence (name, longitude, latitude, radius)
 VALUES ($1, $2, $3, $4)
 RETURNING id;
 
+-- name: UpdateFence :one
+UPDATE fence
+SET name      = $2,
+    longitude = $3,
+    latitude  = $4,
+    radius    = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateFenceSettings :one
+UPDATE fence
+SET settings = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpsertFence :one
+INSERT INTO fence (name, longitude, latitude, radius, settings)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name) DO UPDATE
+    SET longitude = excluded.longitude,
+        latitude  = excluded.latitude,
+        radius    = excluded.radius,
+        settings  = excluded.settings
+RETURNING *;
+
 -- name: DeleteFence :exec
 DELETE
 FROM fence
 WHERE id = $1;
 
+-- name: GetAllWebhookSubscriptions :many
+SELECT *
+FROM webhook_subscription
+ORDER BY id;
+
+-- name: GetEnabledWebhookSubscriptionsByEvent :many
+SELECT *
+FROM webhook_subscription
+WHERE enabled
+  AND @event::VARCHAR = ANY (events);
+
+-- name: GetWebhookSubscription :one
+SELECT *
+FROM webhook_subscription
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscription (url, secret, events, enabled, created)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscription
+SET url     = $2,
+    secret  = $3,
+    events  = $4,
+    enabled = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE
+FROM webhook_subscription
+WHERE id = $1;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_delivery (subscription_id, event, payload, status, attempts, next_attempt, created)
+VALUES ($1, $2, $3, 'pending', 0, $4, $4)
+RETURNING id;
+
+-- name: GetWebhookDelivery :one
+SELECT *
+FROM webhook_delivery
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetWebhookDeliveriesBySubscriptionID :many
+SELECT *
+FROM webhook_delivery
+WHERE subscription_id = @subscription_id
+  AND (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status')::VARCHAR)
+ORDER BY id DESC
+LIMIT @max_count;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_delivery
+SET status       = 'sending',
+    next_attempt = @lease_until
+FROM webhook_subscription
+WHERE webhook_subscription.id = webhook_delivery.subscription_id
+  AND webhook_delivery.id IN (SELECT due.id
+                              FROM webhook_delivery due
+                              WHERE due.status IN ('pending', 'sending')
+                                AND due.next_attempt <= @now
+                              ORDER BY due.next_attempt
+                              LIMIT @max_count FOR UPDATE SKIP LOCKED)
+RETURNING sqlc.embed(webhook_delivery), sqlc.embed(webhook_subscription);
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_delivery
+SET status           = 'delivered',
+    attempts         = attempts + 1,
+    last_error       = NULL,
+    last_status_code = $2,
+    delivered        = $3
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryAttemptFailed :exec
+UPDATE webhook_delivery
+SET status           = $2,
+    attempts         = attempts + 1,
+    next_attempt     = $3,
+    last_error       = $4,
+    last_status_code = $5
+WHERE id = $1;
+
+-- name: ReplayWebhookDelivery :one
+UPDATE webhook_delivery
+SET status       = 'pending',
+    attempts     = 0,
+    next_attempt = $2,
+    delivered    = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: ReplayFailedWebhookDeliveries :execrows
+UPDATE webhook_delivery
+SET status       = 'pending',
+    attempts     = 0,
+    next_attempt = $2
+WHERE subscription_id = $1
+  AND status = 'failed';
+
+-- name: CreateAlertOutbox :one
+INSERT INTO alert_outbox (account_id, event, channel, target, title, text, status, attempts, next_attempt, created)
+VALUES ($1, $2, $3, $4, $5, $6, 'pending', 0, $7, $7)
+RETURNING id;
+
+-- name: ClaimDueAlertOutbox :many
+UPDATE alert_outbox
+SET status       = 'sending',
+    next_attempt = @lease_until
+FROM account
+WHERE account.id = alert_outbox.account_id
+  AND alert_outbox.id IN (SELECT due.id
+                          FROM alert_outbox due
+                          WHERE due.status IN ('pending', 'sending')
+                            AND due.next_attempt <= @now
+                          ORDER BY due.id
+                          LIMIT @max_count FOR UPDATE SKIP LOCKED)
+RETURNING sqlc.embed(alert_outbox), sqlc.embed(account);
+
+-- name: MarkAlertOutboxSent :exec
+UPDATE alert_outbox
+SET status     = 'sent',
+    attempts   = attempts + 1,
+    last_error = NULL,
+    sent       = $2
+WHERE id = $1;
+
+-- name: MarkAlertOutboxAttemptFailed :exec
+UPDATE alert_outbox
+SET status       = $2,
+    attempts     = attempts + 1,
+    next_attempt = $3,
+    last_error   = $4
+WHERE id = $1;
+
+-- name: PostponeAlertOutbox :exec
+UPDATE alert_outbox
+SET status       = 'pending',
+    next_attempt = $2,
+    last_error   = $3
+WHERE id = $1;
+
+-- name: GetAlertOutbox :many
+SELECT *
+FROM alert_outbox
+WHERE (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status')::VARCHAR)
+ORDER BY id DESC
+LIMIT @max_count;
+
+-- name: CreateSosIncident :one
+INSERT INTO sos_incident (account_id, created, message, escalation_level, next_escalation)
+VALUES ($1, $2, $3, 0, $4)
+RETURNING *;
+
+-- name: GetSosIncident :one
+SELECT *
+FROM sos_incident
+WHERE id = $1
+LIMIT 1;
+
+-- name: ClaimDueSosIncidents :many
+UPDATE sos_incident
+SET next_escalation = @lease_until
+WHERE id IN (SELECT due.id
+             FROM sos_incident due
+             WHERE due.acknowledged IS NULL
+               AND due.next_escalation <= @now
+             ORDER BY due.id
+             FOR UPDATE SKIP LOCKED)
+RETURNING *;
+
+-- name: UpdateSosIncidentEscalation :exec
+UPDATE sos_incident
+SET escalation_level = $2,
+    next_escalation  = $3
+WHERE id = $1;
+
+-- name: AcknowledgeSosIncident :one
+UPDATE sos_incident
+SET acknowledged_by = $2,
+    acknowledged    = $3
+WHERE id = $1
+  AND acknowledged IS NULL
+RETURNING *;
+
+-- name: CreateSosEvent :exec
+INSERT INTO sos_event (sos_id, created, type, account_id, details)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetSosEvents :many
+SELECT *
+FROM sos_event
+WHERE sos_id = $1
+ORDER BY id;
+
 -- name: GetMigrations :many
 SELECT *
 FROM migration
 ORDER BY id;
 
 -- name: CreateMigration :one
-INSERT INTO migration (id, applied)
-VALUES ($1, $2)
+INSERT INTO migration (id, applied, checksum)
+VALUES ($1, $2, $3)
 RETURNING id;
+
+-- name: DeleteMigration :exec
+DELETE
+FROM migration
+WHERE id = $1;
+
+-- name: GetMigrationTableState :one
+SELECT (to_regclass('migration') IS NOT NULL)::boolean AS table_exists,
+       EXISTS (SELECT 1
+               FROM information_schema.columns
+               WHERE table_schema = current_schema()
+                 AND table_name = 'migration'
+                 AND column_name = 'checksum')::boolean AS has_checksum;
+
+-- name: UpgradeMigrationTable :exec
+ALTER TABLE migration ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT '';
+
+-- name: AcquireMigrationLock :exec
+SELECT pg_advisory_lock(@key::bigint);
+
+-- name: ReleaseMigrationLock :exec
+SELECT pg_advisory_unlock(@key::bigint);
+
+-- name: GetSchemaColumns :many
+SELECT table_name::text  AS table_name,
+       column_name::text AS column_name,
+       data_type::text   AS data_type,
+       is_nullable = 'YES' AS nullable
+FROM information_schema.columns
+WHERE table_schema = current_schema();
+
+-- name: CreateWatch :one
+INSERT INTO watch (owner_id, target_id, fence_id, distance, direction, created, expires)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetActiveWatchesByOwnerID :many
+SELECT *
+FROM watch
+WHERE owner_id = $1
+  AND expires > $2
+ORDER BY id;
+
+-- name: GetActiveWatchesByTargetID :many
+SELECT *
+FROM watch
+WHERE target_id = $1
+  AND expires > $2
+ORDER BY id;
+
+-- name: DeleteWatch :execrows
+DELETE
+FROM watch
+WHERE id = $1
+  AND owner_id = $2;
+
+-- name: DeleteFiredWatch :exec
+DELETE
+FROM watch
+WHERE id = $1;
+
+-- name: DeleteExpiredWatches :exec
+DELETE
+FROM watch
+WHERE expires <= $1;
+
+-- name: GetAllProximityRules :many
+SELECT *
+FROM proximity_rule
+ORDER BY id;
+
+-- name: GetProximityRule :one
+SELECT *
+FROM proximity_rule
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetProximityRuleByPair :many
+SELECT *
+FROM proximity_rule
+WHERE first_account_id = $1
+  AND second_account_id = $2
+LIMIT 1;
+
+-- name: GetProximityRulesByAccountID :many
+SELECT *
+FROM proximity_rule
+WHERE first_account_id = @account_id
+   OR second_account_id = @account_id
+ORDER BY id;
+
+-- name: CreateProximityRule :one
+INSERT INTO proximity_rule (first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateProximityRule :one
+UPDATE proximity_rule
+SET distance    = $2,
+    notify_meet = $3,
+    notify_part = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateProximityRuleState :exec
+UPDATE proximity_rule
+SET together = $2,
+    changed  = $3
+WHERE id = $1;
+
+-- name: DeleteProximityRule :execrows
+DELETE
+FROM proximity_rule
+WHERE id = $1;
+
+-- name: CreateShareLink :one
+INSERT INTO share_link (token, account_id, scope, precision, created, expires)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetShareLinkByToken :one
+SELECT *
+FROM share_link
+WHERE token = $1
+LIMIT 1;
+
+-- name: GetActiveShareLinksByAccountID :many
+SELECT share_link.*,
+       (SELECT COUNT(*) FROM share_access WHERE share_access.share_id = share_link.id) AS access_count
+FROM share_link
+WHERE account_id = $1
+  AND revoked IS NULL
+  AND expires > $2
+ORDER BY id;
+
+-- name: RevokeShareLink :execrows
+UPDATE share_link
+SET revoked = $3
+WHERE id = $1
+  AND account_id = $2
+  AND revoked IS NULL;
+
+-- name: CreateShareAccess :exec
+INSERT INTO share_access (share_id, created, kind, ip, user_agent)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CreateLiveLocation :one
+INSERT INTO live_location (chat_id, account_id, message_id, created, expires)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveLiveLocationsByAccountID :many
+SELECT *
+FROM live_location
+WHERE account_id = $1
+  AND expires > $2
+ORDER BY id;
+
+-- name: GetActiveLiveLocationsByChatID :many
+SELECT *
+FROM live_location
+WHERE chat_id = $1
+  AND expires > $2
+ORDER BY id;
+
+-- name: GetLiveLocation :one
+SELECT *
+FROM live_location
+WHERE id = $1
+LIMIT 1;
+
+-- name: DeleteLiveLocation :exec
+DELETE
+FROM live_location
+WHERE id = $1;
+
+-- name: DeleteExpiredLiveLocations :exec
+DELETE
+FROM live_location
+WHERE expires <= $1;
+
+-- name: GetBotState :one
+SELECT *
+FROM bot_state
+WHERE chat_id = $1
+LIMIT 1;
+
+-- name: UpsertBotState :exec
+INSERT INTO bot_state (chat_id, stage, data, expires)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (chat_id) DO UPDATE
+    SET stage   = excluded.stage,
+        data    = excluded.data,
+        expires = excluded.expires;
+
+-- name: DeleteBotState :exec
+DELETE
+FROM bot_state
+WHERE chat_id = $1;
+
+-- name: GetQueueDepths :one
+SELECT (SELECT count(*) FROM alert_outbox WHERE status IN ('pending', 'sending'))     AS pending_alerts,
+       (SELECT count(*) FROM webhook_delivery WHERE status IN ('pending', 'sending')) AS pending_webhooks,
+       (SELECT count(*) FROM webhook_delivery WHERE status = 'failed')                AS failed_webhooks,
+       (SELECT count(*) FROM sos_incident WHERE acknowledged IS NULL)                 AS open_sos;
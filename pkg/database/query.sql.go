@@ -12,6 +12,272 @@ import (
 	"roflbeacon2/app/api"
 )
 
+const acknowledgeSosIncident = `-- name: AcknowledgeSosIncident :one
+UPDATE sos_incident
+SET acknowledged_by = $2,
+    acknowledged    = $3
+WHERE id = $1
+  AND acknowledged IS NULL
+RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+`
+
+type AcknowledgeSosIncidentParams struct {
+	ID             int64      `db:"id"`
+	AcknowledgedBy *int64     `db:"acknowledged_by"`
+	Acknowledged   *time.Time `db:"acknowledged"`
+}
+
+// AcknowledgeSosIncident
+//
+//	UPDATE sos_incident
+//	SET acknowledged_by = $2,
+//	    acknowledged    = $3
+//	WHERE id = $1
+//	  AND acknowledged IS NULL
+//	RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+func (q *Queries) AcknowledgeSosIncident(ctx context.Context, arg AcknowledgeSosIncidentParams) (SosIncident, error) {
+	row := q.db.QueryRow(ctx, acknowledgeSosIncident, arg.ID, arg.AcknowledgedBy, arg.Acknowledged)
+	var i SosIncident
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Created,
+		&i.Message,
+		&i.EscalationLevel,
+		&i.NextEscalation,
+		&i.AcknowledgedBy,
+		&i.Acknowledged,
+	)
+	return i, err
+}
+
+const acquireMigrationLock = `-- name: AcquireMigrationLock :exec
+SELECT pg_advisory_lock($1::bigint)
+`
+
+// AcquireMigrationLock
+//
+//	SELECT pg_advisory_lock($1::bigint)
+func (q *Queries) AcquireMigrationLock(ctx context.Context, key int64) error {
+	_, err := q.db.Exec(ctx, acquireMigrationLock, key)
+	return err
+}
+
+const claimDueAlertOutbox = `-- name: ClaimDueAlertOutbox :many
+UPDATE alert_outbox
+SET status       = 'sending',
+    next_attempt = $1
+FROM account
+WHERE account.id = alert_outbox.account_id
+  AND alert_outbox.id IN (SELECT due.id
+                          FROM alert_outbox due
+                          WHERE due.status IN ('pending', 'sending')
+                            AND due.next_attempt <= $2
+                          ORDER BY due.id
+                          LIMIT $3 FOR UPDATE SKIP LOCKED)
+RETURNING alert_outbox.id, alert_outbox.account_id, alert_outbox.event, alert_outbox.channel, alert_outbox.target, alert_outbox.title, alert_outbox.text, alert_outbox.status, alert_outbox.attempts, alert_outbox.next_attempt, alert_outbox.last_error, alert_outbox.created, alert_outbox.sent, account.id, account.token, account.name, account.chat_id, account.status, account.settings
+`
+
+type ClaimDueAlertOutboxParams struct {
+	LeaseUntil time.Time `db:"lease_until"`
+	Now        time.Time `db:"now"`
+	MaxCount   int32     `db:"max_count"`
+}
+
+type ClaimDueAlertOutboxRow struct {
+	AlertOutbox AlertOutbox `db:"alert_outbox"`
+	Account     Account     `db:"account"`
+}
+
+// ClaimDueAlertOutbox
+//
+//	UPDATE alert_outbox
+//	SET status       = 'sending',
+//	    next_attempt = $1
+//	FROM account
+//	WHERE account.id = alert_outbox.account_id
+//	  AND alert_outbox.id IN (SELECT due.id
+//	                          FROM alert_outbox due
+//	                          WHERE due.status IN ('pending', 'sending')
+//	                            AND due.next_attempt <= $2
+//	                          ORDER BY due.id
+//	                          LIMIT $3 FOR UPDATE SKIP LOCKED)
+//	RETURNING alert_outbox.id, alert_outbox.account_id, alert_outbox.event, alert_outbox.channel, alert_outbox.target, alert_outbox.title, alert_outbox.text, alert_outbox.status, alert_outbox.attempts, alert_outbox.next_attempt, alert_outbox.last_error, alert_outbox.created, alert_outbox.sent, account.id, account.token, account.name, account.chat_id, account.status, account.settings
+func (q *Queries) ClaimDueAlertOutbox(ctx context.Context, arg ClaimDueAlertOutboxParams) ([]ClaimDueAlertOutboxRow, error) {
+	rows, err := q.db.Query(ctx, claimDueAlertOutbox, arg.LeaseUntil, arg.Now, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimDueAlertOutboxRow{}
+	for rows.Next() {
+		var i ClaimDueAlertOutboxRow
+		if err := rows.Scan(
+			&i.AlertOutbox.ID,
+			&i.AlertOutbox.AccountID,
+			&i.AlertOutbox.Event,
+			&i.AlertOutbox.Channel,
+			&i.AlertOutbox.Target,
+			&i.AlertOutbox.Title,
+			&i.AlertOutbox.Text,
+			&i.AlertOutbox.Status,
+			&i.AlertOutbox.Attempts,
+			&i.AlertOutbox.NextAttempt,
+			&i.AlertOutbox.LastError,
+			&i.AlertOutbox.Created,
+			&i.AlertOutbox.Sent,
+			&i.Account.ID,
+			&i.Account.Token,
+			&i.Account.Name,
+			&i.Account.ChatID,
+			&i.Account.Status,
+			&i.Account.Settings,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueSosIncidents = `-- name: ClaimDueSosIncidents :many
+UPDATE sos_incident
+SET next_escalation = $1
+WHERE id IN (SELECT due.id
+             FROM sos_incident due
+             WHERE due.acknowledged IS NULL
+               AND due.next_escalation <= $2
+             ORDER BY due.id
+             FOR UPDATE SKIP LOCKED)
+RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+`
+
+type ClaimDueSosIncidentsParams struct {
+	LeaseUntil time.Time `db:"lease_until"`
+	Now        time.Time `db:"now"`
+}
+
+// ClaimDueSosIncidents
+//
+//	UPDATE sos_incident
+//	SET next_escalation = $1
+//	WHERE id IN (SELECT due.id
+//	             FROM sos_incident due
+//	             WHERE due.acknowledged IS NULL
+//	               AND due.next_escalation <= $2
+//	             ORDER BY due.id
+//	             FOR UPDATE SKIP LOCKED)
+//	RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+func (q *Queries) ClaimDueSosIncidents(ctx context.Context, arg ClaimDueSosIncidentsParams) ([]SosIncident, error) {
+	rows, err := q.db.Query(ctx, claimDueSosIncidents, arg.LeaseUntil, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SosIncident{}
+	for rows.Next() {
+		var i SosIncident
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Message,
+			&i.EscalationLevel,
+			&i.NextEscalation,
+			&i.AcknowledgedBy,
+			&i.Acknowledged,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_delivery
+SET status       = 'sending',
+    next_attempt = $1
+FROM webhook_subscription
+WHERE webhook_subscription.id = webhook_delivery.subscription_id
+  AND webhook_delivery.id IN (SELECT due.id
+                              FROM webhook_delivery due
+                              WHERE due.status IN ('pending', 'sending')
+                                AND due.next_attempt <= $2
+                              ORDER BY due.next_attempt
+                              LIMIT $3 FOR UPDATE SKIP LOCKED)
+RETURNING webhook_delivery.id, webhook_delivery.subscription_id, webhook_delivery.event, webhook_delivery.payload, webhook_delivery.status, webhook_delivery.attempts, webhook_delivery.next_attempt, webhook_delivery.last_error, webhook_delivery.last_status_code, webhook_delivery.created, webhook_delivery.delivered, webhook_subscription.id, webhook_subscription.url, webhook_subscription.secret, webhook_subscription.events, webhook_subscription.enabled, webhook_subscription.created
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseUntil time.Time `db:"lease_until"`
+	Now        time.Time `db:"now"`
+	MaxCount   int32     `db:"max_count"`
+}
+
+type ClaimDueWebhookDeliveriesRow struct {
+	WebhookDelivery     WebhookDelivery     `db:"webhook_delivery"`
+	WebhookSubscription WebhookSubscription `db:"webhook_subscription"`
+}
+
+// ClaimDueWebhookDeliveries
+//
+//	UPDATE webhook_delivery
+//	SET status       = 'sending',
+//	    next_attempt = $1
+//	FROM webhook_subscription
+//	WHERE webhook_subscription.id = webhook_delivery.subscription_id
+//	  AND webhook_delivery.id IN (SELECT due.id
+//	                              FROM webhook_delivery due
+//	                              WHERE due.status IN ('pending', 'sending')
+//	                                AND due.next_attempt <= $2
+//	                              ORDER BY due.next_attempt
+//	                              LIMIT $3 FOR UPDATE SKIP LOCKED)
+//	RETURNING webhook_delivery.id, webhook_delivery.subscription_id, webhook_delivery.event, webhook_delivery.payload, webhook_delivery.status, webhook_delivery.attempts, webhook_delivery.next_attempt, webhook_delivery.last_error, webhook_delivery.last_status_code, webhook_delivery.created, webhook_delivery.delivered, webhook_subscription.id, webhook_subscription.url, webhook_subscription.secret, webhook_subscription.events, webhook_subscription.enabled, webhook_subscription.created
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeaseUntil, arg.Now, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimDueWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ClaimDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.WebhookDelivery.ID,
+			&i.WebhookDelivery.SubscriptionID,
+			&i.WebhookDelivery.Event,
+			&i.WebhookDelivery.Payload,
+			&i.WebhookDelivery.Status,
+			&i.WebhookDelivery.Attempts,
+			&i.WebhookDelivery.NextAttempt,
+			&i.WebhookDelivery.LastError,
+			&i.WebhookDelivery.LastStatusCode,
+			&i.WebhookDelivery.Created,
+			&i.WebhookDelivery.Delivered,
+			&i.WebhookSubscription.ID,
+			&i.WebhookSubscription.Url,
+			&i.WebhookSubscription.Secret,
+			&i.WebhookSubscription.Events,
+			&i.WebhookSubscription.Enabled,
+			&i.WebhookSubscription.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO account (token, name, chat_id, status)
 VALUES ($1, $2, $3, $4)
@@ -19,10 +285,10 @@ RETURNING id
 `
 
 type CreateAccountParams struct {
-	Token  string
-	Name   string
-	ChatID *int64
-	Status api.AccountStatus
+	Token  string            `db:"token"`
+	Name   string            `db:"name"`
+	ChatID *int64            `db:"chat_id"`
+	Status api.AccountStatus `db:"status"`
 }
 
 // CreateAccount
@@ -42,6 +308,42 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (i
 	return id, err
 }
 
+const createAlertOutbox = `-- name: CreateAlertOutbox :one
+INSERT INTO alert_outbox (account_id, event, channel, target, title, text, status, attempts, next_attempt, created)
+VALUES ($1, $2, $3, $4, $5, $6, 'pending', 0, $7, $7)
+RETURNING id
+`
+
+type CreateAlertOutboxParams struct {
+	AccountID   int64     `db:"account_id"`
+	Event       string    `db:"event"`
+	Channel     string    `db:"channel"`
+	Target      *string   `db:"target"`
+	Title       string    `db:"title"`
+	Text        string    `db:"text"`
+	NextAttempt time.Time `db:"next_attempt"`
+}
+
+// CreateAlertOutbox
+//
+//	INSERT INTO alert_outbox (account_id, event, channel, target, title, text, status, attempts, next_attempt, created)
+//	VALUES ($1, $2, $3, $4, $5, $6, 'pending', 0, $7, $7)
+//	RETURNING id
+func (q *Queries) CreateAlertOutbox(ctx context.Context, arg CreateAlertOutboxParams) (int64, error) {
+	row := q.db.QueryRow(ctx, createAlertOutbox,
+		arg.AccountID,
+		arg.Event,
+		arg.Channel,
+		arg.Target,
+		arg.Title,
+		arg.Text,
+		arg.NextAttempt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createFence = `-- name: CreateFence :one
 INSERT INTO fence (name, longitude, latitude, radius)
 VALUES ($1, $2, $3, $4)
@@ -49,10 +351,10 @@ RETURNING id
 `
 
 type CreateFenceParams struct {
-	Name      string
-	Longitude float64
-	Latitude  float64
-	Radius    float64
+	Name      string  `db:"name"`
+	Longitude float64 `db:"longitude"`
+	Latitude  float64 `db:"latitude"`
+	Radius    float64 `db:"radius"`
 }
 
 // CreateFence
@@ -72,29 +374,255 @@ func (q *Queries) CreateFence(ctx context.Context, arg CreateFenceParams) (int64
 	return id, err
 }
 
+const createLiveLocation = `-- name: CreateLiveLocation :one
+INSERT INTO live_location (chat_id, account_id, message_id, created, expires)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, chat_id, account_id, message_id, created, expires
+`
+
+type CreateLiveLocationParams struct {
+	ChatID    int64     `db:"chat_id"`
+	AccountID int64     `db:"account_id"`
+	MessageID int64     `db:"message_id"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
+}
+
+// CreateLiveLocation
+//
+//	INSERT INTO live_location (chat_id, account_id, message_id, created, expires)
+//	VALUES ($1, $2, $3, $4, $5)
+//	RETURNING id, chat_id, account_id, message_id, created, expires
+func (q *Queries) CreateLiveLocation(ctx context.Context, arg CreateLiveLocationParams) (LiveLocation, error) {
+	row := q.db.QueryRow(ctx, createLiveLocation,
+		arg.ChatID,
+		arg.AccountID,
+		arg.MessageID,
+		arg.Created,
+		arg.Expires,
+	)
+	var i LiveLocation
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.AccountID,
+		&i.MessageID,
+		&i.Created,
+		&i.Expires,
+	)
+	return i, err
+}
+
 const createMigration = `-- name: CreateMigration :one
-INSERT INTO migration (id, applied)
-VALUES ($1, $2)
+INSERT INTO migration (id, applied, checksum)
+VALUES ($1, $2, $3)
 RETURNING id
 `
 
 type CreateMigrationParams struct {
-	ID      string
-	Applied time.Time
+	ID       string    `db:"id"`
+	Applied  time.Time `db:"applied"`
+	Checksum string    `db:"checksum"`
 }
 
 // CreateMigration
 //
-//	INSERT INTO migration (id, applied)
-//	VALUES ($1, $2)
+//	INSERT INTO migration (id, applied, checksum)
+//	VALUES ($1, $2, $3)
 //	RETURNING id
 func (q *Queries) CreateMigration(ctx context.Context, arg CreateMigrationParams) (string, error) {
-	row := q.db.QueryRow(ctx, createMigration, arg.ID, arg.Applied)
+	row := q.db.QueryRow(ctx, createMigration, arg.ID, arg.Applied, arg.Checksum)
 	var id string
 	err := row.Scan(&id)
 	return id, err
 }
 
+const createProximityRule = `-- name: CreateProximityRule :one
+INSERT INTO proximity_rule (first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+`
+
+type CreateProximityRuleParams struct {
+	FirstAccountID  int64      `db:"first_account_id"`
+	SecondAccountID int64      `db:"second_account_id"`
+	Distance        float64    `db:"distance"`
+	NotifyMeet      bool       `db:"notify_meet"`
+	NotifyPart      bool       `db:"notify_part"`
+	Together        bool       `db:"together"`
+	Changed         *time.Time `db:"changed"`
+	Created         time.Time  `db:"created"`
+}
+
+// CreateProximityRule
+//
+//	INSERT INTO proximity_rule (first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+//	RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+func (q *Queries) CreateProximityRule(ctx context.Context, arg CreateProximityRuleParams) (ProximityRule, error) {
+	row := q.db.QueryRow(ctx, createProximityRule,
+		arg.FirstAccountID,
+		arg.SecondAccountID,
+		arg.Distance,
+		arg.NotifyMeet,
+		arg.NotifyPart,
+		arg.Together,
+		arg.Changed,
+		arg.Created,
+	)
+	var i ProximityRule
+	err := row.Scan(
+		&i.ID,
+		&i.FirstAccountID,
+		&i.SecondAccountID,
+		&i.Distance,
+		&i.NotifyMeet,
+		&i.NotifyPart,
+		&i.Together,
+		&i.Changed,
+		&i.Created,
+	)
+	return i, err
+}
+
+const createShareAccess = `-- name: CreateShareAccess :exec
+INSERT INTO share_access (share_id, created, kind, ip, user_agent)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateShareAccessParams struct {
+	ShareID   int64     `db:"share_id"`
+	Created   time.Time `db:"created"`
+	Kind      string    `db:"kind"`
+	Ip        string    `db:"ip"`
+	UserAgent string    `db:"user_agent"`
+}
+
+// CreateShareAccess
+//
+//	INSERT INTO share_access (share_id, created, kind, ip, user_agent)
+//	VALUES ($1, $2, $3, $4, $5)
+func (q *Queries) CreateShareAccess(ctx context.Context, arg CreateShareAccessParams) error {
+	_, err := q.db.Exec(ctx, createShareAccess,
+		arg.ShareID,
+		arg.Created,
+		arg.Kind,
+		arg.Ip,
+		arg.UserAgent,
+	)
+	return err
+}
+
+const createShareLink = `-- name: CreateShareLink :one
+INSERT INTO share_link (token, account_id, scope, precision, created, expires)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, token, account_id, scope, precision, created, expires, revoked
+`
+
+type CreateShareLinkParams struct {
+	Token     string    `db:"token"`
+	AccountID int64     `db:"account_id"`
+	Scope     string    `db:"scope"`
+	Precision int32     `db:"precision"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
+}
+
+// CreateShareLink
+//
+//	INSERT INTO share_link (token, account_id, scope, precision, created, expires)
+//	VALUES ($1, $2, $3, $4, $5, $6)
+//	RETURNING id, token, account_id, scope, precision, created, expires, revoked
+func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, createShareLink,
+		arg.Token,
+		arg.AccountID,
+		arg.Scope,
+		arg.Precision,
+		arg.Created,
+		arg.Expires,
+	)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.AccountID,
+		&i.Scope,
+		&i.Precision,
+		&i.Created,
+		&i.Expires,
+		&i.Revoked,
+	)
+	return i, err
+}
+
+const createSosEvent = `-- name: CreateSosEvent :exec
+INSERT INTO sos_event (sos_id, created, type, account_id, details)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateSosEventParams struct {
+	SosID     int64     `db:"sos_id"`
+	Created   time.Time `db:"created"`
+	Type      string    `db:"type"`
+	AccountID *int64    `db:"account_id"`
+	Details   *string   `db:"details"`
+}
+
+// CreateSosEvent
+//
+//	INSERT INTO sos_event (sos_id, created, type, account_id, details)
+//	VALUES ($1, $2, $3, $4, $5)
+func (q *Queries) CreateSosEvent(ctx context.Context, arg CreateSosEventParams) error {
+	_, err := q.db.Exec(ctx, createSosEvent,
+		arg.SosID,
+		arg.Created,
+		arg.Type,
+		arg.AccountID,
+		arg.Details,
+	)
+	return err
+}
+
+const createSosIncident = `-- name: CreateSosIncident :one
+INSERT INTO sos_incident (account_id, created, message, escalation_level, next_escalation)
+VALUES ($1, $2, $3, 0, $4)
+RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+`
+
+type CreateSosIncidentParams struct {
+	AccountID      int64     `db:"account_id"`
+	Created        time.Time `db:"created"`
+	Message        *string   `db:"message"`
+	NextEscalation time.Time `db:"next_escalation"`
+}
+
+// CreateSosIncident
+//
+//	INSERT INTO sos_incident (account_id, created, message, escalation_level, next_escalation)
+//	VALUES ($1, $2, $3, 0, $4)
+//	RETURNING id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+func (q *Queries) CreateSosIncident(ctx context.Context, arg CreateSosIncidentParams) (SosIncident, error) {
+	row := q.db.QueryRow(ctx, createSosIncident,
+		arg.AccountID,
+		arg.Created,
+		arg.Message,
+		arg.NextEscalation,
+	)
+	var i SosIncident
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Created,
+		&i.Message,
+		&i.EscalationLevel,
+		&i.NextEscalation,
+		&i.AcknowledgedBy,
+		&i.Acknowledged,
+	)
+	return i, err
+}
+
 const createUpdate = `-- name: CreateUpdate :one
 INSERT INTO updates (account_id, created, data)
 VALUES ($1, $2, $3)
@@ -102,9 +630,9 @@ RETURNING id
 `
 
 type CreateUpdateParams struct {
-	AccountID int64
-	Created   time.Time
-	Data      api.UpdateData
+	AccountID int64          `db:"account_id"`
+	Created   time.Time      `db:"created"`
+	Data      api.UpdateData `db:"data"`
 }
 
 // CreateUpdate
@@ -119,126 +647,467 @@ func (q *Queries) CreateUpdate(ctx context.Context, arg CreateUpdateParams) (int
 	return id, err
 }
 
-const deleteFence = `-- name: DeleteFence :exec
-DELETE
-FROM fence
-WHERE id = $1
+const createWatch = `-- name: CreateWatch :one
+INSERT INTO watch (owner_id, target_id, fence_id, distance, direction, created, expires)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, owner_id, target_id, fence_id, distance, direction, created, expires
 `
 
-// DeleteFence
-//
-//	DELETE
-//	FROM fence
-//	WHERE id = $1
-func (q *Queries) DeleteFence(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteFence, id)
-	return err
+type CreateWatchParams struct {
+	OwnerID   int64     `db:"owner_id"`
+	TargetID  int64     `db:"target_id"`
+	FenceID   *int64    `db:"fence_id"`
+	Distance  *float64  `db:"distance"`
+	Direction string    `db:"direction"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
 }
 
-const getAccount = `-- name: GetAccount :one
-SELECT id, token, name, chat_id, status
-FROM account
-WHERE id = $1
-LIMIT 1
-`
-
-// GetAccount
+// CreateWatch
 //
-//	SELECT id, token, name, chat_id, status
-//	FROM account
-//	WHERE id = $1
-//	LIMIT 1
-func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
-	row := q.db.QueryRow(ctx, getAccount, id)
-	var i Account
+//	INSERT INTO watch (owner_id, target_id, fence_id, distance, direction, created, expires)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7)
+//	RETURNING id, owner_id, target_id, fence_id, distance, direction, created, expires
+func (q *Queries) CreateWatch(ctx context.Context, arg CreateWatchParams) (Watch, error) {
+	row := q.db.QueryRow(ctx, createWatch,
+		arg.OwnerID,
+		arg.TargetID,
+		arg.FenceID,
+		arg.Distance,
+		arg.Direction,
+		arg.Created,
+		arg.Expires,
+	)
+	var i Watch
 	err := row.Scan(
 		&i.ID,
-		&i.Token,
-		&i.Name,
-		&i.ChatID,
-		&i.Status,
+		&i.OwnerID,
+		&i.TargetID,
+		&i.FenceID,
+		&i.Distance,
+		&i.Direction,
+		&i.Created,
+		&i.Expires,
 	)
 	return i, err
 }
 
-const getAccountByChatID = `-- name: GetAccountByChatID :one
-SELECT id, token, name, chat_id, status
-FROM account
-WHERE chat_id = $1
-LIMIT 1
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_delivery (subscription_id, event, payload, status, attempts, next_attempt, created)
+VALUES ($1, $2, $3, 'pending', 0, $4, $4)
+RETURNING id
 `
 
-// GetAccountByChatID
+type CreateWebhookDeliveryParams struct {
+	SubscriptionID int64     `db:"subscription_id"`
+	Event          string    `db:"event"`
+	Payload        []byte    `db:"payload"`
+	NextAttempt    time.Time `db:"next_attempt"`
+}
+
+// CreateWebhookDelivery
 //
-//	SELECT id, token, name, chat_id, status
-//	FROM account
-//	WHERE chat_id = $1
-//	LIMIT 1
-func (q *Queries) GetAccountByChatID(ctx context.Context, chatID *int64) (Account, error) {
-	row := q.db.QueryRow(ctx, getAccountByChatID, chatID)
-	var i Account
-	err := row.Scan(
-		&i.ID,
-		&i.Token,
-		&i.Name,
-		&i.ChatID,
-		&i.Status,
+//	INSERT INTO webhook_delivery (subscription_id, event, payload, status, attempts, next_attempt, created)
+//	VALUES ($1, $2, $3, 'pending', 0, $4, $4)
+//	RETURNING id
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, createWebhookDelivery,
+		arg.SubscriptionID,
+		arg.Event,
+		arg.Payload,
+		arg.NextAttempt,
 	)
-	return i, err
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
-const getAccountByToken = `-- name: GetAccountByToken :one
-SELECT id, token, name, chat_id, status
-FROM account
-WHERE token = $1
-LIMIT 1
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscription (url, secret, events, enabled, created)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, url, secret, events, enabled, created
 `
 
-// GetAccountByToken
+type CreateWebhookSubscriptionParams struct {
+	Url     string    `db:"url"`
+	Secret  string    `db:"secret"`
+	Events  []string  `db:"events"`
+	Enabled bool      `db:"enabled"`
+	Created time.Time `db:"created"`
+}
+
+// CreateWebhookSubscription
 //
-//	SELECT id, token, name, chat_id, status
-//	FROM account
-//	WHERE token = $1
-//	LIMIT 1
-func (q *Queries) GetAccountByToken(ctx context.Context, token string) (Account, error) {
-	row := q.db.QueryRow(ctx, getAccountByToken, token)
-	var i Account
+//	INSERT INTO webhook_subscription (url, secret, events, enabled, created)
+//	VALUES ($1, $2, $3, $4, $5)
+//	RETURNING id, url, secret, events, enabled, created
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+		arg.Enabled,
+		arg.Created,
+	)
+	var i WebhookSubscription
 	err := row.Scan(
 		&i.ID,
-		&i.Token,
-		&i.Name,
-		&i.ChatID,
-		&i.Status,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Enabled,
+		&i.Created,
 	)
 	return i, err
 }
 
-const getAllAccounts = `-- name: GetAllAccounts :many
-SELECT id, token, name, chat_id, status
-FROM account
-ORDER BY id
-`
+const deleteBotState = `-- name: DeleteBotState :exec
+DELETE
+FROM bot_state
+WHERE chat_id = $1
+`
 
-// GetAllAccounts
+// DeleteBotState
+//
+//	DELETE
+//	FROM bot_state
+//	WHERE chat_id = $1
+func (q *Queries) DeleteBotState(ctx context.Context, chatID int64) error {
+	_, err := q.db.Exec(ctx, deleteBotState, chatID)
+	return err
+}
+
+const deleteExpiredLiveLocations = `-- name: DeleteExpiredLiveLocations :exec
+DELETE
+FROM live_location
+WHERE expires <= $1
+`
+
+// DeleteExpiredLiveLocations
+//
+//	DELETE
+//	FROM live_location
+//	WHERE expires <= $1
+func (q *Queries) DeleteExpiredLiveLocations(ctx context.Context, expires time.Time) error {
+	_, err := q.db.Exec(ctx, deleteExpiredLiveLocations, expires)
+	return err
+}
+
+const deleteExpiredWatches = `-- name: DeleteExpiredWatches :exec
+DELETE
+FROM watch
+WHERE expires <= $1
+`
+
+// DeleteExpiredWatches
+//
+//	DELETE
+//	FROM watch
+//	WHERE expires <= $1
+func (q *Queries) DeleteExpiredWatches(ctx context.Context, expires time.Time) error {
+	_, err := q.db.Exec(ctx, deleteExpiredWatches, expires)
+	return err
+}
+
+const deleteFence = `-- name: DeleteFence :exec
+DELETE
+FROM fence
+WHERE id = $1
+`
+
+// DeleteFence
+//
+//	DELETE
+//	FROM fence
+//	WHERE id = $1
+func (q *Queries) DeleteFence(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteFence, id)
+	return err
+}
+
+const deleteFiredWatch = `-- name: DeleteFiredWatch :exec
+DELETE
+FROM watch
+WHERE id = $1
+`
+
+// DeleteFiredWatch
+//
+//	DELETE
+//	FROM watch
+//	WHERE id = $1
+func (q *Queries) DeleteFiredWatch(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteFiredWatch, id)
+	return err
+}
+
+const deleteLiveLocation = `-- name: DeleteLiveLocation :exec
+DELETE
+FROM live_location
+WHERE id = $1
+`
+
+// DeleteLiveLocation
+//
+//	DELETE
+//	FROM live_location
+//	WHERE id = $1
+func (q *Queries) DeleteLiveLocation(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteLiveLocation, id)
+	return err
+}
+
+const deleteMigration = `-- name: DeleteMigration :exec
+DELETE
+FROM migration
+WHERE id = $1
+`
+
+// DeleteMigration
+//
+//	DELETE
+//	FROM migration
+//	WHERE id = $1
+func (q *Queries) DeleteMigration(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, deleteMigration, id)
+	return err
+}
+
+const deleteProximityRule = `-- name: DeleteProximityRule :execrows
+DELETE
+FROM proximity_rule
+WHERE id = $1
+`
+
+// DeleteProximityRule
+//
+//	DELETE
+//	FROM proximity_rule
+//	WHERE id = $1
+func (q *Queries) DeleteProximityRule(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProximityRule, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWatch = `-- name: DeleteWatch :execrows
+DELETE
+FROM watch
+WHERE id = $1
+  AND owner_id = $2
+`
+
+type DeleteWatchParams struct {
+	ID      int64 `db:"id"`
+	OwnerID int64 `db:"owner_id"`
+}
+
+// DeleteWatch
+//
+//	DELETE
+//	FROM watch
+//	WHERE id = $1
+//	  AND owner_id = $2
+func (q *Queries) DeleteWatch(ctx context.Context, arg DeleteWatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWatch, arg.ID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :execrows
+DELETE
+FROM webhook_subscription
+WHERE id = $1
+`
+
+// DeleteWebhookSubscription
+//
+//	DELETE
+//	FROM webhook_subscription
+//	WHERE id = $1
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhookSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT id, token, name, chat_id, status, settings
+FROM account
+WHERE id = $1
+LIMIT 1
+`
+
+// GetAccount
+//
+//	SELECT id, token, name, chat_id, status, settings
+//	FROM account
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.Name,
+		&i.ChatID,
+		&i.Status,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getAccountByChatID = `-- name: GetAccountByChatID :one
+SELECT id, token, name, chat_id, status, settings
+FROM account
+WHERE chat_id = $1
+LIMIT 1
+`
+
+// GetAccountByChatID
+//
+//	SELECT id, token, name, chat_id, status, settings
+//	FROM account
+//	WHERE chat_id = $1
+//	LIMIT 1
+func (q *Queries) GetAccountByChatID(ctx context.Context, chatID *int64) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByChatID, chatID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.Name,
+		&i.ChatID,
+		&i.Status,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getAccountByName = `-- name: GetAccountByName :one
+SELECT id, token, name, chat_id, status, settings
+FROM account
+WHERE name = $1
+LIMIT 1
+`
+
+// GetAccountByName
+//
+//	SELECT id, token, name, chat_id, status, settings
+//	FROM account
+//	WHERE name = $1
+//	LIMIT 1
+func (q *Queries) GetAccountByName(ctx context.Context, name string) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByName, name)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.Name,
+		&i.ChatID,
+		&i.Status,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getAccountByToken = `-- name: GetAccountByToken :one
+SELECT id, token, name, chat_id, status, settings
+FROM account
+WHERE token = $1
+LIMIT 1
+`
+
+// GetAccountByToken
+//
+//	SELECT id, token, name, chat_id, status, settings
+//	FROM account
+//	WHERE token = $1
+//	LIMIT 1
+func (q *Queries) GetAccountByToken(ctx context.Context, token string) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByToken, token)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.Name,
+		&i.ChatID,
+		&i.Status,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getAccountForUpdate = `-- name: GetAccountForUpdate :one
+SELECT id, token, name, chat_id, status, settings
+FROM account
+WHERE id = $1
+FOR UPDATE
+`
+
+// GetAccountForUpdate
 //
-//	SELECT id, token, name, chat_id, status
+//	SELECT id, token, name, chat_id, status, settings
 //	FROM account
+//	WHERE id = $1
+//	FOR UPDATE
+func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountForUpdate, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.Name,
+		&i.ChatID,
+		&i.Status,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getActiveLiveLocationsByAccountID = `-- name: GetActiveLiveLocationsByAccountID :many
+SELECT id, chat_id, account_id, message_id, created, expires
+FROM live_location
+WHERE account_id = $1
+  AND expires > $2
+ORDER BY id
+`
+
+type GetActiveLiveLocationsByAccountIDParams struct {
+	AccountID int64     `db:"account_id"`
+	Expires   time.Time `db:"expires"`
+}
+
+// GetActiveLiveLocationsByAccountID
+//
+//	SELECT id, chat_id, account_id, message_id, created, expires
+//	FROM live_location
+//	WHERE account_id = $1
+//	  AND expires > $2
 //	ORDER BY id
-func (q *Queries) GetAllAccounts(ctx context.Context) ([]Account, error) {
-	rows, err := q.db.Query(ctx, getAllAccounts)
+func (q *Queries) GetActiveLiveLocationsByAccountID(ctx context.Context, arg GetActiveLiveLocationsByAccountIDParams) ([]LiveLocation, error) {
+	rows, err := q.db.Query(ctx, getActiveLiveLocationsByAccountID, arg.AccountID, arg.Expires)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Account{}
+	items := []LiveLocation{}
 	for rows.Next() {
-		var i Account
+		var i LiveLocation
 		if err := rows.Scan(
 			&i.ID,
-			&i.Token,
-			&i.Name,
 			&i.ChatID,
-			&i.Status,
+			&i.AccountID,
+			&i.MessageID,
+			&i.Created,
+			&i.Expires,
 		); err != nil {
 			return nil, err
 		}
@@ -250,30 +1119,42 @@ func (q *Queries) GetAllAccounts(ctx context.Context) ([]Account, error) {
 	return items, nil
 }
 
-const getAllFences = `-- name: GetAllFences :many
-SELECT id, name, longitude, latitude, radius
-FROM fence
+const getActiveLiveLocationsByChatID = `-- name: GetActiveLiveLocationsByChatID :many
+SELECT id, chat_id, account_id, message_id, created, expires
+FROM live_location
+WHERE chat_id = $1
+  AND expires > $2
+ORDER BY id
 `
 
-// GetAllFences
+type GetActiveLiveLocationsByChatIDParams struct {
+	ChatID  int64     `db:"chat_id"`
+	Expires time.Time `db:"expires"`
+}
+
+// GetActiveLiveLocationsByChatID
 //
-//	SELECT id, name, longitude, latitude, radius
-//	FROM fence
-func (q *Queries) GetAllFences(ctx context.Context) ([]Fence, error) {
-	rows, err := q.db.Query(ctx, getAllFences)
+//	SELECT id, chat_id, account_id, message_id, created, expires
+//	FROM live_location
+//	WHERE chat_id = $1
+//	  AND expires > $2
+//	ORDER BY id
+func (q *Queries) GetActiveLiveLocationsByChatID(ctx context.Context, arg GetActiveLiveLocationsByChatIDParams) ([]LiveLocation, error) {
+	rows, err := q.db.Query(ctx, getActiveLiveLocationsByChatID, arg.ChatID, arg.Expires)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Fence{}
+	items := []LiveLocation{}
 	for rows.Next() {
-		var i Fence
+		var i LiveLocation
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Longitude,
-			&i.Latitude,
-			&i.Radius,
+			&i.ChatID,
+			&i.AccountID,
+			&i.MessageID,
+			&i.Created,
+			&i.Expires,
 		); err != nil {
 			return nil, err
 		}
@@ -285,35 +1166,61 @@ func (q *Queries) GetAllFences(ctx context.Context) ([]Fence, error) {
 	return items, nil
 }
 
-const getLastUpdateByAccountID = `-- name: GetLastUpdateByAccountID :many
-SELECT id, account_id, created, data
-FROM updates
+const getActiveShareLinksByAccountID = `-- name: GetActiveShareLinksByAccountID :many
+SELECT share_link.id, share_link.token, share_link.account_id, share_link.scope, share_link.precision, share_link.created, share_link.expires, share_link.revoked,
+       (SELECT COUNT(*) FROM share_access WHERE share_access.share_id = share_link.id) AS access_count
+FROM share_link
 WHERE account_id = $1
-ORDER BY id DESC
-LIMIT 1
+  AND revoked IS NULL
+  AND expires > $2
+ORDER BY id
 `
 
-// GetLastUpdateByAccountID
+type GetActiveShareLinksByAccountIDParams struct {
+	AccountID int64     `db:"account_id"`
+	Expires   time.Time `db:"expires"`
+}
+
+type GetActiveShareLinksByAccountIDRow struct {
+	ID          int64      `db:"id"`
+	Token       string     `db:"token"`
+	AccountID   int64      `db:"account_id"`
+	Scope       string     `db:"scope"`
+	Precision   int32      `db:"precision"`
+	Created     time.Time  `db:"created"`
+	Expires     time.Time  `db:"expires"`
+	Revoked     *time.Time `db:"revoked"`
+	AccessCount int64      `db:"access_count"`
+}
+
+// GetActiveShareLinksByAccountID
 //
-//	SELECT id, account_id, created, data
-//	FROM updates
+//	SELECT share_link.id, share_link.token, share_link.account_id, share_link.scope, share_link.precision, share_link.created, share_link.expires, share_link.revoked,
+//	       (SELECT COUNT(*) FROM share_access WHERE share_access.share_id = share_link.id) AS access_count
+//	FROM share_link
 //	WHERE account_id = $1
-//	ORDER BY id DESC
-//	LIMIT 1
-func (q *Queries) GetLastUpdateByAccountID(ctx context.Context, accountID int64) ([]Update, error) {
-	rows, err := q.db.Query(ctx, getLastUpdateByAccountID, accountID)
+//	  AND revoked IS NULL
+//	  AND expires > $2
+//	ORDER BY id
+func (q *Queries) GetActiveShareLinksByAccountID(ctx context.Context, arg GetActiveShareLinksByAccountIDParams) ([]GetActiveShareLinksByAccountIDRow, error) {
+	rows, err := q.db.Query(ctx, getActiveShareLinksByAccountID, arg.AccountID, arg.Expires)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Update{}
+	items := []GetActiveShareLinksByAccountIDRow{}
 	for rows.Next() {
-		var i Update
+		var i GetActiveShareLinksByAccountIDRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.Token,
 			&i.AccountID,
+			&i.Scope,
+			&i.Precision,
 			&i.Created,
-			&i.Data,
+			&i.Expires,
+			&i.Revoked,
+			&i.AccessCount,
 		); err != nil {
 			return nil, err
 		}
@@ -325,35 +1232,147 @@ func (q *Queries) GetLastUpdateByAccountID(ctx context.Context, accountID int64)
 	return items, nil
 }
 
-const getLatestUpdatesByAccountID = `-- name: GetLatestUpdatesByAccountID :many
-SELECT id, account_id, created, data
-FROM updates
-WHERE account_id = $1
+const getActiveWatchesByOwnerID = `-- name: GetActiveWatchesByOwnerID :many
+SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+FROM watch
+WHERE owner_id = $1
+  AND expires > $2
+ORDER BY id
+`
+
+type GetActiveWatchesByOwnerIDParams struct {
+	OwnerID int64     `db:"owner_id"`
+	Expires time.Time `db:"expires"`
+}
+
+// GetActiveWatchesByOwnerID
+//
+//	SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+//	FROM watch
+//	WHERE owner_id = $1
+//	  AND expires > $2
+//	ORDER BY id
+func (q *Queries) GetActiveWatchesByOwnerID(ctx context.Context, arg GetActiveWatchesByOwnerIDParams) ([]Watch, error) {
+	rows, err := q.db.Query(ctx, getActiveWatchesByOwnerID, arg.OwnerID, arg.Expires)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Watch{}
+	for rows.Next() {
+		var i Watch
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.TargetID,
+			&i.FenceID,
+			&i.Distance,
+			&i.Direction,
+			&i.Created,
+			&i.Expires,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActiveWatchesByTargetID = `-- name: GetActiveWatchesByTargetID :many
+SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+FROM watch
+WHERE target_id = $1
+  AND expires > $2
+ORDER BY id
+`
+
+type GetActiveWatchesByTargetIDParams struct {
+	TargetID int64     `db:"target_id"`
+	Expires  time.Time `db:"expires"`
+}
+
+// GetActiveWatchesByTargetID
+//
+//	SELECT id, owner_id, target_id, fence_id, distance, direction, created, expires
+//	FROM watch
+//	WHERE target_id = $1
+//	  AND expires > $2
+//	ORDER BY id
+func (q *Queries) GetActiveWatchesByTargetID(ctx context.Context, arg GetActiveWatchesByTargetIDParams) ([]Watch, error) {
+	rows, err := q.db.Query(ctx, getActiveWatchesByTargetID, arg.TargetID, arg.Expires)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Watch{}
+	for rows.Next() {
+		var i Watch
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.TargetID,
+			&i.FenceID,
+			&i.Distance,
+			&i.Direction,
+			&i.Created,
+			&i.Expires,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertOutbox = `-- name: GetAlertOutbox :many
+SELECT id, account_id, event, channel, target, title, text, status, attempts, next_attempt, last_error, created, sent
+FROM alert_outbox
+WHERE ($1::VARCHAR IS NULL OR status = $1::VARCHAR)
 ORDER BY id DESC
-LIMIT 10
+LIMIT $2
 `
 
-// GetLatestUpdatesByAccountID
+type GetAlertOutboxParams struct {
+	Status   *string `db:"status"`
+	MaxCount int32   `db:"max_count"`
+}
+
+// GetAlertOutbox
 //
-//	SELECT id, account_id, created, data
-//	FROM updates
-//	WHERE account_id = $1
+//	SELECT id, account_id, event, channel, target, title, text, status, attempts, next_attempt, last_error, created, sent
+//	FROM alert_outbox
+//	WHERE ($1::VARCHAR IS NULL OR status = $1::VARCHAR)
 //	ORDER BY id DESC
-//	LIMIT 10
-func (q *Queries) GetLatestUpdatesByAccountID(ctx context.Context, accountID int64) ([]Update, error) {
-	rows, err := q.db.Query(ctx, getLatestUpdatesByAccountID, accountID)
+//	LIMIT $2
+func (q *Queries) GetAlertOutbox(ctx context.Context, arg GetAlertOutboxParams) ([]AlertOutbox, error) {
+	rows, err := q.db.Query(ctx, getAlertOutbox, arg.Status, arg.MaxCount)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Update{}
+	items := []AlertOutbox{}
 	for rows.Next() {
-		var i Update
+		var i AlertOutbox
 		if err := rows.Scan(
 			&i.ID,
 			&i.AccountID,
+			&i.Event,
+			&i.Channel,
+			&i.Target,
+			&i.Title,
+			&i.Text,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttempt,
+			&i.LastError,
 			&i.Created,
-			&i.Data,
+			&i.Sent,
 		); err != nil {
 			return nil, err
 		}
@@ -365,27 +1384,34 @@ func (q *Queries) GetLatestUpdatesByAccountID(ctx context.Context, accountID int
 	return items, nil
 }
 
-const getMigrations = `-- name: GetMigrations :many
-SELECT id, applied
-FROM migration
+const getAllAccounts = `-- name: GetAllAccounts :many
+SELECT id, token, name, chat_id, status, settings
+FROM account
 ORDER BY id
 `
 
-// GetMigrations
+// GetAllAccounts
 //
-//	SELECT id, applied
-//	FROM migration
+//	SELECT id, token, name, chat_id, status, settings
+//	FROM account
 //	ORDER BY id
-func (q *Queries) GetMigrations(ctx context.Context) ([]Migration, error) {
-	rows, err := q.db.Query(ctx, getMigrations)
+func (q *Queries) GetAllAccounts(ctx context.Context) ([]Account, error) {
+	rows, err := q.db.Query(ctx, getAllAccounts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Migration{}
+	items := []Account{}
 	for rows.Next() {
-		var i Migration
-		if err := rows.Scan(&i.ID, &i.Applied); err != nil {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Token,
+			&i.Name,
+			&i.ChatID,
+			&i.Status,
+			&i.Settings,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -396,23 +1422,1630 @@ func (q *Queries) GetMigrations(ctx context.Context) ([]Migration, error) {
 	return items, nil
 }
 
-const updateAccountStatus = `-- name: UpdateAccountStatus :exec
-UPDATE account
-SET status = $2
-WHERE id = $1
+const getAllFences = `-- name: GetAllFences :many
+SELECT id, name, longitude, latitude, radius, settings
+FROM fence
+ORDER BY id
 `
 
-type UpdateAccountStatusParams struct {
-	ID     int64
-	Status api.AccountStatus
+// GetAllFences
+//
+//	SELECT id, name, longitude, latitude, radius, settings
+//	FROM fence
+//	ORDER BY id
+func (q *Queries) GetAllFences(ctx context.Context) ([]Fence, error) {
+	rows, err := q.db.Query(ctx, getAllFences)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Fence{}
+	for rows.Next() {
+		var i Fence
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Longitude,
+			&i.Latitude,
+			&i.Radius,
+			&i.Settings,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-// UpdateAccountStatus
+const getAllProximityRules = `-- name: GetAllProximityRules :many
+SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+FROM proximity_rule
+ORDER BY id
+`
+
+// GetAllProximityRules
 //
-//	UPDATE account
-//	SET status = $2
+//	SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+//	FROM proximity_rule
+//	ORDER BY id
+func (q *Queries) GetAllProximityRules(ctx context.Context) ([]ProximityRule, error) {
+	rows, err := q.db.Query(ctx, getAllProximityRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProximityRule{}
+	for rows.Next() {
+		var i ProximityRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstAccountID,
+			&i.SecondAccountID,
+			&i.Distance,
+			&i.NotifyMeet,
+			&i.NotifyPart,
+			&i.Together,
+			&i.Changed,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllWebhookSubscriptions = `-- name: GetAllWebhookSubscriptions :many
+SELECT id, url, secret, events, enabled, created
+FROM webhook_subscription
+ORDER BY id
+`
+
+// GetAllWebhookSubscriptions
+//
+//	SELECT id, url, secret, events, enabled, created
+//	FROM webhook_subscription
+//	ORDER BY id
+func (q *Queries) GetAllWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, getAllWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.Enabled,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBotState = `-- name: GetBotState :one
+SELECT chat_id, stage, data, expires
+FROM bot_state
+WHERE chat_id = $1
+LIMIT 1
+`
+
+// GetBotState
+//
+//	SELECT chat_id, stage, data, expires
+//	FROM bot_state
+//	WHERE chat_id = $1
+//	LIMIT 1
+func (q *Queries) GetBotState(ctx context.Context, chatID int64) (BotState, error) {
+	row := q.db.QueryRow(ctx, getBotState, chatID)
+	var i BotState
+	err := row.Scan(
+		&i.ChatID,
+		&i.Stage,
+		&i.Data,
+		&i.Expires,
+	)
+	return i, err
+}
+
+const getEnabledWebhookSubscriptionsByEvent = `-- name: GetEnabledWebhookSubscriptionsByEvent :many
+SELECT id, url, secret, events, enabled, created
+FROM webhook_subscription
+WHERE enabled
+  AND $1::VARCHAR = ANY (events)
+`
+
+// GetEnabledWebhookSubscriptionsByEvent
+//
+//	SELECT id, url, secret, events, enabled, created
+//	FROM webhook_subscription
+//	WHERE enabled
+//	  AND $1::VARCHAR = ANY (events)
+func (q *Queries) GetEnabledWebhookSubscriptionsByEvent(ctx context.Context, event string) ([]WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, getEnabledWebhookSubscriptionsByEvent, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.Enabled,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFence = `-- name: GetFence :one
+SELECT id, name, longitude, latitude, radius, settings
+FROM fence
+WHERE id = $1
+LIMIT 1
+`
+
+// GetFence
+//
+//	SELECT id, name, longitude, latitude, radius, settings
+//	FROM fence
 //	WHERE id = $1
-func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) error {
-	_, err := q.db.Exec(ctx, updateAccountStatus, arg.ID, arg.Status)
-	return err
+//	LIMIT 1
+func (q *Queries) GetFence(ctx context.Context, id int64) (Fence, error) {
+	row := q.db.QueryRow(ctx, getFence, id)
+	var i Fence
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Longitude,
+		&i.Latitude,
+		&i.Radius,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const getLastLocationUpdateByAccountID = `-- name: GetLastLocationUpdateByAccountID :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+  AND data -> 'location' IS NOT NULL
+ORDER BY id DESC
+LIMIT 1
+`
+
+// GetLastLocationUpdateByAccountID
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	  AND data -> 'location' IS NOT NULL
+//	ORDER BY id DESC
+//	LIMIT 1
+func (q *Queries) GetLastLocationUpdateByAccountID(ctx context.Context, accountID int64) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLastLocationUpdateByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastUpdateByAccountID = `-- name: GetLastUpdateByAccountID :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+// GetLastUpdateByAccountID
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	ORDER BY id DESC
+//	LIMIT 1
+func (q *Queries) GetLastUpdateByAccountID(ctx context.Context, accountID int64) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLastUpdateByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestUpdatesByAccountID = `-- name: GetLatestUpdatesByAccountID :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 10
+`
+
+// GetLatestUpdatesByAccountID
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	ORDER BY id DESC
+//	LIMIT 10
+func (q *Queries) GetLatestUpdatesByAccountID(ctx context.Context, accountID int64) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLatestUpdatesByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLiveLocation = `-- name: GetLiveLocation :one
+SELECT id, chat_id, account_id, message_id, created, expires
+FROM live_location
+WHERE id = $1
+LIMIT 1
+`
+
+// GetLiveLocation
+//
+//	SELECT id, chat_id, account_id, message_id, created, expires
+//	FROM live_location
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetLiveLocation(ctx context.Context, id int64) (LiveLocation, error) {
+	row := q.db.QueryRow(ctx, getLiveLocation, id)
+	var i LiveLocation
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.AccountID,
+		&i.MessageID,
+		&i.Created,
+		&i.Expires,
+	)
+	return i, err
+}
+
+const getLocationUpdatesByAccountIDBetween = `-- name: GetLocationUpdatesByAccountIDBetween :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+  AND created >= $2
+  AND created < $3
+  AND data -> 'location' IS NOT NULL
+ORDER BY id
+`
+
+type GetLocationUpdatesByAccountIDBetweenParams struct {
+	AccountID int64     `db:"account_id"`
+	Since     time.Time `db:"since"`
+	Until     time.Time `db:"until"`
+}
+
+// GetLocationUpdatesByAccountIDBetween
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	  AND created >= $2
+//	  AND created < $3
+//	  AND data -> 'location' IS NOT NULL
+//	ORDER BY id
+func (q *Queries) GetLocationUpdatesByAccountIDBetween(ctx context.Context, arg GetLocationUpdatesByAccountIDBetweenParams) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLocationUpdatesByAccountIDBetween, arg.AccountID, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLocationUpdatesByAccountIDSince = `-- name: GetLocationUpdatesByAccountIDSince :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+  AND created >= $2
+  AND data -> 'location' IS NOT NULL
+ORDER BY id
+`
+
+type GetLocationUpdatesByAccountIDSinceParams struct {
+	AccountID int64     `db:"account_id"`
+	Created   time.Time `db:"created"`
+}
+
+// GetLocationUpdatesByAccountIDSince
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	  AND created >= $2
+//	  AND data -> 'location' IS NOT NULL
+//	ORDER BY id
+func (q *Queries) GetLocationUpdatesByAccountIDSince(ctx context.Context, arg GetLocationUpdatesByAccountIDSinceParams) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getLocationUpdatesByAccountIDSince, arg.AccountID, arg.Created)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMigrationTableState = `-- name: GetMigrationTableState :one
+SELECT (to_regclass('migration') IS NOT NULL)::boolean AS table_exists,
+       EXISTS (SELECT 1
+               FROM information_schema.columns
+               WHERE table_schema = current_schema()
+                 AND table_name = 'migration'
+                 AND column_name = 'checksum')::boolean AS has_checksum
+`
+
+type GetMigrationTableStateRow struct {
+	TableExists bool `db:"table_exists"`
+	HasChecksum bool `db:"has_checksum"`
+}
+
+// GetMigrationTableState
+//
+//	SELECT (to_regclass('migration') IS NOT NULL)::boolean AS table_exists,
+//	       EXISTS (SELECT 1
+//	               FROM information_schema.columns
+//	               WHERE table_schema = current_schema()
+//	                 AND table_name = 'migration'
+//	                 AND column_name = 'checksum')::boolean AS has_checksum
+func (q *Queries) GetMigrationTableState(ctx context.Context) (GetMigrationTableStateRow, error) {
+	row := q.db.QueryRow(ctx, getMigrationTableState)
+	var i GetMigrationTableStateRow
+	err := row.Scan(&i.TableExists, &i.HasChecksum)
+	return i, err
+}
+
+const getMigrations = `-- name: GetMigrations :many
+SELECT id, applied, checksum
+FROM migration
+ORDER BY id
+`
+
+// GetMigrations
+//
+//	SELECT id, applied, checksum
+//	FROM migration
+//	ORDER BY id
+func (q *Queries) GetMigrations(ctx context.Context) ([]Migration, error) {
+	rows, err := q.db.Query(ctx, getMigrations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Migration{}
+	for rows.Next() {
+		var i Migration
+		if err := rows.Scan(&i.ID, &i.Applied, &i.Checksum); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProximityRule = `-- name: GetProximityRule :one
+SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+FROM proximity_rule
+WHERE id = $1
+LIMIT 1
+`
+
+// GetProximityRule
+//
+//	SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+//	FROM proximity_rule
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetProximityRule(ctx context.Context, id int64) (ProximityRule, error) {
+	row := q.db.QueryRow(ctx, getProximityRule, id)
+	var i ProximityRule
+	err := row.Scan(
+		&i.ID,
+		&i.FirstAccountID,
+		&i.SecondAccountID,
+		&i.Distance,
+		&i.NotifyMeet,
+		&i.NotifyPart,
+		&i.Together,
+		&i.Changed,
+		&i.Created,
+	)
+	return i, err
+}
+
+const getProximityRuleByPair = `-- name: GetProximityRuleByPair :many
+SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+FROM proximity_rule
+WHERE first_account_id = $1
+  AND second_account_id = $2
+LIMIT 1
+`
+
+type GetProximityRuleByPairParams struct {
+	FirstAccountID  int64 `db:"first_account_id"`
+	SecondAccountID int64 `db:"second_account_id"`
+}
+
+// GetProximityRuleByPair
+//
+//	SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+//	FROM proximity_rule
+//	WHERE first_account_id = $1
+//	  AND second_account_id = $2
+//	LIMIT 1
+func (q *Queries) GetProximityRuleByPair(ctx context.Context, arg GetProximityRuleByPairParams) ([]ProximityRule, error) {
+	rows, err := q.db.Query(ctx, getProximityRuleByPair, arg.FirstAccountID, arg.SecondAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProximityRule{}
+	for rows.Next() {
+		var i ProximityRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstAccountID,
+			&i.SecondAccountID,
+			&i.Distance,
+			&i.NotifyMeet,
+			&i.NotifyPart,
+			&i.Together,
+			&i.Changed,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProximityRulesByAccountID = `-- name: GetProximityRulesByAccountID :many
+SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+FROM proximity_rule
+WHERE first_account_id = $1
+   OR second_account_id = $1
+ORDER BY id
+`
+
+// GetProximityRulesByAccountID
+//
+//	SELECT id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+//	FROM proximity_rule
+//	WHERE first_account_id = $1
+//	   OR second_account_id = $1
+//	ORDER BY id
+func (q *Queries) GetProximityRulesByAccountID(ctx context.Context, accountID int64) ([]ProximityRule, error) {
+	rows, err := q.db.Query(ctx, getProximityRulesByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProximityRule{}
+	for rows.Next() {
+		var i ProximityRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstAccountID,
+			&i.SecondAccountID,
+			&i.Distance,
+			&i.NotifyMeet,
+			&i.NotifyPart,
+			&i.Together,
+			&i.Changed,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQueueDepths = `-- name: GetQueueDepths :one
+SELECT (SELECT count(*) FROM alert_outbox WHERE status IN ('pending', 'sending'))     AS pending_alerts,
+       (SELECT count(*) FROM webhook_delivery WHERE status IN ('pending', 'sending')) AS pending_webhooks,
+       (SELECT count(*) FROM webhook_delivery WHERE status = 'failed')                AS failed_webhooks,
+       (SELECT count(*) FROM sos_incident WHERE acknowledged IS NULL)                 AS open_sos
+`
+
+type GetQueueDepthsRow struct {
+	PendingAlerts   int64 `db:"pending_alerts"`
+	PendingWebhooks int64 `db:"pending_webhooks"`
+	FailedWebhooks  int64 `db:"failed_webhooks"`
+	OpenSos         int64 `db:"open_sos"`
+}
+
+// GetQueueDepths
+//
+//	SELECT (SELECT count(*) FROM alert_outbox WHERE status IN ('pending', 'sending'))     AS pending_alerts,
+//	       (SELECT count(*) FROM webhook_delivery WHERE status IN ('pending', 'sending')) AS pending_webhooks,
+//	       (SELECT count(*) FROM webhook_delivery WHERE status = 'failed')                AS failed_webhooks,
+//	       (SELECT count(*) FROM sos_incident WHERE acknowledged IS NULL)                 AS open_sos
+func (q *Queries) GetQueueDepths(ctx context.Context) (GetQueueDepthsRow, error) {
+	row := q.db.QueryRow(ctx, getQueueDepths)
+	var i GetQueueDepthsRow
+	err := row.Scan(
+		&i.PendingAlerts,
+		&i.PendingWebhooks,
+		&i.FailedWebhooks,
+		&i.OpenSos,
+	)
+	return i, err
+}
+
+const getSchemaColumns = `-- name: GetSchemaColumns :many
+SELECT table_name::text  AS table_name,
+       column_name::text AS column_name,
+       data_type::text   AS data_type,
+       is_nullable = 'YES' AS nullable
+FROM information_schema.columns
+WHERE table_schema = current_schema()
+`
+
+type GetSchemaColumnsRow struct {
+	TableName  string `db:"table_name"`
+	ColumnName string `db:"column_name"`
+	DataType   string `db:"data_type"`
+	Nullable   bool   `db:"nullable"`
+}
+
+// GetSchemaColumns
+//
+//	SELECT table_name::text  AS table_name,
+//	       column_name::text AS column_name,
+//	       data_type::text   AS data_type,
+//	       is_nullable = 'YES' AS nullable
+//	FROM information_schema.columns
+//	WHERE table_schema = current_schema()
+func (q *Queries) GetSchemaColumns(ctx context.Context) ([]GetSchemaColumnsRow, error) {
+	rows, err := q.db.Query(ctx, getSchemaColumns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSchemaColumnsRow{}
+	for rows.Next() {
+		var i GetSchemaColumnsRow
+		if err := rows.Scan(
+			&i.TableName,
+			&i.ColumnName,
+			&i.DataType,
+			&i.Nullable,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShareLinkByToken = `-- name: GetShareLinkByToken :one
+SELECT id, token, account_id, scope, precision, created, expires, revoked
+FROM share_link
+WHERE token = $1
+LIMIT 1
+`
+
+// GetShareLinkByToken
+//
+//	SELECT id, token, account_id, scope, precision, created, expires, revoked
+//	FROM share_link
+//	WHERE token = $1
+//	LIMIT 1
+func (q *Queries) GetShareLinkByToken(ctx context.Context, token string) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLinkByToken, token)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.AccountID,
+		&i.Scope,
+		&i.Precision,
+		&i.Created,
+		&i.Expires,
+		&i.Revoked,
+	)
+	return i, err
+}
+
+const getSosEvents = `-- name: GetSosEvents :many
+SELECT id, sos_id, created, type, account_id, details
+FROM sos_event
+WHERE sos_id = $1
+ORDER BY id
+`
+
+// GetSosEvents
+//
+//	SELECT id, sos_id, created, type, account_id, details
+//	FROM sos_event
+//	WHERE sos_id = $1
+//	ORDER BY id
+func (q *Queries) GetSosEvents(ctx context.Context, sosID int64) ([]SosEvent, error) {
+	rows, err := q.db.Query(ctx, getSosEvents, sosID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SosEvent{}
+	for rows.Next() {
+		var i SosEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.SosID,
+			&i.Created,
+			&i.Type,
+			&i.AccountID,
+			&i.Details,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSosIncident = `-- name: GetSosIncident :one
+SELECT id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+FROM sos_incident
+WHERE id = $1
+LIMIT 1
+`
+
+// GetSosIncident
+//
+//	SELECT id, account_id, created, message, escalation_level, next_escalation, acknowledged_by, acknowledged
+//	FROM sos_incident
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetSosIncident(ctx context.Context, id int64) (SosIncident, error) {
+	row := q.db.QueryRow(ctx, getSosIncident, id)
+	var i SosIncident
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Created,
+		&i.Message,
+		&i.EscalationLevel,
+		&i.NextEscalation,
+		&i.AcknowledgedBy,
+		&i.Acknowledged,
+	)
+	return i, err
+}
+
+const getUpdatesByAccountIDBetween = `-- name: GetUpdatesByAccountIDBetween :many
+SELECT id, account_id, created, data
+FROM updates
+WHERE account_id = $1
+  AND created >= $2
+  AND created < $3
+ORDER BY id
+`
+
+type GetUpdatesByAccountIDBetweenParams struct {
+	AccountID int64     `db:"account_id"`
+	Since     time.Time `db:"since"`
+	Until     time.Time `db:"until"`
+}
+
+// GetUpdatesByAccountIDBetween
+//
+//	SELECT id, account_id, created, data
+//	FROM updates
+//	WHERE account_id = $1
+//	  AND created >= $2
+//	  AND created < $3
+//	ORDER BY id
+func (q *Queries) GetUpdatesByAccountIDBetween(ctx context.Context, arg GetUpdatesByAccountIDBetweenParams) ([]Update, error) {
+	rows, err := q.db.Query(ctx, getUpdatesByAccountIDBetween, arg.AccountID, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Update{}
+	for rows.Next() {
+		var i Update
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Created,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookDeliveriesBySubscriptionID = `-- name: GetWebhookDeliveriesBySubscriptionID :many
+SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+FROM webhook_delivery
+WHERE subscription_id = $1
+  AND ($2::VARCHAR IS NULL OR status = $2::VARCHAR)
+ORDER BY id DESC
+LIMIT $3
+`
+
+type GetWebhookDeliveriesBySubscriptionIDParams struct {
+	SubscriptionID int64   `db:"subscription_id"`
+	Status         *string `db:"status"`
+	MaxCount       int32   `db:"max_count"`
+}
+
+// GetWebhookDeliveriesBySubscriptionID
+//
+//	SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+//	FROM webhook_delivery
+//	WHERE subscription_id = $1
+//	  AND ($2::VARCHAR IS NULL OR status = $2::VARCHAR)
+//	ORDER BY id DESC
+//	LIMIT $3
+func (q *Queries) GetWebhookDeliveriesBySubscriptionID(ctx context.Context, arg GetWebhookDeliveriesBySubscriptionIDParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, getWebhookDeliveriesBySubscriptionID, arg.SubscriptionID, arg.Status, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.Event,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttempt,
+			&i.LastError,
+			&i.LastStatusCode,
+			&i.Created,
+			&i.Delivered,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+FROM webhook_delivery
+WHERE id = $1
+LIMIT 1
+`
+
+// GetWebhookDelivery
+//
+//	SELECT id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+//	FROM webhook_delivery
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, getWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttempt,
+		&i.LastError,
+		&i.LastStatusCode,
+		&i.Created,
+		&i.Delivered,
+	)
+	return i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT id, url, secret, events, enabled, created
+FROM webhook_subscription
+WHERE id = $1
+LIMIT 1
+`
+
+// GetWebhookSubscription
+//
+//	SELECT id, url, secret, events, enabled, created
+//	FROM webhook_subscription
+//	WHERE id = $1
+//	LIMIT 1
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Enabled,
+		&i.Created,
+	)
+	return i, err
+}
+
+const markAlertOutboxAttemptFailed = `-- name: MarkAlertOutboxAttemptFailed :exec
+UPDATE alert_outbox
+SET status       = $2,
+    attempts     = attempts + 1,
+    next_attempt = $3,
+    last_error   = $4
+WHERE id = $1
+`
+
+type MarkAlertOutboxAttemptFailedParams struct {
+	ID          int64     `db:"id"`
+	Status      string    `db:"status"`
+	NextAttempt time.Time `db:"next_attempt"`
+	LastError   *string   `db:"last_error"`
+}
+
+// MarkAlertOutboxAttemptFailed
+//
+//	UPDATE alert_outbox
+//	SET status       = $2,
+//	    attempts     = attempts + 1,
+//	    next_attempt = $3,
+//	    last_error   = $4
+//	WHERE id = $1
+func (q *Queries) MarkAlertOutboxAttemptFailed(ctx context.Context, arg MarkAlertOutboxAttemptFailedParams) error {
+	_, err := q.db.Exec(ctx, markAlertOutboxAttemptFailed,
+		arg.ID,
+		arg.Status,
+		arg.NextAttempt,
+		arg.LastError,
+	)
+	return err
+}
+
+const markAlertOutboxSent = `-- name: MarkAlertOutboxSent :exec
+UPDATE alert_outbox
+SET status     = 'sent',
+    attempts   = attempts + 1,
+    last_error = NULL,
+    sent       = $2
+WHERE id = $1
+`
+
+type MarkAlertOutboxSentParams struct {
+	ID   int64      `db:"id"`
+	Sent *time.Time `db:"sent"`
+}
+
+// MarkAlertOutboxSent
+//
+//	UPDATE alert_outbox
+//	SET status     = 'sent',
+//	    attempts   = attempts + 1,
+//	    last_error = NULL,
+//	    sent       = $2
+//	WHERE id = $1
+func (q *Queries) MarkAlertOutboxSent(ctx context.Context, arg MarkAlertOutboxSentParams) error {
+	_, err := q.db.Exec(ctx, markAlertOutboxSent, arg.ID, arg.Sent)
+	return err
+}
+
+const markWebhookDeliveryAttemptFailed = `-- name: MarkWebhookDeliveryAttemptFailed :exec
+UPDATE webhook_delivery
+SET status           = $2,
+    attempts         = attempts + 1,
+    next_attempt     = $3,
+    last_error       = $4,
+    last_status_code = $5
+WHERE id = $1
+`
+
+type MarkWebhookDeliveryAttemptFailedParams struct {
+	ID             int64     `db:"id"`
+	Status         string    `db:"status"`
+	NextAttempt    time.Time `db:"next_attempt"`
+	LastError      *string   `db:"last_error"`
+	LastStatusCode *int32    `db:"last_status_code"`
+}
+
+// MarkWebhookDeliveryAttemptFailed
+//
+//	UPDATE webhook_delivery
+//	SET status           = $2,
+//	    attempts         = attempts + 1,
+//	    next_attempt     = $3,
+//	    last_error       = $4,
+//	    last_status_code = $5
+//	WHERE id = $1
+func (q *Queries) MarkWebhookDeliveryAttemptFailed(ctx context.Context, arg MarkWebhookDeliveryAttemptFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryAttemptFailed,
+		arg.ID,
+		arg.Status,
+		arg.NextAttempt,
+		arg.LastError,
+		arg.LastStatusCode,
+	)
+	return err
+}
+
+const markWebhookDeliveryDelivered = `-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_delivery
+SET status           = 'delivered',
+    attempts         = attempts + 1,
+    last_error       = NULL,
+    last_status_code = $2,
+    delivered        = $3
+WHERE id = $1
+`
+
+type MarkWebhookDeliveryDeliveredParams struct {
+	ID             int64      `db:"id"`
+	LastStatusCode *int32     `db:"last_status_code"`
+	Delivered      *time.Time `db:"delivered"`
+}
+
+// MarkWebhookDeliveryDelivered
+//
+//	UPDATE webhook_delivery
+//	SET status           = 'delivered',
+//	    attempts         = attempts + 1,
+//	    last_error       = NULL,
+//	    last_status_code = $2,
+//	    delivered        = $3
+//	WHERE id = $1
+func (q *Queries) MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryDelivered, arg.ID, arg.LastStatusCode, arg.Delivered)
+	return err
+}
+
+const postponeAlertOutbox = `-- name: PostponeAlertOutbox :exec
+UPDATE alert_outbox
+SET status       = 'pending',
+    next_attempt = $2,
+    last_error   = $3
+WHERE id = $1
+`
+
+type PostponeAlertOutboxParams struct {
+	ID          int64     `db:"id"`
+	NextAttempt time.Time `db:"next_attempt"`
+	LastError   *string   `db:"last_error"`
+}
+
+// PostponeAlertOutbox
+//
+//	UPDATE alert_outbox
+//	SET status       = 'pending',
+//	    next_attempt = $2,
+//	    last_error   = $3
+//	WHERE id = $1
+func (q *Queries) PostponeAlertOutbox(ctx context.Context, arg PostponeAlertOutboxParams) error {
+	_, err := q.db.Exec(ctx, postponeAlertOutbox, arg.ID, arg.NextAttempt, arg.LastError)
+	return err
+}
+
+const releaseMigrationLock = `-- name: ReleaseMigrationLock :exec
+SELECT pg_advisory_unlock($1::bigint)
+`
+
+// ReleaseMigrationLock
+//
+//	SELECT pg_advisory_unlock($1::bigint)
+func (q *Queries) ReleaseMigrationLock(ctx context.Context, key int64) error {
+	_, err := q.db.Exec(ctx, releaseMigrationLock, key)
+	return err
+}
+
+const replayFailedWebhookDeliveries = `-- name: ReplayFailedWebhookDeliveries :execrows
+UPDATE webhook_delivery
+SET status       = 'pending',
+    attempts     = 0,
+    next_attempt = $2
+WHERE subscription_id = $1
+  AND status = 'failed'
+`
+
+type ReplayFailedWebhookDeliveriesParams struct {
+	SubscriptionID int64     `db:"subscription_id"`
+	NextAttempt    time.Time `db:"next_attempt"`
+}
+
+// ReplayFailedWebhookDeliveries
+//
+//	UPDATE webhook_delivery
+//	SET status       = 'pending',
+//	    attempts     = 0,
+//	    next_attempt = $2
+//	WHERE subscription_id = $1
+//	  AND status = 'failed'
+func (q *Queries) ReplayFailedWebhookDeliveries(ctx context.Context, arg ReplayFailedWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, replayFailedWebhookDeliveries, arg.SubscriptionID, arg.NextAttempt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const replayWebhookDelivery = `-- name: ReplayWebhookDelivery :one
+UPDATE webhook_delivery
+SET status       = 'pending',
+    attempts     = 0,
+    next_attempt = $2,
+    delivered    = NULL
+WHERE id = $1
+RETURNING id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+`
+
+type ReplayWebhookDeliveryParams struct {
+	ID          int64     `db:"id"`
+	NextAttempt time.Time `db:"next_attempt"`
+}
+
+// ReplayWebhookDelivery
+//
+//	UPDATE webhook_delivery
+//	SET status       = 'pending',
+//	    attempts     = 0,
+//	    next_attempt = $2,
+//	    delivered    = NULL
+//	WHERE id = $1
+//	RETURNING id, subscription_id, event, payload, status, attempts, next_attempt, last_error, last_status_code, created, delivered
+func (q *Queries) ReplayWebhookDelivery(ctx context.Context, arg ReplayWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, replayWebhookDelivery, arg.ID, arg.NextAttempt)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttempt,
+		&i.LastError,
+		&i.LastStatusCode,
+		&i.Created,
+		&i.Delivered,
+	)
+	return i, err
+}
+
+const revokeShareLink = `-- name: RevokeShareLink :execrows
+UPDATE share_link
+SET revoked = $3
+WHERE id = $1
+  AND account_id = $2
+  AND revoked IS NULL
+`
+
+type RevokeShareLinkParams struct {
+	ID        int64      `db:"id"`
+	AccountID int64      `db:"account_id"`
+	Revoked   *time.Time `db:"revoked"`
+}
+
+// RevokeShareLink
+//
+//	UPDATE share_link
+//	SET revoked = $3
+//	WHERE id = $1
+//	  AND account_id = $2
+//	  AND revoked IS NULL
+func (q *Queries) RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeShareLink, arg.ID, arg.AccountID, arg.Revoked)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAccountSettings = `-- name: UpdateAccountSettings :exec
+UPDATE account
+SET settings = $2
+WHERE id = $1
+`
+
+type UpdateAccountSettingsParams struct {
+	ID       int64               `db:"id"`
+	Settings api.AccountSettings `db:"settings"`
+}
+
+// UpdateAccountSettings
+//
+//	UPDATE account
+//	SET settings = $2
+//	WHERE id = $1
+func (q *Queries) UpdateAccountSettings(ctx context.Context, arg UpdateAccountSettingsParams) error {
+	_, err := q.db.Exec(ctx, updateAccountSettings, arg.ID, arg.Settings)
+	return err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :exec
+UPDATE account
+SET status = $2
+WHERE id = $1
+`
+
+type UpdateAccountStatusParams struct {
+	ID     int64             `db:"id"`
+	Status api.AccountStatus `db:"status"`
+}
+
+// UpdateAccountStatus
+//
+//	UPDATE account
+//	SET status = $2
+//	WHERE id = $1
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) error {
+	_, err := q.db.Exec(ctx, updateAccountStatus, arg.ID, arg.Status)
+	return err
+}
+
+const updateAccountToken = `-- name: UpdateAccountToken :exec
+UPDATE account
+SET token = $2
+WHERE id = $1
+`
+
+type UpdateAccountTokenParams struct {
+	ID    int64  `db:"id"`
+	Token string `db:"token"`
+}
+
+// UpdateAccountToken
+//
+//	UPDATE account
+//	SET token = $2
+//	WHERE id = $1
+func (q *Queries) UpdateAccountToken(ctx context.Context, arg UpdateAccountTokenParams) error {
+	_, err := q.db.Exec(ctx, updateAccountToken, arg.ID, arg.Token)
+	return err
+}
+
+const updateFence = `-- name: UpdateFence :one
+UPDATE fence
+SET name      = $2,
+    longitude = $3,
+    latitude  = $4,
+    radius    = $5
+WHERE id = $1
+RETURNING id, name, longitude, latitude, radius, settings
+`
+
+type UpdateFenceParams struct {
+	ID        int64   `db:"id"`
+	Name      string  `db:"name"`
+	Longitude float64 `db:"longitude"`
+	Latitude  float64 `db:"latitude"`
+	Radius    float64 `db:"radius"`
+}
+
+// UpdateFence
+//
+//	UPDATE fence
+//	SET name      = $2,
+//	    longitude = $3,
+//	    latitude  = $4,
+//	    radius    = $5
+//	WHERE id = $1
+//	RETURNING id, name, longitude, latitude, radius, settings
+func (q *Queries) UpdateFence(ctx context.Context, arg UpdateFenceParams) (Fence, error) {
+	row := q.db.QueryRow(ctx, updateFence,
+		arg.ID,
+		arg.Name,
+		arg.Longitude,
+		arg.Latitude,
+		arg.Radius,
+	)
+	var i Fence
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Longitude,
+		&i.Latitude,
+		&i.Radius,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const updateFenceSettings = `-- name: UpdateFenceSettings :one
+UPDATE fence
+SET settings = $2
+WHERE id = $1
+RETURNING id, name, longitude, latitude, radius, settings
+`
+
+type UpdateFenceSettingsParams struct {
+	ID       int64             `db:"id"`
+	Settings api.FenceSettings `db:"settings"`
+}
+
+// UpdateFenceSettings
+//
+//	UPDATE fence
+//	SET settings = $2
+//	WHERE id = $1
+//	RETURNING id, name, longitude, latitude, radius, settings
+func (q *Queries) UpdateFenceSettings(ctx context.Context, arg UpdateFenceSettingsParams) (Fence, error) {
+	row := q.db.QueryRow(ctx, updateFenceSettings, arg.ID, arg.Settings)
+	var i Fence
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Longitude,
+		&i.Latitude,
+		&i.Radius,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const updateProximityRule = `-- name: UpdateProximityRule :one
+UPDATE proximity_rule
+SET distance    = $2,
+    notify_meet = $3,
+    notify_part = $4
+WHERE id = $1
+RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+`
+
+type UpdateProximityRuleParams struct {
+	ID         int64   `db:"id"`
+	Distance   float64 `db:"distance"`
+	NotifyMeet bool    `db:"notify_meet"`
+	NotifyPart bool    `db:"notify_part"`
+}
+
+// UpdateProximityRule
+//
+//	UPDATE proximity_rule
+//	SET distance    = $2,
+//	    notify_meet = $3,
+//	    notify_part = $4
+//	WHERE id = $1
+//	RETURNING id, first_account_id, second_account_id, distance, notify_meet, notify_part, together, changed, created
+func (q *Queries) UpdateProximityRule(ctx context.Context, arg UpdateProximityRuleParams) (ProximityRule, error) {
+	row := q.db.QueryRow(ctx, updateProximityRule,
+		arg.ID,
+		arg.Distance,
+		arg.NotifyMeet,
+		arg.NotifyPart,
+	)
+	var i ProximityRule
+	err := row.Scan(
+		&i.ID,
+		&i.FirstAccountID,
+		&i.SecondAccountID,
+		&i.Distance,
+		&i.NotifyMeet,
+		&i.NotifyPart,
+		&i.Together,
+		&i.Changed,
+		&i.Created,
+	)
+	return i, err
+}
+
+const updateProximityRuleState = `-- name: UpdateProximityRuleState :exec
+UPDATE proximity_rule
+SET together = $2,
+    changed  = $3
+WHERE id = $1
+`
+
+type UpdateProximityRuleStateParams struct {
+	ID       int64      `db:"id"`
+	Together bool       `db:"together"`
+	Changed  *time.Time `db:"changed"`
+}
+
+// UpdateProximityRuleState
+//
+//	UPDATE proximity_rule
+//	SET together = $2,
+//	    changed  = $3
+//	WHERE id = $1
+func (q *Queries) UpdateProximityRuleState(ctx context.Context, arg UpdateProximityRuleStateParams) error {
+	_, err := q.db.Exec(ctx, updateProximityRuleState, arg.ID, arg.Together, arg.Changed)
+	return err
+}
+
+const updateSosIncidentEscalation = `-- name: UpdateSosIncidentEscalation :exec
+UPDATE sos_incident
+SET escalation_level = $2,
+    next_escalation  = $3
+WHERE id = $1
+`
+
+type UpdateSosIncidentEscalationParams struct {
+	ID              int64     `db:"id"`
+	EscalationLevel int32     `db:"escalation_level"`
+	NextEscalation  time.Time `db:"next_escalation"`
+}
+
+// UpdateSosIncidentEscalation
+//
+//	UPDATE sos_incident
+//	SET escalation_level = $2,
+//	    next_escalation  = $3
+//	WHERE id = $1
+func (q *Queries) UpdateSosIncidentEscalation(ctx context.Context, arg UpdateSosIncidentEscalationParams) error {
+	_, err := q.db.Exec(ctx, updateSosIncidentEscalation, arg.ID, arg.EscalationLevel, arg.NextEscalation)
+	return err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscription
+SET url     = $2,
+    secret  = $3,
+    events  = $4,
+    enabled = $5
+WHERE id = $1
+RETURNING id, url, secret, events, enabled, created
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	ID      int64    `db:"id"`
+	Url     string   `db:"url"`
+	Secret  string   `db:"secret"`
+	Events  []string `db:"events"`
+	Enabled bool     `db:"enabled"`
+}
+
+// UpdateWebhookSubscription
+//
+//	UPDATE webhook_subscription
+//	SET url     = $2,
+//	    secret  = $3,
+//	    events  = $4,
+//	    enabled = $5
+//	WHERE id = $1
+//	RETURNING id, url, secret, events, enabled, created
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.ID,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+		arg.Enabled,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Enabled,
+		&i.Created,
+	)
+	return i, err
+}
+
+const upgradeMigrationTable = `-- name: UpgradeMigrationTable :exec
+ALTER TABLE migration ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT ''
+`
+
+// UpgradeMigrationTable
+//
+//	ALTER TABLE migration ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT ''
+func (q *Queries) UpgradeMigrationTable(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, upgradeMigrationTable)
+	return err
+}
+
+const upsertBotState = `-- name: UpsertBotState :exec
+INSERT INTO bot_state (chat_id, stage, data, expires)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (chat_id) DO UPDATE
+    SET stage   = excluded.stage,
+        data    = excluded.data,
+        expires = excluded.expires
+`
+
+type UpsertBotStateParams struct {
+	ChatID  int64     `db:"chat_id"`
+	Stage   string    `db:"stage"`
+	Data    []byte    `db:"data"`
+	Expires time.Time `db:"expires"`
+}
+
+// UpsertBotState
+//
+//	INSERT INTO bot_state (chat_id, stage, data, expires)
+//	VALUES ($1, $2, $3, $4)
+//	ON CONFLICT (chat_id) DO UPDATE
+//	    SET stage   = excluded.stage,
+//	        data    = excluded.data,
+//	        expires = excluded.expires
+func (q *Queries) UpsertBotState(ctx context.Context, arg UpsertBotStateParams) error {
+	_, err := q.db.Exec(ctx, upsertBotState,
+		arg.ChatID,
+		arg.Stage,
+		arg.Data,
+		arg.Expires,
+	)
+	return err
+}
+
+const upsertFence = `-- name: UpsertFence :one
+INSERT INTO fence (name, longitude, latitude, radius, settings)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name) DO UPDATE
+    SET longitude = excluded.longitude,
+        latitude  = excluded.latitude,
+        radius    = excluded.radius,
+        settings  = excluded.settings
+RETURNING id, name, longitude, latitude, radius, settings
+`
+
+type UpsertFenceParams struct {
+	Name      string            `db:"name"`
+	Longitude float64           `db:"longitude"`
+	Latitude  float64           `db:"latitude"`
+	Radius    float64           `db:"radius"`
+	Settings  api.FenceSettings `db:"settings"`
+}
+
+// UpsertFence
+//
+//	INSERT INTO fence (name, longitude, latitude, radius, settings)
+//	VALUES ($1, $2, $3, $4, $5)
+//	ON CONFLICT (name) DO UPDATE
+//	    SET longitude = excluded.longitude,
+//	        latitude  = excluded.latitude,
+//	        radius    = excluded.radius,
+//	        settings  = excluded.settings
+//	RETURNING id, name, longitude, latitude, radius, settings
+func (q *Queries) UpsertFence(ctx context.Context, arg UpsertFenceParams) (Fence, error) {
+	row := q.db.QueryRow(ctx, upsertFence,
+		arg.Name,
+		arg.Longitude,
+		arg.Latitude,
+		arg.Radius,
+		arg.Settings,
+	)
+	var i Fence
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Longitude,
+		&i.Latitude,
+		&i.Radius,
+		&i.Settings,
+	)
+	return i, err
 }
@@ -0,0 +1,196 @@
+package i18n
+
+var enMessages = map[string]string{
+	"duration.days":        "%d d",
+	"duration.hours":       "%d h",
+	"duration.minutes":     "%d min",
+	"duration.less_minute": "less than a minute",
+
+	"distance.km":  "%.1f km",
+	"distance.m":   "%.0f m",
+	"distance.kmh": "%.0f km/h",
+
+	"ago.future":    "in the future",
+	"ago.now":       "just now",
+	"ago.seconds":   "less than a minute ago",
+	"ago.minute":    "a minute ago",
+	"ago.hour":      "an hour ago",
+	"ago.yesterday": "yesterday",
+	"ago.week":      "a week ago",
+	"ago.month":     "a month ago",
+	"ago.year":      "a year ago",
+	"ago.long":      "long ago",
+
+	"common.cancel":          "Cancel",
+	"common.ok":              "OK",
+	"common.forbidden":       "You are not allowed to use this command",
+	"common.unknown_command": "Unknown command",
+	"common.state_expired":   "The answer timed out, please start over",
+	"common.outdated":        "This action is outdated",
+	"common.on_map":          "[On the map](%s)",
+	"common.route_to_me":     "[Route to me](%s)",
+	"common.drive_route":     "[Drive route](%s)",
+	"common.no_location":     "⚠️ Location unknown",
+	"common.no_address":      "📍 Address unknown",
+	"common.accuracy":        "±%.0f m",
+	"common.item_left":       "%d. %s (%s left)",
+
+	"command.list":        "Show everyone",
+	"command.map":         "Map",
+	"command.follow":      "Follow live",
+	"command.unfollow":    "Stop following",
+	"command.history":     "History",
+	"command.watch":       "Notify when someone arrives or leaves",
+	"command.watches":     "Active watches",
+	"command.share":       "Share location with a link",
+	"command.shares":      "Active links",
+	"command.language":    "Language",
+	"command.maps":        "Map links",
+	"command.thresholds":  "Alert thresholds",
+	"command.addfence":    "Add a fence",
+	"command.editfence":   "Edit a fence",
+	"command.deletefence": "Delete a fence",
+	"command.cancel":      "Cancel the current action",
+
+	"language.name":   "English",
+	"language.pick":   "Choose a language",
+	"language.picked": "Language: English",
+
+	"maps.pick":   "Which maps should links open in?",
+	"maps.picked": "Links will open in: %s",
+	"maps.yandex": "Yandex Maps",
+	"maps.google": "Google Maps",
+	"maps.osm":    "OpenStreetMap",
+	"maps.2gis":   "2GIS",
+	"maps.geo":    "Default app",
+
+	"update.eta": "⏱ ~%s to me (%.0f km/h)",
+
+	"alert.battery_low":     "🪫 %s's phone battery is low: %d%%",
+	"alert.speeding":        "🏎 %s is speeding: %.0f km/h (limit %.0f km/h)",
+	"alert.drive_start":     "🚗 %s started driving",
+	"alert.drive_finish":    "🏁 %s finished driving: %s, top speed %.0f km/h",
+	"alert.fence_leave":     "🔴 %s left %s",
+	"alert.fence_enter":     "🟢 %s entered %s",
+	"alert.online":          "✅ %s is back online",
+	"alert.online_after":    "✅ %s is back online (was offline for %s)",
+	"alert.offline":         "🚨 %s stopped sending updates",
+	"alert.offline_battery": "🪫 The battery was low in the last update: %d%%",
+	"alert.offline_last":    "Last location (%s): [On the map](%s)",
+	"alert.proximity_meet":  "🤝 %s and %s met",
+	"alert.proximity_part":  "👋 %s and %s parted (%s apart)",
+	"alert.watch_arrived":   "🔔 %s arrived at %s",
+	"alert.watch_left":      "🔔 %s left %s",
+	"alert.watch_near":      "🔔 %s is %s away from you",
+	"alert.watch_far":       "🔔 %s moved %s away from you",
+	"alert.watch_inside":    "🔔 %s is already at %s",
+	"alert.watch_outside":   "🔔 %s is already away from %s",
+
+	"sos.title":       "🆘 *SOS* from %s!",
+	"sos.repeat":      "Repeat #%d, nobody has responded yet (%s)",
+	"sos.location":    "Location (%s): [On the map](%s) ±%.0f m",
+	"sos.ack_button":  "🙋 I'm on it",
+	"sos.already_ack": "The SOS is already acknowledged",
+	"sos.thanks":      "Thank you!",
+	"sos.acked":       "✅ %s is handling the SOS from %s",
+
+	"history.pick_account": "Choose a person",
+	"history.pick_range":   "Choose a period",
+	"history.last_hour":    "Last hour",
+	"history.today":        "Today",
+	"history.yesterday":    "Yesterday",
+	"history.three_days":   "3 days",
+	"history.week":         "Week",
+	"history.empty":        "*%s*: no data for %s",
+	"history.caption":      "*%s*: %s, points: %d",
+	"history.page":         "*%s*: %s (page %d/%d)",
+	"history.stop":         "🕒 %s–%s, stayed %s",
+
+	"fence.pick_delete":      "Choose a fence to delete",
+	"fence.deleted":          "Fence deleted",
+	"fence.enter_name":       "Enter the name of the new fence:",
+	"fence.center_prompt":    "Send the fence center as a map pin (📎 → Location) or enter the latitude and longitude separated by a comma:",
+	"fence.pick_edit":        "Choose a fence to edit",
+	"fence.describe":         "%s: %.5f, %.5f, radius %s",
+	"fence.move":             "📍 Move",
+	"fence.resize":           "📏 Radius",
+	"fence.rename":           "✏️ Name",
+	"fence.enter_new_name":   "Enter the new fence name:",
+	"fence.bad_coordinates":  "Could not parse the coordinates, please try again",
+	"fence.bad_radius":       "Invalid number, please try again",
+	"fence.create_failed":    "Failed to create the fence",
+	"fence.created":          "Fence created: %s",
+	"fence.update_failed":    "Failed to update the fence",
+	"fence.updated":          "Fence updated: %s",
+	"fence.radius":           "Radius %s",
+	"fence.pick_radius":      "Choose a radius or enter it in meters:",
+	"fence.accuracy":         "🎯 Accuracy margin",
+	"fence.accuracy_prompt":  "Enter how many location accuracies to add to the radius (0 to 10), or \"-\" to use the member's setting:",
+	"fence.accuracy_updated": "Accuracy margin of %s: ×%g",
+	"fence.accuracy_reset":   "%s uses the member's accuracy margin",
+
+	"thresholds.pick":         "Alert thresholds of %s (tap to change):",
+	"thresholds.pick_account": "Whose alert thresholds to change?",
+	"thresholds.offline":      "📴 Offline after %s",
+	"thresholds.battery":      "🪫 Low battery: %d%%",
+	"thresholds.speed":        "🏎 Speed limit: %s",
+	"thresholds.off":          "off",
+	"thresholds.accuracy":     "🎯 Accuracy margin: ×%g",
+	"thresholds.enter":        "Enter a value from %g to %g or \"-\" to restore the default:",
+	"thresholds.bad_value":    "Invalid value, please try again",
+
+	"map.empty": "Nobody has a location",
+
+	"venue.title": "%s (%s, ±%.0f m)",
+
+	"inline.title": "%s: %s from you, %s",
+
+	"follow.pick_account":     "Whom to follow live?",
+	"follow.none":             "No active live locations. Start one: /follow",
+	"follow.stop_button":      "⏹ Stop %d",
+	"follow.pick_period":      "For how long?",
+	"follow.unknown_location": "%s's location is unknown",
+	"follow.started":          "📡 Following %s for %s. Stop: /unfollow",
+	"follow.already_stopped":  "The live location is already stopped",
+	"follow.stopped":          "Live location stopped",
+
+	"share.pick_ttl":       "For how long to share the location?",
+	"share.none":           "No active links. Create one: /share",
+	"share.item":           "%d. %s, %s (%s left, %s)",
+	"share.revoke_button":  "❌ Revoke %d",
+	"share.pick_precision": "How precise?",
+	"share.pick_scope":     "What to show?",
+	"share.created":        "🔗 Link for %s (%s, %s):\n%s\n\nRevoke: /shares",
+	"share.revoked":        "Link revoked",
+	"share.scope_today":    "today's track",
+	"share.scope_live":     "current position only",
+	"share.exact":          "exact",
+	"share.approx":         "~%s",
+
+	"watch.pick_account":   "Whom to watch?",
+	"watch.none":           "No active watches. Add one: /watch",
+	"watch.cancel_button":  "❌ Cancel %d",
+	"watch.arrive":         "Arrives",
+	"watch.leave":          "Leaves",
+	"watch.pick_direction": "When to notify?",
+	"watch.from_me":        "%s from me",
+	"watch.pick_place":     "Where?",
+	"watch.created":        "🔔 I will notify you once: %s. Valid for %s",
+	"watch.already":        "✅ No need to wait, it's already true: %s",
+	"watch.deleted":        "Watch cancelled",
+	"watch.arrive_fence":   "%s arrives at %s",
+	"watch.leave_fence":    "%s leaves %s",
+	"watch.arrive_near":    "%s gets closer than %s to me",
+	"watch.leave_far":      "%s gets farther than %s from me",
+}
+
+var enPlurals = map[string][]string{
+	"ago.minutes": {"%d minute ago", "%d minutes ago"},
+	"ago.hours":   {"%d hour ago", "%d hours ago"},
+	"ago.days":    {"%d day ago", "%d days ago"},
+	"ago.weeks":   {"%d week ago", "%d weeks ago"},
+	"ago.months":  {"%d month ago", "%d months ago"},
+	"ago.years":   {"%d year ago", "%d years ago"},
+
+	"share.opened": {"opened %d time", "opened %d times"},
+}
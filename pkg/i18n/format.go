@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"roflbeacon2/pkg/maplink"
+	"strings"
+	"time"
+)
+
+// Duration renders as e.g. "1 h 20 min" in the recipient's language.
+type Duration time.Duration
+
+func (d Duration) Localize(loc Locale) string {
+	return FormatDuration(loc.Lang, time.Duration(d))
+}
+
+// Distance is in meters.
+type Distance float64
+
+func (d Distance) Localize(loc Locale) string {
+	return FormatDistance(loc.Lang, float64(d))
+}
+
+// Ago renders the time relative to the moment it is localized.
+type Ago time.Time
+
+func (a Ago) Localize(loc Locale) string {
+	return TimeAgo(loc.Lang, time.Time(a))
+}
+
+// MapPoint is a link to the point on the recipient's map.
+type MapPoint struct {
+	Lat, Lon float64
+}
+
+func (p MapPoint) Localize(loc Locale) string {
+	return loc.MapLinks().Point(p.Lat, p.Lon)
+}
+
+// MapRoute is a link to the route on the recipient's map.
+type MapRoute struct {
+	FromLat, FromLon float64
+	ToLat, ToLon     float64
+	Mode             maplink.Mode
+}
+
+func (r MapRoute) Localize(loc Locale) string {
+	return loc.MapLinks().Route(r.FromLat, r.FromLon, r.ToLat, r.ToLon, r.Mode)
+}
+
+func FormatDuration(lang Lang, d time.Duration) string {
+	totalMinutes := int(d.Round(time.Minute).Minutes())
+
+	days := totalMinutes / (24 * 60)
+	hours := totalMinutes / 60 % 24
+	minutes := totalMinutes % 60
+
+	var parts []string
+
+	if days > 0 {
+		parts = append(parts, T(lang, "duration.days", days))
+	}
+	if hours > 0 {
+		parts = append(parts, T(lang, "duration.hours", hours))
+	}
+	if minutes > 0 && days == 0 {
+		parts = append(parts, T(lang, "duration.minutes", minutes))
+	}
+
+	if len(parts) == 0 {
+		return T(lang, "duration.less_minute")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func FormatDistance(lang Lang, meters float64) string {
+	if meters >= 1000 {
+		return T(lang, "distance.km", meters/1000)
+	}
+
+	return T(lang, "distance.m", meters)
+}
+
+func TimeAgo(lang Lang, t time.Time) string {
+	duration := time.Since(t)
+
+	seconds := int(duration.Seconds())
+	minutes := int(duration.Minutes())
+	hours := int(duration.Hours())
+	days := hours / 24
+	weeks := days / 7
+	months := days / 30
+	years := days / 365
+
+	switch {
+	case seconds < 0:
+		return T(lang, "ago.future")
+	case seconds < 10:
+		return T(lang, "ago.now")
+	case seconds < 60:
+		return T(lang, "ago.seconds")
+	case minutes == 1:
+		return T(lang, "ago.minute")
+	case minutes < 60:
+		return N(lang, "ago.minutes", minutes)
+	case hours == 1:
+		return T(lang, "ago.hour")
+	case hours < 24:
+		return N(lang, "ago.hours", hours)
+	case days == 1:
+		return T(lang, "ago.yesterday")
+	case days < 7:
+		return N(lang, "ago.days", days)
+	case weeks == 1:
+		return T(lang, "ago.week")
+	case weeks < 4:
+		return N(lang, "ago.weeks", weeks)
+	case months == 1:
+		return T(lang, "ago.month")
+	case months < 12:
+		return N(lang, "ago.months", months)
+	case years == 1:
+		return T(lang, "ago.year")
+	case years < 5:
+		return N(lang, "ago.years", years)
+	default:
+		return T(lang, "ago.long")
+	}
+}
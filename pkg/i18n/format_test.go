@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		n    int
+		ru   int
+		en   int
+		name string
+	}{
+		{name: "zero", n: 0, ru: 2, en: 1},
+		{name: "one", n: 1, ru: 0, en: 0},
+		{name: "few", n: 3, ru: 1, en: 1},
+		{name: "many", n: 5, ru: 2, en: 1},
+		{name: "eleven", n: 11, ru: 2, en: 1},
+		{name: "twelve", n: 12, ru: 2, en: 1},
+		{name: "twenty one", n: 21, ru: 0, en: 1},
+		{name: "twenty two", n: 22, ru: 1, en: 1},
+		{name: "hundred eleven", n: 111, ru: 2, en: 1},
+		{name: "hundred twenty four", n: 124, ru: 1, en: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ruPluralRule(tt.n); got != tt.ru {
+				t.Errorf("ruPluralRule(%d) = %d, want %d", tt.n, got, tt.ru)
+			}
+
+			if got := enPluralRule(tt.n); got != tt.en {
+				t.Errorf("enPluralRule(%d) = %d, want %d", tt.n, got, tt.en)
+			}
+		})
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		key  string
+		n    int
+		want string
+	}{
+		{lang: RU, key: "ago.minutes", n: 1, want: "1 минуту назад"},
+		{lang: RU, key: "ago.minutes", n: 2, want: "2 минуты назад"},
+		{lang: RU, key: "ago.minutes", n: 5, want: "5 минут назад"},
+		{lang: RU, key: "ago.days", n: 21, want: "21 день назад"},
+		{lang: EN, key: "ago.days", n: 1, want: "1 day ago"},
+		{lang: EN, key: "ago.days", n: 3, want: "3 days ago"},
+		{lang: EN, key: "unknown.key", n: 3, want: "unknown.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := N(tt.lang, tt.key, tt.n); got != tt.want {
+				t.Errorf("N(%s, %q, %d) = %q, want %q", tt.lang, tt.key, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		d    time.Duration
+		want string
+	}{
+		{lang: EN, d: 20 * time.Second, want: "less than a minute"},
+		{lang: EN, d: 45 * time.Minute, want: "45 min"},
+		{lang: EN, d: 80 * time.Minute, want: "1 h 20 min"},
+		{lang: EN, d: 2 * time.Hour, want: "2 h"},
+		// minutes are dropped once the duration is over a day
+		{lang: EN, d: 26*time.Hour + 5*time.Minute, want: "1 d 2 h"},
+		{lang: RU, d: 90 * time.Minute, want: "1 ч 30 мин"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatDuration(tt.lang, tt.d); got != tt.want {
+				t.Errorf("FormatDuration(%s, %s) = %q, want %q", tt.lang, tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDistance(t *testing.T) {
+	tests := []struct {
+		lang   Lang
+		meters float64
+		want   string
+	}{
+		{lang: EN, meters: 42.4, want: "42 m"},
+		{lang: EN, meters: 999, want: "999 m"},
+		{lang: EN, meters: 1000, want: "1.0 km"},
+		{lang: RU, meters: 12345, want: "12.3 км"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatDistance(tt.lang, tt.meters); got != tt.want {
+				t.Errorf("FormatDistance(%s, %v) = %q, want %q", tt.lang, tt.meters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeAgo(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		ago  time.Duration
+		want string
+	}{
+		{lang: EN, ago: -time.Hour, want: "in the future"},
+		{lang: EN, ago: 5 * time.Second, want: "just now"},
+		{lang: EN, ago: 30 * time.Second, want: "less than a minute ago"},
+		{lang: EN, ago: 90 * time.Second, want: "a minute ago"},
+		{lang: EN, ago: 5 * time.Minute, want: "5 minutes ago"},
+		{lang: EN, ago: 3 * time.Hour, want: "3 hours ago"},
+		{lang: EN, ago: 30 * time.Hour, want: "yesterday"},
+		{lang: RU, ago: 3 * 24 * time.Hour, want: "3 дня назад"},
+		{lang: RU, ago: 14 * 24 * time.Hour, want: "2 недели назад"},
+		{lang: RU, ago: 10 * 365 * 24 * time.Hour, want: "давно"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := TimeAgo(tt.lang, time.Now().Add(-tt.ago)); got != tt.want {
+				t.Errorf("TimeAgo(%s, -%s) = %q, want %q", tt.lang, tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogsMatch(t *testing.T) {
+	for key := range catalogs[EN].messages {
+		if _, ok := catalogs[RU].messages[key]; !ok {
+			t.Errorf("message %q is missing in ru", key)
+		}
+	}
+
+	for key := range catalogs[RU].messages {
+		if _, ok := catalogs[EN].messages[key]; !ok {
+			t.Errorf("message %q is missing in en", key)
+		}
+	}
+
+	for lang, c := range catalogs {
+		want := 0
+		for n := range 200 {
+			want = max(want, c.rule(n)+1)
+		}
+
+		for key, forms := range c.plurals {
+			if len(forms) < want {
+				t.Errorf("plural %q in %s has %d forms, want at least %d", key, lang, len(forms), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package i18n
+
+import (
+	"fmt"
+	"roflbeacon2/pkg/maplink"
+	"strings"
+)
+
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	Default = RU
+)
+
+var Langs = []Lang{RU, EN}
+
+type catalog struct {
+	messages map[string]string
+	// plurals hold the forms in the order of the language's plural rule
+	plurals map[string][]string
+	rule    func(n int) int
+}
+
+var catalogs = map[Lang]catalog{
+	RU: {messages: ruMessages, plurals: ruPlurals, rule: ruPluralRule},
+	EN: {messages: enMessages, plurals: enPlurals, rule: enPluralRule},
+}
+
+// Parse returns the supported language for the code, Default otherwise.
+func Parse(code string) Lang {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+
+	if _, ok := catalogs[Lang(code)]; ok {
+		return Lang(code)
+	}
+
+	return Default
+}
+
+// Locale is the recipient's preferences a text is rendered with.
+type Locale struct {
+	Lang Lang
+	// Maps builds the map links, maplink.Default if nil
+	Maps maplink.Provider
+}
+
+func (l Locale) MapLinks() maplink.Provider {
+	if l.Maps == nil {
+		return maplink.Default
+	}
+
+	return l.Maps
+}
+
+// Text is anything that can be rendered for the recipient.
+type Text interface {
+	Localize(loc Locale) string
+}
+
+// T formats the message of the key in the language with the default map links.
+func T(lang Lang, key string, args ...any) string {
+	return Locale{Lang: lang}.T(key, args...)
+}
+
+// T formats the message of the key, Text arguments are localized first.
+// Missing messages fall back to the default language and then to the key itself.
+func (l Locale) T(key string, args ...any) string {
+	format, ok := message(l.Lang, key)
+	if !ok {
+		format, ok = message(Default, key)
+	}
+	if !ok {
+		return key
+	}
+
+	return sprintf(l, format, args)
+}
+
+func message(lang Lang, key string) (string, bool) {
+	if overridden := overrides.Load(); overridden != nil {
+		if format, ok := (*overridden)[lang][key]; ok {
+			return format, true
+		}
+	}
+
+	format, ok := catalogs[lang].messages[key]
+
+	return format, ok
+}
+
+// N formats the plural form of the key that matches n, the forms take n as the only argument.
+func N(lang Lang, key string, n int) string {
+	c := catalogs[lang]
+
+	forms, ok := c.plurals[key]
+	if !ok {
+		c = catalogs[Default]
+		forms, ok = c.plurals[key]
+	}
+	if !ok {
+		return key
+	}
+
+	return fmt.Sprintf(forms[min(c.rule(n), len(forms)-1)], n)
+}
+
+func sprintf(loc Locale, format string, args []any) string {
+	if len(args) == 0 {
+		return format
+	}
+
+	localized := make([]any, len(args))
+
+	for i, arg := range args {
+		if text, ok := arg.(Text); ok {
+			localized[i] = text.Localize(loc)
+		} else {
+			localized[i] = arg
+		}
+	}
+
+	return fmt.Sprintf(format, localized...)
+}
+
+type Message struct {
+	Key  string
+	Args []any
+}
+
+func M(key string, args ...any) Message {
+	return Message{Key: key, Args: args}
+}
+
+func (m Message) Localize(loc Locale) string {
+	return loc.T(m.Key, m.Args...)
+}
+
+type plural struct {
+	key string
+	n   int
+}
+
+// P is the plural message of the key for n.
+func P(key string, n int) Text {
+	return plural{key: key, n: n}
+}
+
+func (p plural) Localize(loc Locale) string {
+	return N(loc.Lang, p.key, p.n)
+}
+
+// Raw is a text that is the same in every language, like an address or a link.
+type Raw string
+
+func (r Raw) Localize(Locale) string {
+	return string(r)
+}
+
+// Escaped is user input, like an account name or a message, shown literally in Markdown messages.
+type Escaped string
+
+func (e Escaped) Localize(Locale) string {
+	return EscapeMarkdown(string(e))
+}
+
+var markdownEscaper = strings.NewReplacer("_", `\_`, "*", `\*`, "`", "\\`", "[", `\[`)
+
+// EscapeMarkdown escapes the characters that start an entity in Telegram's legacy Markdown.
+// The escapes only work outside of entities, so the result must not be put inside e.g. *bold*.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+type lines []Text
+
+// Lines joins the texts with line breaks, nil texts are skipped.
+func Lines(texts ...Text) Text {
+	return lines(texts)
+}
+
+func (l lines) Localize(loc Locale) string {
+	var parts []string
+
+	for _, text := range l {
+		if text != nil {
+			parts = append(parts, text.Localize(loc))
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func ruPluralRule(n int) int {
+	n10, n100 := n%10, n%100
+
+	switch {
+	case n10 == 1 && n100 != 11:
+		return 0
+	case n10 >= 2 && n10 <= 4 && (n100 < 12 || n100 > 14):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func enPluralRule(n int) int {
+	if n == 1 {
+		return 0
+	}
+
+	return 1
+}
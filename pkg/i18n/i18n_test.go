@@ -0,0 +1,35 @@
+package i18n
+
+import "testing"
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "Alice", want: "Alice"},
+		{name: "underscore", in: "help_me", want: `help\_me`},
+		{name: "bold", in: "*now*", want: `\*now\*`},
+		{name: "code", in: "a`b", want: "a\\`b"},
+		{name: "link", in: "[x](y)", want: `\[x](y)`},
+		{name: "mixed", in: "help_me *now", want: `help\_me \*now`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMarkdown(tt.in); got != tt.want {
+				t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapedAsArgument(t *testing.T) {
+	got := T(EN, "sos.title", Escaped("bad_name*"))
+	want := `🆘 *SOS* from bad\_name\*!`
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sync/atomic"
+)
+
+var overrides atomic.Pointer[map[Lang]map[string]string]
+
+var verbRegexp = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]*)?[a-zA-Z%]`)
+
+// ValidateOverrides checks that every overridden message exists and takes the same arguments as the original.
+func ValidateOverrides(texts map[string]map[string]string) error {
+	for code, messages := range texts {
+		c, ok := catalogs[Lang(code)]
+		if !ok {
+			return fmt.Errorf("unknown language: %s", code)
+		}
+
+		for key, format := range messages {
+			original, ok := c.messages[key]
+			if !ok {
+				return fmt.Errorf("unknown message: %s.%s", code, key)
+			}
+
+			if !slices.Equal(verbs(original), verbs(format)) {
+				return fmt.Errorf("message %s.%s must keep the arguments of %q", code, key, original)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetOverrides replaces the catalog messages with the validated texts, nil restores the catalogs.
+func SetOverrides(texts map[string]map[string]string) {
+	result := make(map[Lang]map[string]string, len(texts))
+
+	for code, messages := range texts {
+		result[Lang(code)] = messages
+	}
+
+	overrides.Store(&result)
+}
+
+func verbs(format string) []string {
+	var result []string
+
+	for _, verb := range verbRegexp.FindAllString(format, -1) {
+		if verb != "%%" {
+			result = append(result, verb[len(verb)-1:])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,196 @@
+package i18n
+
+var ruMessages = map[string]string{
+	"duration.days":        "%d д",
+	"duration.hours":       "%d ч",
+	"duration.minutes":     "%d мин",
+	"duration.less_minute": "меньше минуты",
+
+	"distance.km":  "%.1f км",
+	"distance.m":   "%.0f м",
+	"distance.kmh": "%.0f км/ч",
+
+	"ago.future":    "в будущем",
+	"ago.now":       "только что",
+	"ago.seconds":   "менее минуты назад",
+	"ago.minute":    "минуту назад",
+	"ago.hour":      "час назад",
+	"ago.yesterday": "вчера",
+	"ago.week":      "неделю назад",
+	"ago.month":     "месяц назад",
+	"ago.year":      "год назад",
+	"ago.long":      "давно",
+
+	"common.cancel":          "Отмена",
+	"common.ok":              "ОК",
+	"common.forbidden":       "Вы не можете использовать данную команду",
+	"common.unknown_command": "Неизвестная команда",
+	"common.state_expired":   "Время ожидания ответа истекло, начните заново",
+	"common.outdated":        "Действие устарело",
+	"common.on_map":          "[На карте](%s)",
+	"common.route_to_me":     "[Маршрут до меня](%s)",
+	"common.drive_route":     "[Маршрут поездки](%s)",
+	"common.no_location":     "⚠️ Местоположение не определено",
+	"common.no_address":      "📍 Адрес не определен",
+	"common.accuracy":        "±%.0f м",
+	"common.item_left":       "%d. %s (еще %s)",
+
+	"command.list":        "Показать всех",
+	"command.map":         "Карта",
+	"command.follow":      "Следить в реальном времени",
+	"command.unfollow":    "Остановить слежение",
+	"command.history":     "История",
+	"command.watch":       "Уведомить, когда кто-то придет или уйдет",
+	"command.watches":     "Активные уведомления",
+	"command.share":       "Поделиться местоположением по ссылке",
+	"command.shares":      "Активные ссылки",
+	"command.language":    "Язык",
+	"command.maps":        "Карты для ссылок",
+	"command.thresholds":  "Пороги уведомлений",
+	"command.addfence":    "Добавить ограду",
+	"command.editfence":   "Изменить ограду",
+	"command.deletefence": "Удалить ограду",
+	"command.cancel":      "Отменить текущее действие",
+
+	"language.name":   "Русский",
+	"language.pick":   "Выберите язык",
+	"language.picked": "Язык: русский",
+
+	"maps.pick":   "В каких картах открывать ссылки?",
+	"maps.picked": "Ссылки будут открываться в: %s",
+	"maps.yandex": "Яндекс Карты",
+	"maps.google": "Google Maps",
+	"maps.osm":    "OpenStreetMap",
+	"maps.2gis":   "2ГИС",
+	"maps.geo":    "Приложение по умолчанию",
+
+	"update.eta": "⏱ ~%s до меня (%.0f км/ч)",
+
+	"alert.battery_low":     "🪫 У %s садится телефон: %d%%",
+	"alert.speeding":        "🏎 %s превысил скорость: %.0f км/ч (лимит %.0f км/ч)",
+	"alert.drive_start":     "🚗 %s начал поездку",
+	"alert.drive_finish":    "🏁 %s завершил поездку: %s, макс. скорость %.0f км/ч",
+	"alert.fence_leave":     "🔴 %s покинул %s",
+	"alert.fence_enter":     "🟢 %s вошел в %s",
+	"alert.online":          "✅ %s снова на связи",
+	"alert.online_after":    "✅ %s снова на связи (не было на связи %s)",
+	"alert.offline":         "🚨 %s перестал присылать обновления",
+	"alert.offline_battery": "🪫 В последнем обновлении батарея была разряжена: %d%%",
+	"alert.offline_last":    "Последнее местоположение (%s): [На карте](%s)",
+	"alert.proximity_meet":  "🤝 %s и %s встретились",
+	"alert.proximity_part":  "👋 %s и %s разошлись (%s друг от друга)",
+	"alert.watch_arrived":   "🔔 %s прибыл в %s",
+	"alert.watch_left":      "🔔 %s покинул %s",
+	"alert.watch_near":      "🔔 %s уже в %s от вас",
+	"alert.watch_far":       "🔔 %s отошел от вас на %s",
+	"alert.watch_inside":    "🔔 %s уже находится в %s",
+	"alert.watch_outside":   "🔔 %s уже покинул %s",
+
+	"sos.title":       "🆘 *SOS* от %s!",
+	"sos.repeat":      "Повтор #%d, никто еще не откликнулся (%s)",
+	"sos.location":    "Местоположение (%s): [На карте](%s) ±%.0f м",
+	"sos.ack_button":  "🙋 Я займусь",
+	"sos.already_ack": "SOS уже подтвержден",
+	"sos.thanks":      "Спасибо!",
+	"sos.acked":       "✅ %s занимается SOS от %s",
+
+	"history.pick_account": "Выберите пользователя",
+	"history.pick_range":   "Выберите период",
+	"history.last_hour":    "Последний час",
+	"history.today":        "Сегодня",
+	"history.yesterday":    "Вчера",
+	"history.three_days":   "3 дня",
+	"history.week":         "Неделя",
+	"history.empty":        "*%s*: нет данных за %s",
+	"history.caption":      "*%s*: %s, точек: %d",
+	"history.page":         "*%s*: %s (стр. %d/%d)",
+	"history.stop":         "🕒 %s–%s, на месте %s",
+
+	"fence.pick_delete":      "Выберите ограду для удаления",
+	"fence.deleted":          "Ограда удалена",
+	"fence.enter_name":       "Введите имя новой ограды:",
+	"fence.center_prompt":    "Отправьте центр ограды точкой на карте (📎 → Геопозиция) или введите широту и долготу через запятую:",
+	"fence.pick_edit":        "Выберите ограду для изменения",
+	"fence.describe":         "%s: %.5f, %.5f, радиус %s",
+	"fence.move":             "📍 Переместить",
+	"fence.resize":           "📏 Радиус",
+	"fence.rename":           "✏️ Имя",
+	"fence.enter_new_name":   "Введите новое имя ограды:",
+	"fence.bad_coordinates":  "Не удалось разобрать координаты, попробуйте еще раз",
+	"fence.bad_radius":       "Неверное число, попробуйте еще раз",
+	"fence.create_failed":    "Не удалось создать ограду",
+	"fence.created":          "Ограда успешно создана: %s",
+	"fence.update_failed":    "Не удалось изменить ограду",
+	"fence.updated":          "Ограда изменена: %s",
+	"fence.radius":           "Радиус %s",
+	"fence.pick_radius":      "Выберите радиус или введите его в метрах:",
+	"fence.accuracy":         "🎯 Запас точности",
+	"fence.accuracy_prompt":  "Введите, сколько погрешностей геопозиции добавлять к радиусу (от 0 до 10), или «-», чтобы использовать настройку пользователя:",
+	"fence.accuracy_updated": "Запас точности ограды %s: ×%g",
+	"fence.accuracy_reset":   "Ограда %s использует запас точности пользователя",
+
+	"thresholds.pick":         "Пороги уведомлений для %s (нажмите, чтобы изменить):",
+	"thresholds.pick_account": "Чьи пороги уведомлений изменить?",
+	"thresholds.offline":      "📴 Нет на связи через %s",
+	"thresholds.battery":      "🪫 Низкий заряд: %d%%",
+	"thresholds.speed":        "🏎 Лимит скорости: %s",
+	"thresholds.off":          "выключен",
+	"thresholds.accuracy":     "🎯 Запас точности: ×%g",
+	"thresholds.enter":        "Введите значение от %g до %g или «-», чтобы вернуть значение по умолчанию:",
+	"thresholds.bad_value":    "Неверное значение, попробуйте еще раз",
+
+	"map.empty": "Ни у кого нет местоположения",
+
+	"venue.title": "%s (%s, ±%.0f м)",
+
+	"inline.title": "%s: %s от вас, %s",
+
+	"follow.pick_account":     "За кем следить в реальном времени?",
+	"follow.none":             "Нет активных трансляций. Начать: /follow",
+	"follow.stop_button":      "⏹ Остановить %d",
+	"follow.pick_period":      "Как долго?",
+	"follow.unknown_location": "Местоположение %s неизвестно",
+	"follow.started":          "📡 Слежу за %s %s. Остановить: /unfollow",
+	"follow.already_stopped":  "Трансляция уже остановлена",
+	"follow.stopped":          "Трансляция остановлена",
+
+	"share.pick_ttl":       "На сколько поделиться местоположением?",
+	"share.none":           "Нет активных ссылок. Создать: /share",
+	"share.item":           "%d. %s, %s (еще %s, %s)",
+	"share.revoke_button":  "❌ Отозвать %d",
+	"share.pick_precision": "С какой точностью?",
+	"share.pick_scope":     "Что показывать?",
+	"share.created":        "🔗 Ссылка на %s (%s, %s):\n%s\n\nОтозвать: /shares",
+	"share.revoked":        "Ссылка отозвана",
+	"share.scope_today":    "трек за сегодня",
+	"share.scope_live":     "только текущее",
+	"share.exact":          "точно",
+	"share.approx":         "~%s",
+
+	"watch.pick_account":   "За кем следить?",
+	"watch.none":           "Нет активных уведомлений. Добавить: /watch",
+	"watch.cancel_button":  "❌ Отменить %d",
+	"watch.arrive":         "Прибудет",
+	"watch.leave":          "Уйдет",
+	"watch.pick_direction": "Когда уведомить?",
+	"watch.from_me":        "%s от меня",
+	"watch.pick_place":     "Где?",
+	"watch.created":        "🔔 Уведомлю один раз: %s. Действует %s",
+	"watch.already":        "✅ Ждать не нужно, это уже так: %s",
+	"watch.deleted":        "Уведомление отменено",
+	"watch.arrive_fence":   "%s прибудет в %s",
+	"watch.leave_fence":    "%s покинет %s",
+	"watch.arrive_near":    "%s будет ближе %s от меня",
+	"watch.leave_far":      "%s отойдет дальше %s от меня",
+}
+
+var ruPlurals = map[string][]string{
+	"ago.minutes": {"%d минуту назад", "%d минуты назад", "%d минут назад"},
+	"ago.hours":   {"%d час назад", "%d часа назад", "%d часов назад"},
+	"ago.days":    {"%d день назад", "%d дня назад", "%d дней назад"},
+	"ago.weeks":   {"%d неделю назад", "%d недели назад", "%d недель назад"},
+	"ago.months":  {"%d месяц назад", "%d месяца назад", "%d месяцев назад"},
+	"ago.years":   {"%d год назад", "%d года назад", "%d лет назад"},
+
+	"share.opened": {"открыта %d раз", "открыта %d раза", "открыта %d раз"},
+}
@@ -0,0 +1,86 @@
+package mapimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// shapeMask is an alpha mask defined by a distance predicate around a center.
+type shapeMask struct {
+	bounds image.Rectangle
+	inside func(x, y float64) bool
+}
+
+func (m *shapeMask) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+func (m *shapeMask) Bounds() image.Rectangle {
+	return m.bounds
+}
+
+func (m *shapeMask) At(x, y int) color.Color {
+	if m.inside(float64(x)+0.5, float64(y)+0.5) {
+		return color.Alpha{A: 0xff}
+	}
+
+	return color.Alpha{}
+}
+
+func drawMask(img *image.RGBA, mask *shapeMask, c color.NRGBA) {
+	bounds := mask.bounds.Intersect(img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	draw.DrawMask(img, bounds, &image.Uniform{C: c}, image.Point{}, mask, bounds.Min, draw.Over)
+}
+
+func circleBounds(cx, cy, r float64) image.Rectangle {
+	return image.Rect(int(math.Floor(cx-r)), int(math.Floor(cy-r)), int(math.Ceil(cx+r))+1, int(math.Ceil(cy+r))+1)
+}
+
+func fillCircle(img *image.RGBA, cx, cy, r float64, c color.NRGBA) {
+	drawMask(img, &shapeMask{
+		bounds: circleBounds(cx, cy, r),
+		inside: func(x, y float64) bool {
+			return math.Hypot(x-cx, y-cy) <= r
+		},
+	}, c)
+}
+
+func strokeCircle(img *image.RGBA, cx, cy, r, width float64, c color.NRGBA) {
+	drawMask(img, &shapeMask{
+		bounds: circleBounds(cx, cy, r+width),
+		inside: func(x, y float64) bool {
+			return math.Abs(math.Hypot(x-cx, y-cy)-r) <= width/2
+		},
+	}, c)
+}
+
+func drawLine(img *image.RGBA, x1, y1, x2, y2, width float64, c color.NRGBA) {
+	half := width / 2
+
+	bounds := image.Rect(
+		int(math.Floor(math.Min(x1, x2)-half)), int(math.Floor(math.Min(y1, y2)-half)),
+		int(math.Ceil(math.Max(x1, x2)+half))+1, int(math.Ceil(math.Max(y1, y2)+half))+1,
+	)
+
+	dx, dy := x2-x1, y2-y1
+	lengthSq := dx*dx + dy*dy
+
+	drawMask(img, &shapeMask{
+		bounds: bounds,
+		inside: func(x, y float64) bool {
+			// distance from the pixel to the segment
+			t := 0.0
+			if lengthSq > 0 {
+				t = math.Max(0, math.Min(1, ((x-x1)*dx+(y-y1)*dy)/lengthSq))
+			}
+
+			return math.Hypot(x-(x1+t*dx), y-(y1+t*dy)) <= half
+		},
+	}, c)
+}
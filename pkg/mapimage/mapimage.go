@@ -0,0 +1,192 @@
+// Package mapimage renders tracks, fences and positions into PNG map images.
+package mapimage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log/slog"
+	"math"
+	"time"
+)
+
+const (
+	tileSize = 256
+	minZoom  = 1
+	maxZoom  = 17
+	padding  = 40
+
+	trackWidth   = 4
+	markerRadius = 9
+
+	// tilesDeadline is how long all tiles of an image may take together,
+	// the tiles that aren't there by then are drawn as a grid
+	tilesDeadline = 10 * time.Second
+)
+
+var (
+	backgroundColor = color.NRGBA{R: 0xee, G: 0xf0, B: 0xf2, A: 0xff}
+	gridColor       = color.NRGBA{R: 0xd5, G: 0xd9, B: 0xde, A: 0xff}
+	borderColor     = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Track struct {
+	Points []Point
+	Color  color.NRGBA
+}
+
+type Circle struct {
+	Center Point
+	Radius float64 // meters
+	Color  color.NRGBA
+}
+
+type Marker struct {
+	Point Point
+	Color color.NRGBA
+}
+
+// Scene is what gets drawn. The viewport is fitted to the tracks and markers,
+// circles only define it when there is nothing else.
+type Scene struct {
+	Width   int
+	Height  int
+	Tracks  []Track
+	Circles []Circle
+	Markers []Marker
+}
+
+type Renderer struct {
+	tiles         TileSource
+	tilesDeadline time.Duration
+}
+
+// New creates a renderer, a nil tile source draws a plain grid instead of the map.
+func New(tiles TileSource) *Renderer {
+	return &Renderer{
+		tiles:         tiles,
+		tilesDeadline: tilesDeadline,
+	}
+}
+
+func (r *Renderer) Render(ctx context.Context, scene Scene) ([]byte, error) {
+	if scene.Width <= 0 || scene.Height <= 0 {
+		return nil, fmt.Errorf("invalid image size %dx%d", scene.Width, scene.Height)
+	}
+
+	view, ok := fitView(scene)
+	if !ok {
+		return nil, fmt.Errorf("nothing to render")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, scene.Width, scene.Height))
+
+	r.drawBase(ctx, img, view)
+
+	for _, c := range scene.Circles {
+		x, y := view.project(c.Center)
+		radius := c.Radius / view.metersPerPixel(c.Center.Latitude)
+
+		fill := c.Color
+		fill.A = 0x40
+
+		fillCircle(img, x, y, radius, fill)
+		strokeCircle(img, x, y, radius, 2, c.Color)
+	}
+
+	for _, t := range scene.Tracks {
+		for i := 1; i < len(t.Points); i++ {
+			x1, y1 := view.project(t.Points[i-1])
+			x2, y2 := view.project(t.Points[i])
+
+			drawLine(img, x1, y1, x2, y2, trackWidth, t.Color)
+		}
+	}
+
+	for _, m := range scene.Markers {
+		x, y := view.project(m.Point)
+
+		fillCircle(img, x, y, markerRadius+2, borderColor)
+		fillCircle(img, x, y, markerRadius, m.Color)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *Renderer) drawBase(ctx context.Context, img *image.RGBA, view view) {
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	ctx, cancel := context.WithTimeout(ctx, r.tilesDeadline)
+	defer cancel()
+
+	tileCount := 1 << view.zoom
+	timedOut := 0
+
+	minTX := int(math.Floor(view.originX / tileSize))
+	maxTX := int(math.Floor((view.originX + float64(img.Bounds().Dx())) / tileSize))
+	minTY := int(math.Floor(view.originY / tileSize))
+	maxTY := int(math.Floor((view.originY + float64(img.Bounds().Dy())) / tileSize))
+
+	for ty := max(minTY, 0); ty <= min(maxTY, tileCount-1); ty++ {
+		for tx := minTX; tx <= maxTX; tx++ {
+			dst := image.Rect(0, 0, tileSize, tileSize).Add(image.Point{
+				X: int(math.Round(float64(tx*tileSize) - view.originX)),
+				Y: int(math.Round(float64(ty*tileSize) - view.originY)),
+			})
+
+			if r.tiles != nil {
+				tile, err := r.tiles.Tile(ctx, view.zoom, ((tx%tileCount)+tileCount)%tileCount, ty)
+				if err == nil {
+					draw.Draw(img, dst, tile, tile.Bounds().Min, draw.Src)
+					continue
+				}
+
+				if ctx.Err() != nil {
+					// the rest fails the same way, the cached tiles are still drawn
+					timedOut++
+					drawGrid(img, dst)
+					continue
+				}
+
+				slog.WarnContext(ctx, "Failed to get map tile",
+					slog.Int("zoom", view.zoom),
+					slog.Int("x", tx),
+					slog.Int("y", ty),
+					slog.Any("error", err),
+				)
+			}
+
+			drawGrid(img, dst)
+		}
+	}
+
+	if timedOut > 0 {
+		slog.WarnContext(ctx, "Map tiles timed out",
+			slog.Int("tiles", timedOut),
+			slog.Duration("deadline", r.tilesDeadline),
+		)
+	}
+}
+
+func drawGrid(img *image.RGBA, tile image.Rectangle) {
+	const step = tileSize / 4
+
+	for i := 0; i < tileSize; i += step {
+		draw.Draw(img, image.Rect(tile.Min.X+i, tile.Min.Y, tile.Min.X+i+1, tile.Max.Y), &image.Uniform{C: gridColor}, image.Point{}, draw.Src)
+		draw.Draw(img, image.Rect(tile.Min.X, tile.Min.Y+i, tile.Max.X, tile.Min.Y+i+1), &image.Uniform{C: gridColor}, image.Point{}, draw.Src)
+	}
+}
@@ -0,0 +1,168 @@
+package mapimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var tileColor = color.NRGBA{R: 0x10, G: 0x80, B: 0x10, A: 0xff}
+
+// solidTiles returns the same tile for every coordinate.
+type solidTiles struct{}
+
+func (solidTiles) Tile(context.Context, int, int, int) (image.Image, error) {
+	return image.NewUniform(tileColor), nil
+}
+
+type failingTiles struct{}
+
+func (failingTiles) Tile(context.Context, int, int, int) (image.Image, error) {
+	return nil, errors.New("tile server is down")
+}
+
+// hangingTiles never answers before the context is done.
+type hangingTiles struct{}
+
+func (hangingTiles) Tile(ctx context.Context, _, _, _ int) (image.Image, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestRender(t *testing.T) {
+	scene := Scene{
+		Width:   320,
+		Height:  240,
+		Tracks:  []Track{{Points: []Point{{55.7558, 37.6173}, {55.7658, 37.6273}}, Color: color.NRGBA{R: 0xff, A: 0xff}}},
+		Markers: []Marker{{Point: Point{55.7658, 37.6273}, Color: color.NRGBA{B: 0xff, A: 0xff}}},
+	}
+
+	tests := []struct {
+		name   string
+		tiles  TileSource
+		corner []color.Color
+	}{
+		{name: "no tile source", tiles: nil, corner: []color.Color{backgroundColor, gridColor}},
+		{name: "tiles", tiles: solidTiles{}, corner: []color.Color{tileColor}},
+		{name: "failing tiles", tiles: failingTiles{}, corner: []color.Color{backgroundColor, gridColor}},
+		{name: "hanging tiles", tiles: hangingTiles{}, corner: []color.Color{backgroundColor, gridColor}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.tiles)
+			r.tilesDeadline = 50 * time.Millisecond
+
+			start := time.Now()
+
+			data, err := r.Render(context.Background(), scene)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Render() took %s despite the tiles deadline", elapsed)
+			}
+
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode png: %v", err)
+			}
+
+			if size := img.Bounds().Size(); size.X != scene.Width || size.Y != scene.Height {
+				t.Errorf("size = %v, want %dx%d", size, scene.Width, scene.Height)
+			}
+
+			corner := color.NRGBAModel.Convert(img.At(1, 1))
+			matched := false
+			for _, c := range tt.corner {
+				matched = matched || corner == color.NRGBAModel.Convert(c)
+			}
+			if !matched {
+				t.Errorf("corner pixel = %v, want one of %v", corner, tt.corner)
+			}
+		})
+	}
+}
+
+func TestRenderInvalidScene(t *testing.T) {
+	tests := []struct {
+		name  string
+		scene Scene
+	}{
+		{name: "zero size", scene: Scene{Markers: []Marker{{Point: Point{1, 1}}}}},
+		{name: "nothing to draw", scene: Scene{Width: 100, Height: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(nil).Render(context.Background(), tt.scene); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestHTTPTileSource(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("User-Agent") != "test agent" {
+			t.Errorf("user agent = %q", r.Header.Get("User-Agent"))
+		}
+
+		if r.URL.Path == "/1/0/0.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		tile := image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize))
+		draw.Draw(tile, tile.Bounds(), image.NewUniform(tileColor), image.Point{}, draw.Src)
+		_ = png.Encode(w, tile)
+	}))
+	defer server.Close()
+
+	source := NewHTTPTileSource(server.URL+"/{z}/{x}/{y}.png", "test agent", time.Second)
+
+	tests := []struct {
+		name         string
+		zoom, x, y   int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "downloaded", zoom: 2, x: 1, y: 3, wantRequests: 1},
+		{name: "cached", zoom: 2, x: 1, y: 3, wantRequests: 1},
+		{name: "another tile", zoom: 2, x: 2, y: 3, wantRequests: 2},
+		{name: "not found", zoom: 1, x: 0, y: 0, wantErr: true, wantRequests: 3},
+		{name: "errors are not cached", zoom: 1, x: 0, y: 0, wantErr: true, wantRequests: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile, err := source.Tile(context.Background(), tt.zoom, tt.x, tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Tile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && tile.Bounds().Dx() != tileSize {
+				t.Errorf("tile width = %d", tile.Bounds().Dx())
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package mapimage
+
+import "math"
+
+const earthCircumference = 40075016.686
+
+type view struct {
+	zoom    int
+	originX float64
+	originY float64
+}
+
+// worldPixel projects the point to Web Mercator pixel coordinates at the given zoom.
+func worldPixel(p Point, zoom int) (float64, float64) {
+	scale := tileSize * math.Exp2(float64(zoom))
+
+	lat := math.Max(math.Min(p.Latitude, 85.0511), -85.0511) * math.Pi / 180
+
+	x := (p.Longitude + 180) / 360 * scale
+	y := (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * scale
+
+	return x, y
+}
+
+func (v view) project(p Point) (float64, float64) {
+	x, y := worldPixel(p, v.zoom)
+
+	return x - v.originX, y - v.originY
+}
+
+func (v view) metersPerPixel(latitude float64) float64 {
+	return earthCircumference * math.Cos(latitude*math.Pi/180) / (tileSize * math.Exp2(float64(v.zoom)))
+}
+
+// fitView picks the largest zoom at which the scene fits into the image and centers it.
+func fitView(scene Scene) (view, bool) {
+	var points []Point
+	var radii []float64
+
+	for _, t := range scene.Tracks {
+		for _, p := range t.Points {
+			points = append(points, p)
+			radii = append(radii, 0)
+		}
+	}
+
+	for _, m := range scene.Markers {
+		points = append(points, m.Point)
+		radii = append(radii, 0)
+	}
+
+	if len(points) == 0 {
+		for _, c := range scene.Circles {
+			points = append(points, c.Center)
+			radii = append(radii, c.Radius)
+		}
+	}
+
+	if len(points) == 0 {
+		return view{}, false
+	}
+
+	for zoom := maxZoom; zoom >= minZoom; zoom-- {
+		v := view{zoom: zoom}
+
+		minX, minY := math.Inf(1), math.Inf(1)
+		maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+		for i, p := range points {
+			x, y := worldPixel(p, zoom)
+			r := radii[i] / v.metersPerPixel(p.Latitude)
+
+			minX, maxX = math.Min(minX, x-r), math.Max(maxX, x+r)
+			minY, maxY = math.Min(minY, y-r), math.Max(maxY, y+r)
+		}
+
+		if maxX-minX+2*padding > float64(scene.Width) || maxY-minY+2*padding > float64(scene.Height) {
+			if zoom > minZoom {
+				continue
+			}
+		}
+
+		v.originX = (minX+maxX)/2 - float64(scene.Width)/2
+		v.originY = (minY+maxY)/2 - float64(scene.Height)/2
+
+		return v, true
+	}
+
+	return view{}, false
+}
@@ -0,0 +1,105 @@
+package mapimage
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWorldPixel(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+		zoom  int
+		x, y  float64
+	}{
+		{name: "origin", point: Point{0, 0}, zoom: 0, x: 128, y: 128},
+		{name: "origin zoom 1", point: Point{0, 0}, zoom: 1, x: 256, y: 256},
+		{name: "north west corner", point: Point{85.0511, -180}, zoom: 0, x: 0, y: 0},
+		{name: "south east corner", point: Point{-85.0511, 180}, zoom: 0, x: 256, y: 256},
+		{name: "clamped beyond the pole", point: Point{89, 0}, zoom: 0, x: 128, y: 0},
+		{name: "moscow", point: Point{55.7558, 37.6173}, zoom: 10, x: 158464.1, y: 81946.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := worldPixel(tt.point, tt.zoom)
+
+			if math.Abs(x-tt.x) > 0.1 || math.Abs(y-tt.y) > 0.1 {
+				t.Errorf("worldPixel() = (%.1f, %.1f), want (%.1f, %.1f)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestMetersPerPixel(t *testing.T) {
+	tests := []struct {
+		zoom     int
+		latitude float64
+		want     float64
+	}{
+		{zoom: 0, latitude: 0, want: 156543.03},
+		{zoom: 10, latitude: 0, want: 152.87},
+		{zoom: 10, latitude: 60, want: 76.44},
+	}
+
+	for _, tt := range tests {
+		if got := (view{zoom: tt.zoom}).metersPerPixel(tt.latitude); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("metersPerPixel(zoom %d, lat %v) = %.2f, want %.2f", tt.zoom, tt.latitude, got, tt.want)
+		}
+	}
+}
+
+func TestFitView(t *testing.T) {
+	moscow := Point{55.7558, 37.6173}
+	nearby := Point{55.7658, 37.6273}
+	petersburg := Point{59.9343, 30.3351}
+
+	tests := []struct {
+		name     string
+		scene    Scene
+		wantOK   bool
+		wantZoom int
+	}{
+		{name: "empty", scene: Scene{Width: 400, Height: 300}},
+		{name: "single marker", scene: Scene{Width: 400, Height: 300, Markers: []Marker{{Point: moscow}}}, wantOK: true, wantZoom: maxZoom},
+		{name: "short track", scene: Scene{Width: 400, Height: 300, Tracks: []Track{{Points: []Point{moscow, nearby}}}}, wantOK: true, wantZoom: 14},
+		{name: "long track", scene: Scene{Width: 400, Height: 300, Tracks: []Track{{Points: []Point{moscow, petersburg}}}}, wantOK: true, wantZoom: 5},
+		{name: "circle only", scene: Scene{Width: 400, Height: 300, Circles: []Circle{{Center: moscow, Radius: 500}}}, wantOK: true, wantZoom: 14},
+		{
+			name:     "circles ignored next to markers",
+			scene:    Scene{Width: 400, Height: 300, Markers: []Marker{{Point: moscow}}, Circles: []Circle{{Center: petersburg, Radius: 500}}},
+			wantOK:   true,
+			wantZoom: maxZoom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := fitView(tt.scene)
+			if ok != tt.wantOK {
+				t.Fatalf("fitView() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if v.zoom != tt.wantZoom {
+				t.Errorf("zoom = %d, want %d", v.zoom, tt.wantZoom)
+			}
+
+			// everything that defines the view lands inside the image
+			for _, m := range tt.scene.Markers {
+				if x, y := v.project(m.Point); x < 0 || y < 0 || x > float64(tt.scene.Width) || y > float64(tt.scene.Height) {
+					t.Errorf("marker projected outside the image: (%.0f, %.0f)", x, y)
+				}
+			}
+			for _, track := range tt.scene.Tracks {
+				for _, p := range track.Points {
+					if x, y := v.project(p); x < padding || y < padding || x > float64(tt.scene.Width-padding) || y > float64(tt.scene.Height-padding) {
+						t.Errorf("track point projected outside the padding: (%.0f, %.0f)", x, y)
+					}
+				}
+			}
+		})
+	}
+}
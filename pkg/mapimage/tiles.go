@@ -0,0 +1,92 @@
+package mapimage
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+const (
+	tileCacheTTL = 24 * time.Hour
+	// tileCacheCapacity bounds the memory of the decoded tiles, about 256 KiB each
+	tileCacheCapacity = 256
+)
+
+// TileSource provides 256x256 raster map tiles in the XYZ scheme.
+type TileSource interface {
+	Tile(ctx context.Context, zoom, x, y int) (image.Image, error)
+}
+
+// HTTPTileSource downloads tiles from a URL template with {z}, {x} and {y} placeholders
+// and keeps the recently used ones in memory for up to a day.
+type HTTPTileSource struct {
+	urlTemplate string
+	userAgent   string
+	client      *http.Client
+	cache       *ttlcache.Cache[string, image.Image]
+}
+
+func NewHTTPTileSource(urlTemplate, userAgent string, timeout time.Duration) *HTTPTileSource {
+	// expired tiles are skipped by Get and the capacity evicts them, so no janitor goroutine is needed
+	cache := ttlcache.New[string, image.Image](
+		ttlcache.WithTTL[string, image.Image](tileCacheTTL),
+		ttlcache.WithCapacity[string, image.Image](tileCacheCapacity),
+		ttlcache.WithDisableTouchOnHit[string, image.Image](),
+	)
+
+	return &HTTPTileSource{
+		urlTemplate: urlTemplate,
+		userAgent:   userAgent,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		cache: cache,
+	}
+}
+
+func (s *HTTPTileSource) Tile(ctx context.Context, zoom, x, y int) (image.Image, error) {
+	url := strings.NewReplacer(
+		"{z}", strconv.Itoa(zoom),
+		"{x}", strconv.Itoa(x),
+		"{y}", strconv.Itoa(y),
+	).Replace(s.urlTemplate)
+
+	if item := s.cache.Get(url); item != nil {
+		return item.Value(), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// tile servers such as OpenStreetMap reject requests without an identifying user agent
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	tile, _, err := image.Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode tile: %w", err)
+	}
+
+	s.cache.Set(url, tile, ttlcache.DefaultTTL)
+
+	return tile, nil
+}
@@ -0,0 +1,52 @@
+package maplink
+
+// Mode is the travel mode of a route.
+type Mode string
+
+const (
+	Driving Mode = "driving"
+	Walking Mode = "walking"
+	Transit Mode = "transit"
+)
+
+// Provider builds links to a map service.
+type Provider interface {
+	Point(lat, lon float64) string
+	Route(fromLat, fromLon, toLat, toLon float64, mode Mode) string
+}
+
+const (
+	NameYandex = "yandex"
+	NameGoogle = "google"
+	NameOSM    = "osm"
+	Name2GIS   = "2gis"
+	NameGeo    = "geo"
+)
+
+// Names lists the providers in the order they are offered.
+var Names = []string{NameYandex, NameGoogle, NameOSM, Name2GIS, NameGeo}
+
+var providers = map[string]Provider{
+	NameYandex: Yandex{},
+	NameGoogle: Google{},
+	NameOSM:    OSM{},
+	Name2GIS:   TwoGIS{},
+	NameGeo:    Geo{},
+}
+
+var Default Provider = Yandex{}
+
+// Get returns the provider with the name, Default for unknown names.
+func Get(name string) Provider {
+	if provider, ok := providers[name]; ok {
+		return provider
+	}
+
+	return Default
+}
+
+// Valid reports whether there is a provider with the name.
+func Valid(name string) bool {
+	_, ok := providers[name]
+	return ok
+}
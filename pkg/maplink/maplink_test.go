@@ -0,0 +1,102 @@
+package maplink
+
+import "testing"
+
+func TestProviders(t *testing.T) {
+	const lat, lon = 55.75, 37.61
+	const toLat, toLon = 59.93, 30.36
+
+	tests := []struct {
+		name      string
+		point     string
+		driving   string
+		transit   string
+		provider  Provider
+		knownName bool
+	}{
+		{
+			name:      NameYandex,
+			provider:  Yandex{},
+			point:     "https://maps.yandex.ru?pt=37.610000,55.750000&z=17",
+			driving:   "https://maps.yandex.ru?rtext=55.750000,37.610000~59.930000,30.360000&rtt=auto",
+			transit:   "https://maps.yandex.ru?rtext=55.750000,37.610000~59.930000,30.360000&rtt=mt",
+			knownName: true,
+		},
+		{
+			name:      NameGoogle,
+			provider:  Google{},
+			point:     "https://www.google.com/maps/search/?api=1&query=55.750000,37.610000",
+			driving:   "https://www.google.com/maps/dir/?api=1&origin=55.750000,37.610000&destination=59.930000,30.360000&travelmode=driving",
+			transit:   "https://www.google.com/maps/dir/?api=1&origin=55.750000,37.610000&destination=59.930000,30.360000&travelmode=transit",
+			knownName: true,
+		},
+		{
+			name:      NameOSM,
+			provider:  OSM{},
+			point:     "https://www.openstreetmap.org/?mlat=55.750000&mlon=37.610000#map=17/55.750000/37.610000",
+			driving:   "https://www.openstreetmap.org/directions?engine=fossgis_osrm_car&route=55.750000,37.610000;59.930000,30.360000",
+			transit:   "https://www.openstreetmap.org/directions?engine=fossgis_osrm_foot&route=55.750000,37.610000;59.930000,30.360000",
+			knownName: true,
+		},
+		{
+			name:      Name2GIS,
+			provider:  TwoGIS{},
+			point:     "https://2gis.ru/geo/37.610000,55.750000",
+			driving:   "https://2gis.ru/directions/tab/car/points/37.610000,55.750000|30.360000,59.930000",
+			transit:   "https://2gis.ru/directions/tab/bus/points/37.610000,55.750000|30.360000,59.930000",
+			knownName: true,
+		},
+		{
+			name:      NameGeo,
+			provider:  Geo{},
+			point:     "geo:55.750000,37.610000?q=55.750000,37.610000",
+			driving:   "geo:59.930000,30.360000?q=59.930000,30.360000",
+			transit:   "geo:59.930000,30.360000?q=59.930000,30.360000",
+			knownName: true,
+		},
+		{
+			name:     "unknown",
+			provider: Default,
+			point:    "https://maps.yandex.ru?pt=37.610000,55.750000&z=17",
+			driving:  "https://maps.yandex.ru?rtext=55.750000,37.610000~59.930000,30.360000&rtt=auto",
+			transit:  "https://maps.yandex.ru?rtext=55.750000,37.610000~59.930000,30.360000&rtt=mt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Valid(tt.name) != tt.knownName {
+				t.Errorf("Valid(%q) = %v, want %v", tt.name, !tt.knownName, tt.knownName)
+			}
+
+			provider := Get(tt.name)
+			if provider != tt.provider {
+				t.Errorf("Get(%q) = %T, want %T", tt.name, provider, tt.provider)
+			}
+
+			if got := provider.Point(lat, lon); got != tt.point {
+				t.Errorf("Point() = %q, want %q", got, tt.point)
+			}
+
+			if got := provider.Route(lat, lon, toLat, toLon, Driving); got != tt.driving {
+				t.Errorf("Route(Driving) = %q, want %q", got, tt.driving)
+			}
+
+			if got := provider.Route(lat, lon, toLat, toLon, Transit); got != tt.transit {
+				t.Errorf("Route(Transit) = %q, want %q", got, tt.transit)
+			}
+		})
+	}
+}
+
+func TestNamesAreRegistered(t *testing.T) {
+	if len(Names) != len(providers) {
+		t.Errorf("Names has %d entries, %d providers are registered", len(Names), len(providers))
+	}
+
+	for _, name := range Names {
+		if !Valid(name) {
+			t.Errorf("provider %q is listed but not registered", name)
+		}
+	}
+}
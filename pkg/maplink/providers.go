@@ -0,0 +1,71 @@
+package maplink
+
+import "fmt"
+
+// Yandex links open in the Yandex Maps app when it is installed.
+// https://yandex.ru/dev/yandex-apps-launch-maps/doc/ru/concepts/yandexmaps-ios-app
+type Yandex struct{}
+
+func (Yandex) Point(lat, lon float64) string {
+	return fmt.Sprintf("https://maps.yandex.ru?pt=%.6f,%.6f&z=17", lon, lat)
+}
+
+func (Yandex) Route(fromLat, fromLon, toLat, toLon float64, mode Mode) string {
+	rtt := map[Mode]string{Driving: "auto", Walking: "pd", Transit: "mt"}[mode]
+
+	return fmt.Sprintf("https://maps.yandex.ru?rtext=%.6f,%.6f~%.6f,%.6f&rtt=%s", fromLat, fromLon, toLat, toLon, rtt)
+}
+
+// Google uses the cross-platform Maps URLs.
+// https://developers.google.com/maps/documentation/urls/get-started
+type Google struct{}
+
+func (Google) Point(lat, lon float64) string {
+	return fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%.6f,%.6f", lat, lon)
+}
+
+func (Google) Route(fromLat, fromLon, toLat, toLon float64, mode Mode) string {
+	return fmt.Sprintf("https://www.google.com/maps/dir/?api=1&origin=%.6f,%.6f&destination=%.6f,%.6f&travelmode=%s",
+		fromLat, fromLon, toLat, toLon, mode)
+}
+
+// OSM has no public transport routing, transit routes are walking ones.
+type OSM struct{}
+
+func (OSM) Point(lat, lon float64) string {
+	return fmt.Sprintf("https://www.openstreetmap.org/?mlat=%.6f&mlon=%.6f#map=17/%.6f/%.6f", lat, lon, lat, lon)
+}
+
+func (OSM) Route(fromLat, fromLon, toLat, toLon float64, mode Mode) string {
+	engine := "fossgis_osrm_foot"
+	if mode == Driving {
+		engine = "fossgis_osrm_car"
+	}
+
+	return fmt.Sprintf("https://www.openstreetmap.org/directions?engine=%s&route=%.6f,%.6f;%.6f,%.6f",
+		engine, fromLat, fromLon, toLat, toLon)
+}
+
+type TwoGIS struct{}
+
+func (TwoGIS) Point(lat, lon float64) string {
+	return fmt.Sprintf("https://2gis.ru/geo/%.6f,%.6f", lon, lat)
+}
+
+func (TwoGIS) Route(fromLat, fromLon, toLat, toLon float64, mode Mode) string {
+	tab := map[Mode]string{Driving: "car", Walking: "pedestrian", Transit: "bus"}[mode]
+
+	return fmt.Sprintf("https://2gis.ru/directions/tab/%s/points/%.6f,%.6f|%.6f,%.6f", tab, fromLon, fromLat, toLon, toLat)
+}
+
+// Geo opens the default map app of the device (RFC 5870). The scheme has no routes,
+// so a route is a link to its destination.
+type Geo struct{}
+
+func (Geo) Point(lat, lon float64) string {
+	return fmt.Sprintf("geo:%.6f,%.6f?q=%.6f,%.6f", lat, lon, lat, lon)
+}
+
+func (g Geo) Route(_, _, toLat, toLon float64, _ Mode) string {
+	return g.Point(toLat, toLon)
+}
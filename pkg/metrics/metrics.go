@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const namespace = "beacon"
+
+var (
+	Ingests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ingests_total",
+		Help:      "Updates ingested by account, protocol and result.",
+	}, []string{"account", "protocol", "result"})
+
+	IngestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ingest_duration_seconds",
+		Help:      "Time to process an update, including alerts and webhooks.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"protocol"})
+
+	LocationAccuracy = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "location_accuracy_meters",
+		Help:      "Reported accuracy of the ingested locations.",
+		Buckets:   []float64{5, 10, 20, 35, 50, 100, 200, 500, 1000, 5000},
+	}, []string{"account"})
+
+	FenceTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fence_transitions_total",
+		Help:      "Fence entries and exits by account, fence and direction.",
+	}, []string{"account", "fence", "direction"})
+
+	OfflineAccounts = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "offline_accounts",
+		Help:      "Accounts considered offline by the last offline check.",
+	})
+
+	LastUpdate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_update_timestamp_seconds",
+		Help:      "Unix time of the account's last update, subtract from time() for the age.",
+	}, []string{"account"})
+
+	TelegramRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_requests_total",
+		Help:      "Bot API calls by method and result, the result is ok or the error type.",
+	}, []string{"method", "result"})
+
+	RateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by the rate limits by limit.",
+	}, []string{"limit"})
+)
+
+type partialDeleter interface {
+	prometheus.Collector
+	DeletePartialMatch(labels prometheus.Labels) int
+}
+
+// PruneAccounts deletes the series of the accounts missing from names, i.e. the renamed and deleted ones.
+func PruneAccounts(names []string) {
+	prune("account", names, Ingests, LocationAccuracy, FenceTransitions, LastUpdate)
+}
+
+// PruneFences deletes the series of the fences missing from names.
+func PruneFences(names []string) {
+	prune("fence", names, FenceTransitions)
+}
+
+func prune(label string, keep []string, vecs ...partialDeleter) {
+	for _, vec := range vecs {
+		for _, value := range labelValues(vec, label) {
+			if !slices.Contains(keep, value) {
+				vec.DeletePartialMatch(prometheus.Labels{label: value})
+			}
+		}
+	}
+}
+
+// labelValues lists the distinct values of the label among the series of the collector.
+func labelValues(collector prometheus.Collector, label string) []string {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(ch)
+		close(ch)
+	}()
+
+	var result []string
+
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			continue
+		}
+
+		for _, pair := range m.GetLabel() {
+			if pair.GetName() == label && !slices.Contains(result, pair.GetValue()) {
+				result = append(result, pair.GetValue())
+			}
+		}
+	}
+
+	return result
+}
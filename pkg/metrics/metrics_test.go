@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name   string
+		series [][]string
+		keep   []string
+		want   []string
+	}{
+		{
+			name:   "keeps current accounts",
+			series: [][]string{{"alice", "home"}, {"bob", "home"}},
+			keep:   []string{"alice", "bob"},
+			want:   []string{"alice", "bob"},
+		},
+		{
+			name:   "drops every series of a missing account",
+			series: [][]string{{"alice", "home"}, {"alice", "work"}, {"bob", "home"}},
+			keep:   []string{"bob"},
+			want:   []string{"bob"},
+		},
+		{
+			name:   "drops everything without accounts",
+			series: [][]string{{"alice", "home"}},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total"}, []string{"account", "fence"})
+			for _, labels := range tt.series {
+				vec.WithLabelValues(labels...).Inc()
+			}
+
+			prune("account", tt.keep, vec)
+
+			got := labelValues(vec, "account")
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("accounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -48,7 +48,7 @@ func FiberMiddleware(app *fiber.App, di *do.Injector) {
 		return ctx.Next()
 	})
 
-	ignorePaths := []string{"/api/healthz"}
+	ignorePaths := []string{"/api/healthz", "/api/readyz", cfg.Telegram.WebhookPath}
 
 	// log requests
 	app.Use(NewLogWithConfig(slog.Default(), LogConfig{
@@ -2,47 +2,105 @@ package migration
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	mapset "github.com/deckarep/golang-set/v2"
-	"github.com/elliotchance/pie/v2"
+	"log/slog"
+	"roflbeacon2/pkg/database"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/do"
-	"log/slog"
-	"roflbeacon2/pkg/database"
-	"time"
 )
 
+// Migration is a schema or data change, migrations run once each in the order of their ids.
+// Ids start with a zero-padded sequence number shared by the sql scripts and goMigrations.
 type Migration interface {
 	Id() string
 	Execute(ctx context.Context, di *do.Injector, tx pgx.Tx, queries *database.Queries) error
 }
 
-var allMigrations = []Migration{}
+// Reversible migrations can be undone by Rollback.
+type Reversible interface {
+	Migration
+	Rollback(ctx context.Context, di *do.Injector, tx pgx.Tx, queries *database.Queries) error
+}
+
+type checksummed interface {
+	Checksum() string
+}
+
+// lockKey is the advisory lock held while migrating, concurrent runners wait for each other on it.
+const lockKey int64 = 0x726f666c62656163
+
+// goMigrations are the changes that can't be expressed in sql, e.g. rewriting JSON documents.
+var goMigrations = []Migration{}
+
+var allMigrations = sync.OnceValues(func() ([]Migration, error) {
+	sqlMigrations, err := loadSQLMigrations(sqlFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	result := slices.Concat(sqlMigrations, goMigrations)
+	slices.SortFunc(result, func(a, b Migration) int {
+		return strings.Compare(a.Id(), b.Id())
+	})
+
+	for i := 1; i < len(result); i++ {
+		if result[i].Id() == result[i-1].Id() {
+			return nil, fmt.Errorf("duplicate migration id %s", result[i].Id())
+		}
+	}
+
+	return result, nil
+})
+
+func checksum(migration Migration) string {
+	if c, ok := migration.(checksummed); ok {
+		return c.Checksum()
+	}
+
+	return ""
+}
+
+// State is a migration known to the build or recorded in the database.
+type State struct {
+	ID string
+	// Applied is nil if the migration is pending
+	Applied *time.Time
+	// Modified is set if the script changed after it was applied
+	Modified bool
+	// Unknown is set if the database has the migration but the build doesn't
+	Unknown bool
+}
 
 func doExecute(
 	ctx context.Context,
 	di *do.Injector,
-	dbConn *pgxpool.Pool,
-	queries *database.Queries,
+	conn *pgxpool.Conn,
 	migration Migration,
 ) error {
-	tx, err := dbConn.Begin(ctx)
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck
 
-	qtx := queries.WithTx(tx)
+	qtx := database.New(tx)
 
 	if err = migration.Execute(ctx, di, tx, qtx); err != nil {
 		return fmt.Errorf("error executing migration body: %w", err)
 	}
 
-	if _, err = queries.CreateMigration(ctx, database.CreateMigrationParams{
-		ID:      migration.Id(),
-		Applied: time.Now(),
+	if _, err = qtx.CreateMigration(ctx, database.CreateMigrationParams{
+		ID:       migration.Id(),
+		Applied:  time.Now(),
+		Checksum: checksum(migration),
 	}); err != nil {
 		return fmt.Errorf("error inserting migration: %w", err)
 	}
@@ -54,46 +112,302 @@ func doExecute(
 	return nil
 }
 
-func Migrate(ctx context.Context, di *do.Injector) error {
-	slog.LogAttrs(ctx, slog.LevelInfo, "Executing migrations...")
+func doRollback(
+	ctx context.Context,
+	di *do.Injector,
+	conn *pgxpool.Conn,
+	migration Reversible,
+) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
 
-	dbConn := do.MustInvoke[*pgxpool.Pool](di)
-	queries := do.MustInvoke[*database.Queries](di)
+	qtx := database.New(tx)
+
+	if err = migration.Rollback(ctx, di, tx, qtx); err != nil {
+		return fmt.Errorf("error rolling back migration body: %w", err)
+	}
+
+	if err = qtx.DeleteMigration(ctx, migration.Id()); err != nil {
+		return fmt.Errorf("error deleting migration: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// withLock runs fn on a connection holding the migration lock.
+func withLock(ctx context.Context, dbConn *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := dbConn.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	queries := database.New(conn)
+
+	if err = queries.AcquireMigrationLock(ctx, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if err := queries.ReleaseMigrationLock(context.WithoutCancel(ctx), lockKey); err != nil {
+			slog.ErrorContext(ctx, "Failed to release migration lock",
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return fn(conn)
+}
+
+// applied returns the recorded migrations by id, none if the bookkeeping table doesn't exist yet.
+func applied(ctx context.Context, queries *database.Queries) (map[string]database.Migration, error) {
+	state, err := queries.GetMigrationTableState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not check migration table: %w", err)
+	}
+	if !state.TableExists {
+		return map[string]database.Migration{}, nil
+	}
+
+	// databases created before the checksums have the table without the column
+	if !state.HasChecksum {
+		if err = queries.UpgradeMigrationTable(ctx); err != nil {
+			return nil, fmt.Errorf("could not upgrade migration table: %w", err)
+		}
+	}
 
 	executedMigrations, err := queries.GetMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get migrations: %w", err)
+		return nil, fmt.Errorf("could not get migrations: %w", err)
 	}
 
-	executedIds := mapset.NewThreadUnsafeSet[string]()
+	result := make(map[string]database.Migration, len(executedMigrations))
 	for _, migration := range executedMigrations {
-		executedIds.Add(migration.ID)
+		result[migration.ID] = migration
+	}
+
+	return result, nil
+}
+
+// checkDrift fails if an applied script was edited or the database is ahead of the build.
+func checkDrift(known []Migration, executed map[string]database.Migration) error {
+	var problems []error
+
+	for _, migration := range known {
+		record, ok := executed[migration.Id()]
+		if ok && record.Checksum != "" && record.Checksum != checksum(migration) {
+			problems = append(problems, fmt.Errorf("migration %s was modified after it was applied", migration.Id()))
+		}
+	}
+
+	for id := range executed {
+		if !slices.ContainsFunc(known, func(m Migration) bool { return m.Id() == id }) {
+			problems = append(problems, fmt.Errorf("migration %s is applied but unknown to this build", id))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+func pending(known []Migration, executed map[string]database.Migration) []Migration {
+	var result []Migration
+
+	for _, migration := range known {
+		if _, ok := executed[migration.Id()]; !ok {
+			result = append(result, migration)
+		}
+	}
+
+	return result
+}
+
+// Status lists the known migrations in the order they run, followed by the unknown applied ones.
+func Status(ctx context.Context, di *do.Injector) ([]State, error) {
+	queries := do.MustInvoke[*database.Queries](di)
+
+	known, err := allMigrations()
+	if err != nil {
+		return nil, err
 	}
 
-	pendingMigrations := pie.Filter(allMigrations, func(m Migration) bool {
-		return !executedIds.Contains(m.Id())
+	executed, err := applied(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []State
+
+	for _, migration := range known {
+		state := State{ID: migration.Id()}
+
+		if record, ok := executed[migration.Id()]; ok {
+			state.Applied = &record.Applied
+			state.Modified = record.Checksum != "" && record.Checksum != checksum(migration)
+			delete(executed, migration.Id())
+		}
+
+		result = append(result, state)
+	}
+
+	for _, record := range executed {
+		result = append(result, State{ID: record.ID, Applied: &record.Applied, Unknown: true})
+	}
+
+	slices.SortStableFunc(result[len(known):], func(a, b State) int {
+		return strings.Compare(a.ID, b.ID)
 	})
 
-	if len(pendingMigrations) == 0 {
-		slog.LogAttrs(ctx, slog.LevelInfo, "No pending migrations")
-		return nil
+	return result, nil
+}
+
+// Pending returns the migrations that Migrate would execute.
+func Pending(ctx context.Context, di *do.Injector) ([]Migration, error) {
+	known, err := allMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	executed, err := applied(ctx, do.MustInvoke[*database.Queries](di))
+	if err != nil {
+		return nil, err
+	}
+
+	return pending(known, executed), nil
+}
+
+// Verify fails unless every migration is applied unchanged and the schema matches the generated queries.
+func Verify(ctx context.Context, di *do.Injector) error {
+	queries := do.MustInvoke[*database.Queries](di)
+
+	known, err := allMigrations()
+	if err != nil {
+		return err
+	}
+
+	executed, err := applied(ctx, queries)
+	if err != nil {
+		return err
+	}
+
+	if err = checkDrift(known, executed); err != nil {
+		return fmt.Errorf("migrations drifted: %w", err)
+	}
+
+	if left := pending(known, executed); len(left) > 0 {
+		return fmt.Errorf("%d pending migrations, starting with %s", len(left), left[0].Id())
+	}
+
+	if err = database.CheckSchema(ctx, queries); err != nil {
+		return fmt.Errorf("schema drifted: %w", err)
+	}
+
+	return nil
+}
+
+// Migrate applies the pending migrations and checks the resulting schema.
+func Migrate(ctx context.Context, di *do.Injector) error {
+	slog.LogAttrs(ctx, slog.LevelInfo, "Executing migrations...")
+
+	dbConn := do.MustInvoke[*pgxpool.Pool](di)
+
+	known, err := allMigrations()
+	if err != nil {
+		return err
 	}
 
-	for _, migration := range pendingMigrations {
-		slog.LogAttrs(ctx, slog.LevelInfo, "Starting migration",
-			slog.String("id", migration.Id()),
-		)
+	err = withLock(ctx, dbConn, func(conn *pgxpool.Conn) error {
+		executed, err := applied(ctx, database.New(conn))
+		if err != nil {
+			return err
+		}
 
-		if err = doExecute(ctx, di, dbConn, queries, migration); err != nil {
-			return fmt.Errorf("could not execute migration %v: %w", migration.Id(), err)
+		if err = checkDrift(known, executed); err != nil {
+			return fmt.Errorf("migrations drifted: %w", err)
 		}
 
-		slog.LogAttrs(ctx, slog.LevelInfo, "Migration success",
-			slog.String("id", migration.Id()),
-		)
+		pendingMigrations := pending(known, executed)
+
+		if len(pendingMigrations) == 0 {
+			slog.LogAttrs(ctx, slog.LevelInfo, "No pending migrations")
+			return nil
+		}
+
+		for _, migration := range pendingMigrations {
+			slog.LogAttrs(ctx, slog.LevelInfo, "Starting migration",
+				slog.String("id", migration.Id()),
+			)
+
+			if err = doExecute(ctx, di, conn, migration); err != nil {
+				return fmt.Errorf("could not execute migration %v: %w", migration.Id(), err)
+			}
+
+			slog.LogAttrs(ctx, slog.LevelInfo, "Migration success",
+				slog.String("id", migration.Id()),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = database.CheckSchema(ctx, do.MustInvoke[*database.Queries](di)); err != nil {
+		return fmt.Errorf("schema drifted: %w", err)
 	}
 
 	log.Info("Migrations complete")
 
 	return nil
 }
+
+// Rollback undoes the last steps applied migrations, newest first.
+func Rollback(ctx context.Context, di *do.Injector, steps int) error {
+	dbConn := do.MustInvoke[*pgxpool.Pool](di)
+
+	known, err := allMigrations()
+	if err != nil {
+		return err
+	}
+
+	return withLock(ctx, dbConn, func(conn *pgxpool.Conn) error {
+		executed, err := applied(ctx, database.New(conn))
+		if err != nil {
+			return err
+		}
+
+		if err = checkDrift(known, executed); err != nil {
+			return fmt.Errorf("migrations drifted: %w", err)
+		}
+
+		for i := len(known) - 1; i >= 0 && steps > 0; i-- {
+			migration := known[i]
+			if _, ok := executed[migration.Id()]; !ok {
+				continue
+			}
+
+			reversible, ok := migration.(Reversible)
+			if !ok {
+				return fmt.Errorf("migration %s can't be rolled back", migration.Id())
+			}
+
+			slog.LogAttrs(ctx, slog.LevelInfo, "Rolling back migration",
+				slog.String("id", migration.Id()),
+			)
+
+			if err = doRollback(ctx, di, conn, reversible); err != nil {
+				return fmt.Errorf("could not roll back migration %v: %w", migration.Id(), err)
+			}
+
+			steps--
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package migration
+
+import (
+	"roflbeacon2/pkg/database"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func migrations(ups ...string) []Migration {
+	var result []Migration
+	for i, up := range ups {
+		result = append(result, sqlMigration{id: string(rune('1'+i)) + "_m", up: up})
+	}
+
+	return result
+}
+
+func records(known []Migration, checksums ...string) map[string]database.Migration {
+	result := make(map[string]database.Migration)
+	for i, sum := range checksums {
+		result[known[i].Id()] = database.Migration{ID: known[i].Id(), Checksum: sum}
+	}
+
+	return result
+}
+
+func TestCheckDrift(t *testing.T) {
+	known := migrations("create table a ();", "create table b ();")
+
+	tests := []struct {
+		name     string
+		executed map[string]database.Migration
+		wantErr  bool
+	}{
+		{name: "nothing applied", executed: records(known)},
+		{name: "all applied", executed: records(known, checksum(known[0]), checksum(known[1]))},
+		{name: "partially applied", executed: records(known, checksum(known[0]))},
+		{name: "applied before checksums", executed: records(known, "", "")},
+		{name: "modified", executed: records(known, checksum(known[0]), "edited"), wantErr: true},
+		{
+			name:     "unknown",
+			executed: map[string]database.Migration{"9_future": {ID: "9_future"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkDrift(known, tt.executed); (err != nil) != tt.wantErr {
+				t.Errorf("checkDrift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	known := migrations("create table a ();", "create table b ();", "create table c ();")
+
+	tests := []struct {
+		name     string
+		executed map[string]database.Migration
+		want     []string
+	}{
+		{name: "fresh database", executed: records(known), want: []string{"1_m", "2_m", "3_m"}},
+		{name: "some applied", executed: records(known, "", ""), want: []string{"3_m"}},
+		{name: "up to date", executed: records(known, "", "", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range pending(known, tt.executed) {
+				got = append(got, m.Id())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSQLMigrations(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		want     []string
+		wantDown []bool
+		wantErr  bool
+	}{
+		{
+			name: "up and down",
+			files: fstest.MapFS{
+				"sql/0001_init.up.sql":    {Data: []byte("create table a ();")},
+				"sql/0001_init.down.sql":  {Data: []byte("drop table a;")},
+				"sql/0002_more.up.sql":    {Data: []byte("create table b ();")},
+				"sql/0003_notes.down.sql": {},
+				"sql/0003_notes.up.sql":   {Data: []byte("create table c ();")},
+			},
+			want:     []string{"0001_init", "0002_more", "0003_notes"},
+			wantDown: []bool{true, false, false},
+		},
+		{
+			name:    "down without up",
+			files:   fstest.MapFS{"sql/0001_init.down.sql": {Data: []byte("drop table a;")}},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected name",
+			files:   fstest.MapFS{"sql/0001_init.sql": {Data: []byte("create table a ();")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadSQLMigrations(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadSQLMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var ids []string
+			var downs []bool
+			for _, m := range got {
+				ids = append(ids, m.Id())
+				downs = append(downs, m.(sqlMigration).down != "")
+			}
+
+			if !slices.Equal(ids, tt.want) || !slices.Equal(downs, tt.wantDown) {
+				t.Errorf("loadSQLMigrations() = %v with down scripts %v, want %v with %v", ids, downs, tt.want, tt.wantDown)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	known, err := allMigrations()
+	if err != nil {
+		t.Fatalf("allMigrations() error = %v", err)
+	}
+
+	if len(known) == 0 {
+		t.Fatal("no migrations are embedded")
+	}
+
+	for _, m := range known {
+		if checksum(m) == "" {
+			t.Errorf("migration %s has no checksum", m.Id())
+		}
+	}
+}
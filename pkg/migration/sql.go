@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"roflbeacon2/pkg/database"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+)
+
+// sqlFiles are NNNN_name.up.sql scripts with optional NNNN_name.down.sql counterparts,
+// sqlc reads the same directory as the schema so the up scripts must stay parseable by it.
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type sqlMigration struct {
+	id   string
+	up   string
+	down string
+}
+
+func (m sqlMigration) Id() string {
+	return m.id
+}
+
+// Checksum guards against editing a script after it has been applied somewhere.
+func (m sqlMigration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.up))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (m sqlMigration) Execute(ctx context.Context, _ *do.Injector, tx pgx.Tx, _ *database.Queries) error {
+	if _, err := tx.Exec(ctx, m.up); err != nil {
+		return fmt.Errorf("sql exec error: %w", err)
+	}
+
+	return nil
+}
+
+func (m sqlMigration) Rollback(ctx context.Context, _ *do.Injector, tx pgx.Tx, _ *database.Queries) error {
+	if m.down == "" {
+		return fmt.Errorf("migration %s has no down script", m.id)
+	}
+
+	if _, err := tx.Exec(ctx, m.down); err != nil {
+		return fmt.Errorf("sql exec error: %w", err)
+	}
+
+	return nil
+}
+
+// loadSQLMigrations reads the scripts under sql/ of files, sqlFiles outside of tests.
+func loadSQLMigrations(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "sql/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list sql migrations: %w", err)
+	}
+
+	byID := make(map[string]*sqlMigration)
+	var ids []string
+
+	for _, name := range names {
+		base := strings.TrimPrefix(name, "sql/")
+
+		id, direction, ok := strings.Cut(strings.TrimSuffix(base, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("unexpected migration file name %s", base)
+		}
+
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", base, err)
+		}
+
+		m, ok := byID[id]
+		if !ok {
+			m = &sqlMigration{id: id}
+			byID[id] = m
+			ids = append(ids, id)
+		}
+
+		if direction == "up" {
+			m.up = string(data)
+		} else {
+			m.down = string(data)
+		}
+	}
+
+	result := make([]Migration, 0, len(ids))
+
+	for _, id := range ids {
+		if byID[id].up == "" {
+			return nil, fmt.Errorf("migration %s has no up script", id)
+		}
+
+		result = append(result, *byID[id])
+	}
+
+	return result, nil
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bot_state, live_location, share_access, share_link, proximity_rule, watch, sos_event,
+    sos_incident, alert_outbox, webhook_delivery, webhook_subscription, fence, updates, account;
@@ -0,0 +1,193 @@
+CREATE TABLE IF NOT EXISTS account
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    token              VARCHAR(255) NOT NULL UNIQUE,
+    name               VARCHAR(255) NOT NULL UNIQUE,
+    chat_id            BIGINT,
+    status             JSONB        NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_account_chat_id ON account (chat_id);
+ALTER TABLE account ADD COLUMN IF NOT EXISTS settings JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS updates
+(
+    id         BIGSERIAL PRIMARY KEY,
+    account_id BIGINT    NOT NULL,
+    created    TIMESTAMP NOT NULL,
+    data       JSONB     NOT NULL,
+    CONSTRAINT fk_updates_account FOREIGN KEY (account_id) REFERENCES account (id)
+);
+CREATE INDEX IF NOT EXISTS idx_updates_account_id ON updates (account_id);
+CREATE INDEX IF NOT EXISTS idx_updates_account_id_created_desc ON updates (account_id, created DESC);
+CREATE INDEX IF NOT EXISTS idx_updates_created ON updates (created);
+
+CREATE TABLE IF NOT EXISTS fence
+(
+    id        BIGSERIAL PRIMARY KEY,
+    name      VARCHAR(255)     NOT NULL UNIQUE,
+    longitude DOUBLE PRECISION NOT NULL,
+    latitude  DOUBLE PRECISION NOT NULL,
+    radius    DOUBLE PRECISION NOT NULL
+);
+ALTER TABLE fence ADD COLUMN IF NOT EXISTS settings JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS migration
+(
+    id      VARCHAR(255) PRIMARY KEY,
+    applied TIMESTAMP NOT NULL
+);
+ALTER TABLE migration ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS webhook_subscription
+(
+    id      BIGSERIAL PRIMARY KEY,
+    url     TEXT          NOT NULL,
+    secret  VARCHAR(255)  NOT NULL,
+    events  VARCHAR(64)[] NOT NULL,
+    enabled BOOLEAN       NOT NULL,
+    created TIMESTAMP     NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery
+(
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  BIGINT      NOT NULL,
+    event            VARCHAR(64) NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           VARCHAR(32) NOT NULL,
+    attempts         INTEGER     NOT NULL,
+    next_attempt     TIMESTAMP   NOT NULL,
+    last_error       TEXT,
+    last_status_code INTEGER,
+    created          TIMESTAMP   NOT NULL,
+    delivered        TIMESTAMP,
+    CONSTRAINT fk_webhook_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscription (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_status_next_attempt ON webhook_delivery (status, next_attempt);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_subscription_id_created_desc ON webhook_delivery (subscription_id, created DESC);
+
+CREATE TABLE IF NOT EXISTS alert_outbox
+(
+    id           BIGSERIAL PRIMARY KEY,
+    account_id   BIGINT      NOT NULL,
+    event        VARCHAR(64) NOT NULL,
+    channel      VARCHAR(32) NOT NULL,
+    target       TEXT,
+    title        TEXT        NOT NULL,
+    text         TEXT        NOT NULL,
+    status       VARCHAR(32) NOT NULL,
+    attempts     INTEGER     NOT NULL,
+    next_attempt TIMESTAMP   NOT NULL,
+    last_error   TEXT,
+    created      TIMESTAMP   NOT NULL,
+    sent         TIMESTAMP,
+    CONSTRAINT fk_alert_outbox_account FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_alert_outbox_status_next_attempt ON alert_outbox (status, next_attempt);
+CREATE INDEX IF NOT EXISTS idx_alert_outbox_created_desc ON alert_outbox (created DESC);
+
+CREATE TABLE IF NOT EXISTS sos_incident
+(
+    id               BIGSERIAL PRIMARY KEY,
+    account_id       BIGINT    NOT NULL,
+    created          TIMESTAMP NOT NULL,
+    message          TEXT,
+    escalation_level INTEGER   NOT NULL,
+    next_escalation  TIMESTAMP NOT NULL,
+    acknowledged_by  BIGINT,
+    acknowledged     TIMESTAMP,
+    CONSTRAINT fk_sos_incident_account FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT fk_sos_incident_acknowledged_by FOREIGN KEY (acknowledged_by) REFERENCES account (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sos_incident_next_escalation ON sos_incident (next_escalation) WHERE acknowledged IS NULL;
+
+CREATE TABLE IF NOT EXISTS sos_event
+(
+    id         BIGSERIAL PRIMARY KEY,
+    sos_id     BIGINT      NOT NULL,
+    created    TIMESTAMP   NOT NULL,
+    type       VARCHAR(32) NOT NULL,
+    account_id BIGINT,
+    details    TEXT,
+    CONSTRAINT fk_sos_event_sos FOREIGN KEY (sos_id) REFERENCES sos_incident (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_sos_event_sos_id ON sos_event (sos_id);
+
+CREATE TABLE IF NOT EXISTS watch
+(
+    id        BIGSERIAL PRIMARY KEY,
+    owner_id  BIGINT           NOT NULL,
+    target_id BIGINT           NOT NULL,
+    fence_id  BIGINT,
+    distance  DOUBLE PRECISION,
+    direction VARCHAR(16)      NOT NULL,
+    created   TIMESTAMP        NOT NULL,
+    expires   TIMESTAMP        NOT NULL,
+    CONSTRAINT fk_watch_owner FOREIGN KEY (owner_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT fk_watch_target FOREIGN KEY (target_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT fk_watch_fence FOREIGN KEY (fence_id) REFERENCES fence (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_watch_target_id ON watch (target_id);
+
+CREATE TABLE IF NOT EXISTS proximity_rule
+(
+    id                BIGSERIAL PRIMARY KEY,
+    first_account_id  BIGINT           NOT NULL,
+    second_account_id BIGINT           NOT NULL,
+    distance          DOUBLE PRECISION NOT NULL,
+    notify_meet       BOOLEAN          NOT NULL,
+    notify_part       BOOLEAN          NOT NULL,
+    together          BOOLEAN          NOT NULL,
+    changed           TIMESTAMP,
+    created           TIMESTAMP        NOT NULL,
+    CONSTRAINT fk_proximity_rule_first_account FOREIGN KEY (first_account_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT fk_proximity_rule_second_account FOREIGN KEY (second_account_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT uq_proximity_rule_pair UNIQUE (first_account_id, second_account_id),
+    CONSTRAINT chk_proximity_rule_pair_order CHECK (first_account_id < second_account_id)
+);
+
+CREATE TABLE IF NOT EXISTS share_link
+(
+    id         BIGSERIAL PRIMARY KEY,
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    account_id BIGINT      NOT NULL,
+    scope      VARCHAR(16) NOT NULL,
+    precision  INTEGER     NOT NULL,
+    created    TIMESTAMP   NOT NULL,
+    expires    TIMESTAMP   NOT NULL,
+    revoked    TIMESTAMP,
+    CONSTRAINT fk_share_link_account FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_share_link_account_id ON share_link (account_id);
+
+CREATE TABLE IF NOT EXISTS share_access
+(
+    id         BIGSERIAL PRIMARY KEY,
+    share_id   BIGINT      NOT NULL,
+    created    TIMESTAMP   NOT NULL,
+    kind       VARCHAR(16) NOT NULL,
+    ip         VARCHAR(64) NOT NULL,
+    user_agent TEXT        NOT NULL,
+    CONSTRAINT fk_share_access_share FOREIGN KEY (share_id) REFERENCES share_link (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_share_access_share_id ON share_access (share_id);
+
+CREATE TABLE IF NOT EXISTS live_location
+(
+    id         BIGSERIAL PRIMARY KEY,
+    chat_id    BIGINT    NOT NULL,
+    account_id BIGINT    NOT NULL,
+    message_id BIGINT    NOT NULL,
+    created    TIMESTAMP NOT NULL,
+    expires    TIMESTAMP NOT NULL,
+    CONSTRAINT fk_live_location_account FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_live_location_account_id ON live_location (account_id);
+
+CREATE TABLE IF NOT EXISTS bot_state
+(
+    chat_id BIGINT PRIMARY KEY,
+    stage   VARCHAR(64) NOT NULL,
+    data    JSONB       NOT NULL,
+    expires TIMESTAMP   NOT NULL
+);
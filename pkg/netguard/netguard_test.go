@@ -0,0 +1,92 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "8.8.8.8", want: true},
+		{addr: "2a00:1450:4010:c05::8b", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.1.2.3", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "::", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:8.8.8.8", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := Public(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("Public(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantErr   bool
+		notPublic bool
+	}{
+		{url: "https://8.8.8.8/hook"},
+		{url: "http://8.8.8.8:8080"},
+		{url: "ftp://8.8.8.8/hook", wantErr: true},
+		{url: "file:///etc/passwd", wantErr: true},
+		{url: "https:///hook", wantErr: true},
+		{url: "http://127.0.0.1:8080/hook", wantErr: true, notPublic: true},
+		{url: "http://[::1]/hook", wantErr: true, notPublic: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true, notPublic: true},
+		{url: "http://192.168.0.10/", wantErr: true, notPublic: true},
+		{url: "http://localhost:9090/", wantErr: true, notPublic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckURL(context.Background(), tt.url)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if tt.notPublic && !errors.Is(err, ErrNotPublic) {
+				t.Errorf("CheckURL(%q) error = %v, want ErrNotPublic", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(time.Second).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to a loopback server to fail")
+	}
+
+	if !errors.Is(err, ErrNotPublic) {
+		t.Errorf("error = %v, want ErrNotPublic", err)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"roflbeacon2/app/service/health"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/do"
+)
+
+// HealthRoutes serves the probes: healthz answers as long as the process serves requests,
+// readyz also checks the database, the migrations and the background checks and fails with 503
+// until all of them are fine.
+func HealthRoutes(a *fiber.App, di *do.Injector) {
+	healthService := do.MustInvoke[*health.Service](di)
+
+	group := a.Group("/api")
+
+	group.Get("/healthz", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "ok"})
+	})
+
+	group.Get("/readyz", func(c *fiber.Ctx) error {
+		readiness := healthService.Ready(c.UserContext())
+		if !readiness.Ready {
+			c.Status(fiber.StatusServiceUnavailable)
+		}
+
+		return c.JSON(readiness)
+	})
+}
@@ -0,0 +1,169 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"roflbeacon2/app/service/limits"
+	"roflbeacon2/app/service/settings"
+	"roflbeacon2/app/service/share"
+	"roflbeacon2/pkg/database"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/do"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	sharePollInterval = 5 * time.Second
+	sharePingInterval = 30 * time.Second
+)
+
+//go:embed share.html
+var sharePage []byte
+
+// ShareRoutes serves the shared location pages. The open location streams only end with streams,
+// so it has to be cancelled before the app is shut down, the shutdown waits for them otherwise.
+func ShareRoutes(a *fiber.App, di *do.Injector, streams context.Context) {
+	shareService := do.MustInvoke[*share.Service](di)
+	limitsService := do.MustInvoke[*limits.Service](di)
+	settingsService := do.MustInvoke[*settings.Service](di)
+
+	// resolve resolves the link and logs the access, responding on its own if the link can't be served
+	resolve := func(c *fiber.Ctx, kind string) (*database.ShareLink, error) {
+		ctx := c.UserContext()
+
+		if !limitsService.AllowIpRpm(ctx, "share", settingsService.ShareRpm()) {
+			return nil, c.SendStatus(fiber.StatusTooManyRequests)
+		}
+
+		link, err := shareService.Resolve(ctx, c.Params("token"))
+		if errors.Is(err, share.ErrUnavailable) {
+			return nil, c.SendStatus(fiber.StatusNotFound)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err = shareService.LogAccess(ctx, &link, kind, c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+			return nil, err
+		}
+
+		return &link, nil
+	}
+
+	group := a.Group("/share")
+
+	group.Get("/:token", func(c *fiber.Ctx) error {
+		link, err := resolve(c, share.AccessPage)
+		if link == nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		c.Set("Referrer-Policy", "no-referrer")
+
+		return c.Send(sharePage)
+	})
+
+	group.Get("/:token/feed", func(c *fiber.Ctx) error {
+		link, err := resolve(c, share.AccessFeed)
+		if link == nil {
+			return err
+		}
+
+		feed, err := shareService.Feed(c.UserContext(), link)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderCacheControl, "no-store")
+
+		return c.JSON(feed)
+	})
+
+	group.Get("/:token/stream", func(c *fiber.Ctx) error {
+		link, err := resolve(c, share.AccessStream)
+		if link == nil {
+			return err
+		}
+
+		token := link.Token
+
+		sendEventStream(c, streams, func(ctx context.Context, w *bufio.Writer) {
+			streamShare(ctx, shareService, token, w)
+		})
+
+		return nil
+	})
+}
+
+// sendEventStream responds with the server-sent events written by stream. The fiber context is released
+// once the handler returns, so stream only gets the streams context.
+func sendEventStream(c *fiber.Ctx, streams context.Context, stream func(ctx context.Context, w *bufio.Writer)) {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		stream(streams, w)
+	}))
+}
+
+func streamShare(ctx context.Context, shareService *share.Service, token string, w *bufio.Writer) {
+	ticker := time.NewTicker(sharePollInterval)
+	defer ticker.Stop()
+
+	var lastUpdateID int64
+	lastWrite := time.Now()
+
+	for {
+		link, err := shareService.Resolve(ctx, token)
+		if errors.Is(err, share.ErrUnavailable) {
+			_, _ = fmt.Fprint(w, "event: expired\ndata: {}\n\n")
+			_ = w.Flush()
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to resolve share link",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		feed, err := shareService.Feed(ctx, &link)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to build share feed",
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		if feed.UpdateID != lastUpdateID {
+			lastUpdateID = feed.UpdateID
+
+			data, _ := json.Marshal(&feed)
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= sharePingInterval {
+			_, _ = fmt.Fprint(w, ": ping\n\n")
+			lastWrite = time.Now()
+		}
+
+		// a failed flush means that the viewer is gone
+		if err = w.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
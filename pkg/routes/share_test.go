@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestShutdownWithOpenStream(t *testing.T) {
+	tests := []struct {
+		name string
+		// streams are cancelled before the shutdown
+		stopStreams bool
+		wantDone    bool
+	}{
+		{name: "streams stopped", stopStreams: true, wantDone: true},
+		{name: "streams left open", stopStreams: false, wantDone: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, stopStreams := context.WithCancel(context.Background())
+			defer stopStreams()
+
+			app := fiber.New(fiber.Config{DisableStartupMessage: true})
+			app.Get("/stream", func(c *fiber.Ctx) error {
+				sendEventStream(c, streams, func(ctx context.Context, w *bufio.Writer) {
+					for {
+						_, _ = fmt.Fprint(w, ": ping\n\n")
+						if err := w.Flush(); err != nil {
+							return
+						}
+
+						select {
+						case <-ctx.Done():
+							return
+						case <-time.After(10 * time.Millisecond):
+						}
+					}
+				})
+
+				return nil
+			})
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("listen: %v", err)
+			}
+
+			go app.Listener(listener) //nolint:errcheck
+
+			resp, err := http.Get("http://" + listener.Addr().String() + "/stream") //nolint:noctx
+			if err != nil {
+				t.Fatalf("open stream: %v", err)
+			}
+			defer resp.Body.Close() //nolint:errcheck
+
+			if _, err = bufio.NewReader(resp.Body).ReadString('\n'); err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+
+			if tt.stopStreams {
+				stopStreams()
+			}
+
+			done := make(chan struct{})
+			go func() {
+				_ = app.Shutdown()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				if !tt.wantDone {
+					t.Fatal("shutdown finished while a stream was open")
+				}
+			case <-time.After(time.Second):
+				if tt.wantDone {
+					t.Fatal("shutdown waits for the stopped stream")
+				}
+
+				// let the pending shutdown finish
+				stopStreams()
+				<-done
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"roflbeacon2/app/service/telegram"
+	"roflbeacon2/pkg/config"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/do"
+)
+
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramRoutes mounts the bot update endpoint, it exists only in webhook mode.
+func TelegramRoutes(a *fiber.App, di *do.Injector) {
+	cfg := do.MustInvoke[*config.Config](di)
+	if cfg.Telegram.Mode != config.TelegramModeWebhook {
+		return
+	}
+
+	appCtx := do.MustInvoke[context.Context](di)
+	telegramService := do.MustInvoke[*telegram.Service](di)
+
+	secret := []byte(cfg.Telegram.WebhookSecret)
+
+	a.Post(cfg.Telegram.WebhookPath, func(c *fiber.Ctx) error {
+		if subtle.ConstantTimeCompare([]byte(c.Get(telegramSecretHeader)), secret) != 1 {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		var update models.Update
+		if err := json.Unmarshal(c.Body(), &update); err != nil {
+			return c.SendStatus(fiber.StatusBadRequest)
+		}
+
+		// the update outlives the request, so it is handled with the app context
+		telegramService.HandleUpdate(appCtx, &update)
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+}
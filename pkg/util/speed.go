@@ -0,0 +1,16 @@
+package util
+
+import "time"
+
+func SpeedMps(lat1, lon1 float64, t1 time.Time, lat2, lon2 float64, t2 time.Time) float64 {
+	seconds := t2.Sub(t1).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+
+	return HaversineDistance(lat1, lon1, lat2, lon2) / seconds
+}
+
+func MpsToKmh(mps float64) float64 {
+	return mps * 3.6
+}